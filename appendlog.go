@@ -0,0 +1,179 @@
+package tokenguard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ============================================================================
+// Append-Only Log
+// ============================================================================
+
+// appendLog is an append-only file of newline-terminated JSON records,
+// shared by the file-backed stores. It is not thread-safe; owners guard it
+// with their own lock.
+//
+// Features:
+//   - Failed appends are rolled back, leaving no partial record behind
+//   - Replay skips corrupt records and truncates a torn tail
+//   - Atomic rewrite for compaction
+type appendLog struct {
+	path string
+	name string // Used in error messages, e.g. "cache file"
+	file *os.File
+	size int64 // Current file size in bytes
+	sync bool
+}
+
+// openAppendLog opens (or creates) the log at path.
+func openAppendLog(path, name string, sync bool) (*appendLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	return &appendLog{path: path, name: name, file: file, sync: sync}, nil
+}
+
+// replay calls apply for each record in the log with its offset. A record
+// for which apply returns an error is corrupt.
+//
+// Corrupt records followed by valid ones are skipped (they stay in the
+// file until the owner compacts it). A final line without a trailing
+// newline (torn write), and any corrupt records directly before it, are
+// truncated away.
+func (l *appendLog) replay(apply func(line []byte, offset int64) error) error {
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek %s: %w", l.name, err)
+	}
+
+	reader := bufio.NewReader(l.file)
+	var offset int64
+	corruptFrom := int64(-1) // Start of the trailing run of corrupt records
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break // Any partial trailing line is discarded below
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", l.name, err)
+		}
+
+		if err := apply(line, offset); err != nil {
+			if corruptFrom < 0 {
+				corruptFrom = offset
+			}
+		} else {
+			corruptFrom = -1
+		}
+		offset += int64(len(line))
+	}
+
+	if corruptFrom >= 0 {
+		offset = corruptFrom
+	}
+	l.size = offset
+	if err := l.file.Truncate(offset); err != nil {
+		return fmt.Errorf("truncate %s: %w", l.name, err)
+	}
+
+	return nil
+}
+
+// append writes a newline-terminated record to the end of the log and
+// returns its offset. On failure the log is truncated back to its previous
+// size, so a partial record never precedes later ones.
+func (l *appendLog) append(line []byte) (int64, error) {
+	offset := l.size
+	if _, err := l.file.Write(line); err != nil {
+		l.rollback(offset)
+		return 0, fmt.Errorf("write %s: %w", l.name, err)
+	}
+
+	if l.sync {
+		if err := l.file.Sync(); err != nil {
+			l.rollback(offset)
+			return 0, fmt.Errorf("sync %s: %w", l.name, err)
+		}
+	}
+
+	l.size += int64(len(line))
+	return offset, nil
+}
+
+// rollback truncates the log to offset after a failed append. If that
+// fails too, replay skips the partial record on the next open.
+func (l *appendLog) rollback(offset int64) {
+	_ = l.file.Truncate(offset)
+}
+
+// readAt reads len(buf) bytes of the log starting at offset.
+func (l *appendLog) readAt(buf []byte, offset int64) error {
+	if _, err := l.file.ReadAt(buf, offset); err != nil {
+		return fmt.Errorf("read %s: %w", l.name, err)
+	}
+	return nil
+}
+
+// rewrite replaces the log with the records written by fill.
+//
+// The new log is written to a temporary file and atomically renamed over
+// the old one, so a crash during compaction leaves the old log intact.
+func (l *appendLog) rewrite(fill func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".compact-*")
+	if err != nil {
+		return fmt.Errorf("create compaction file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // No-op after successful rename
+
+	writer := bufio.NewWriter(tmp)
+	if err := fill(writer); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write compaction file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync compaction file: %w", err)
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("stat compaction file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close compaction file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return fmt.Errorf("replace %s: %w", l.name, err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("reopen %s: %w", l.name, err)
+	}
+	_ = l.file.Close()
+
+	l.file = file
+	l.size = info.Size()
+
+	return nil
+}
+
+// close flushes and closes the log file.
+func (l *appendLog) close() error {
+	if err := l.file.Sync(); err != nil {
+		_ = l.file.Close()
+		return fmt.Errorf("sync %s: %w", l.name, err)
+	}
+	return l.file.Close()
+}
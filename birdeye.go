@@ -0,0 +1,138 @@
+package tokenguard
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+)
+
+// BirdeyeSource is the Source name of data produced by the Birdeye adapters.
+const BirdeyeSource = "birdeye"
+
+// ============================================================================
+// Birdeye Adapters
+// ============================================================================
+
+// BirdeyeSecurityProvider adapts a TokenSecurityProvider (such as a
+// *birdeye.Client) to SecurityDataProvider.
+type BirdeyeSecurityProvider struct {
+	provider TokenSecurityProvider
+}
+
+// NewBirdeyeSecurityProvider creates a security data adapter for Birdeye.
+func NewBirdeyeSecurityProvider(provider TokenSecurityProvider) *BirdeyeSecurityProvider {
+	return &BirdeyeSecurityProvider{provider: provider}
+}
+
+// GetSecurityData fetches and converts Birdeye token security data.
+func (p *BirdeyeSecurityProvider) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	security, err := p.provider.GetTokenSecurity(ctx, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+	return SecurityDataFromBirdeye(security, time.Now()), nil
+}
+
+// BirdeyeMarketProvider adapts a TokenOverviewProvider (such as a
+// *birdeye.Client) to MarketDataProvider.
+type BirdeyeMarketProvider struct {
+	provider TokenOverviewProvider
+}
+
+// NewBirdeyeMarketProvider creates a market data adapter for Birdeye.
+func NewBirdeyeMarketProvider(provider TokenOverviewProvider) *BirdeyeMarketProvider {
+	return &BirdeyeMarketProvider{provider: provider}
+}
+
+// GetMarketData fetches and converts Birdeye token overview data.
+func (p *BirdeyeMarketProvider) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	overview, err := p.provider.GetTokenOverview(ctx, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+	return MarketDataFromBirdeye(overview, time.Now()), nil
+}
+
+// SecurityDataFromBirdeye converts Birdeye token security data observed at asOf.
+//
+// Birdeye does not report the largest single holder, so TopHolderPct is
+// approximated by the creator's share.
+func SecurityDataFromBirdeye(security *birdeye.TokenSecurity, asOf time.Time) *SecurityData {
+	data := &SecurityData{
+		Source:          BirdeyeSource,
+		AsOf:            asOf,
+		CreatorAddress:  security.CreatorAddress,
+		Supply:          parseDecimal(security.TotalSupply),
+		CreatorPct:      parsePercentage(security.CreatorPercentage),
+		TopHolderPct:    parsePercentage(security.CreatorPercentage),
+		Top10HoldersPct: parsePercentage(security.Top10HolderPercent),
+		IsToken2022:     security.IsToken2022,
+		HasTransferFee:  security.TransferFeeEnable,
+		NonTransferable: security.NonTransferable,
+		MutableMetadata: security.MutableMetadata,
+	}
+
+	if security.HasMintAuthority() {
+		data.MintAuthority = *security.MintAuthority
+	}
+	if security.HasFreezeAuthority() {
+		data.FreezeAuthority = *security.FreezeAuthority
+	}
+	if security.TransferFeeData != nil {
+		data.TransferFeeBPS = security.TransferFeeData.TransferFeeBPS
+	}
+
+	return data
+}
+
+// MarketDataFromBirdeye converts a Birdeye token overview observed at asOf.
+func MarketDataFromBirdeye(overview *birdeye.TokenOverview, asOf time.Time) *MarketData {
+	data := &MarketData{
+		Source:            BirdeyeSource,
+		AsOf:              asOf,
+		LiquidityUSD:      overview.Liquidity,
+		LiquidityReported: true,
+		PriceUSD:          overview.Price,
+		MarketCapUSD:      overview.MarketCap,
+		Volume24hUSD:      overview.Volume24hUSD,
+		PriceChange24hPct: overview.PriceChange24hPercent,
+		Holders:           overview.Holder,
+		Trades24h:         overview.Trade24h,
+		Buys24h:           overview.Buy24h,
+		Sells24h:          overview.Sell24h,
+	}
+
+	if overview.LastTradeUnixTime > 0 {
+		data.LastTradeAt = time.Unix(overview.LastTradeUnixTime, 0)
+	}
+
+	return data
+}
+
+// parsePercentage safely parses a percentage string to decimal.
+// Returns zero if parsing fails.
+func parsePercentage(s string) decimal.Decimal {
+	if s == "" {
+		return decimal.Zero
+	}
+
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromFloat(val)
+}
+
+// parseDecimal parses a decimal string exactly.
+// Returns zero if parsing fails.
+func parseDecimal(s string) decimal.Decimal {
+	val, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return val
+}
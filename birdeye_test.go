@@ -0,0 +1,104 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	birdeye "github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+)
+
+func TestSecurityDataFromBirdeye(t *testing.T) {
+	asOf := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mintAuth := "MintAuthority111"
+	empty := ""
+
+	data := SecurityDataFromBirdeye(&birdeye.TokenSecurity{
+		MintAuthority:      &mintAuth,
+		FreezeAuthority:    &empty,
+		CreatorAddress:     "Creator111",
+		CreatorPercentage:  "12.5",
+		Top10HolderPercent: "45",
+		TotalSupply:        "1000000000.123456",
+		IsToken2022:        true,
+		TransferFeeEnable:  true,
+		TransferFeeData:    &birdeye.TransferFeeData{TransferFeeBPS: 250},
+		MutableMetadata:    true,
+	}, asOf)
+
+	if data.Source != BirdeyeSource || !data.AsOf.Equal(asOf) {
+		t.Errorf("unexpected provenance: %q at %v", data.Source, data.AsOf)
+	}
+	if data.MintAuthority != mintAuth || !data.HasMintAuthority() {
+		t.Errorf("expected mint authority %q, got %q", mintAuth, data.MintAuthority)
+	}
+	if data.HasFreezeAuthority() {
+		t.Error("expected empty freeze authority to map to revoked")
+	}
+	if data.CreatorAddress != "Creator111" {
+		t.Errorf("expected creator address, got %q", data.CreatorAddress)
+	}
+	if !data.CreatorPct.Equal(decimal.RequireFromString("12.5")) || !data.TopHolderPct.Equal(data.CreatorPct) {
+		t.Errorf("unexpected creator/top holder pct: %s/%s", data.CreatorPct, data.TopHolderPct)
+	}
+	if !data.Top10HoldersPct.Equal(decimal.NewFromInt(45)) {
+		t.Errorf("expected top 10 pct 45, got %s", data.Top10HoldersPct)
+	}
+	if !data.Supply.Equal(decimal.RequireFromString("1000000000.123456")) {
+		t.Errorf("expected exact supply, got %s", data.Supply)
+	}
+	if !data.IsToken2022 || !data.HasTransferFee || data.TransferFeeBPS != 250 || !data.MutableMetadata {
+		t.Errorf("unexpected Token-2022 flags: %+v", data)
+	}
+}
+
+func TestMarketDataFromBirdeye(t *testing.T) {
+	asOf := time.Now()
+
+	data := MarketDataFromBirdeye(&birdeye.TokenOverview{
+		Liquidity:             decimal.NewFromInt(75000),
+		Price:                 decimal.RequireFromString("0.0042"),
+		MarketCap:             decimal.NewFromInt(4200000),
+		Volume24hUSD:          decimal.NewFromInt(120000),
+		PriceChange24hPercent: decimal.NewFromInt(-12),
+		Holder:                1500,
+		Trade24h:              900,
+		Buy24h:                500,
+		Sell24h:               400,
+		LastTradeUnixTime:     1700000000,
+	}, asOf)
+
+	if data.Source != BirdeyeSource || !data.AsOf.Equal(asOf) {
+		t.Errorf("unexpected provenance: %q at %v", data.Source, data.AsOf)
+	}
+	if !data.LiquidityUSD.Equal(decimal.NewFromInt(75000)) {
+		t.Errorf("expected liquidity 75000, got %s", data.LiquidityUSD)
+	}
+	if data.Holders != 1500 || data.Trades24h != 900 || data.Buys24h != 500 || data.Sells24h != 400 {
+		t.Errorf("unexpected activity counts: %+v", data)
+	}
+	if !data.LastTradeAt.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected last trade time: %v", data.LastTradeAt)
+	}
+
+	if got := MarketDataFromBirdeye(&birdeye.TokenOverview{}, asOf); !got.LastTradeAt.IsZero() {
+		t.Errorf("expected zero last trade time when unknown, got %v", got.LastTradeAt)
+	}
+}
+
+func TestBirdeyeAdapters_PropagateErrors(t *testing.T) {
+	ctx := context.Background()
+	apiErr := &birdeye.APIError{StatusCode: 404}
+
+	security := NewBirdeyeSecurityProvider(&mockSecurityProvider{err: apiErr})
+	if _, err := security.GetSecurityData(ctx, "test-mint"); !errors.Is(err, apiErr) {
+		t.Errorf("expected provider error, got %v", err)
+	}
+
+	market := NewBirdeyeMarketProvider(&mockOverviewProvider{err: apiErr})
+	if _, err := market.GetMarketData(ctx, "test-mint"); !errors.Is(err, apiErr) {
+		t.Errorf("expected provider error, got %v", err)
+	}
+}
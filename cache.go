@@ -2,8 +2,13 @@ package tokenguard
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"sync"
 	"time"
+	"unsafe"
+
+	"github.com/shopspring/decimal"
 )
 
 // DefaultCacheTTL is the default time-to-live for cached screening results.
@@ -11,6 +16,8 @@ const DefaultCacheTTL = 5 * time.Minute
 
 // DefaultMaxCacheSize is the default maximum number of entries in the cache.
 // With ~1KB per entry, 10,000 entries = ~10MB max memory.
+// Entry sizes vary widely; use InMemoryCacheConfig.MaxBytes for a hard
+// memory budget.
 const DefaultMaxCacheSize = 10000
 
 // ============================================================================
@@ -21,6 +28,7 @@ const DefaultMaxCacheSize = 10000
 type cacheEntry struct {
 	result    *TokenScreeningResult
 	expiresAt time.Time
+	size      int64 // Estimated memory footprint in bytes
 }
 
 // isExpired returns true if the entry has passed its expiration time.
@@ -28,6 +36,72 @@ func (e *cacheEntry) isExpired() bool {
 	return time.Now().After(e.expiresAt)
 }
 
+// Memory overhead estimates used by estimateEntrySize, in bytes.
+const (
+	// mapEntryOverhead approximates one map slot: key string header,
+	// value pointer and amortized bucket bookkeeping.
+	mapEntryOverhead = 64
+
+	// stringHeaderSize is the size of a string header in a slice.
+	stringHeaderSize = int64(unsafe.Sizeof(""))
+
+	// bigIntOverhead approximates the heap part of a decimal.Decimal:
+	// the big.Int it points to plus a one-word coefficient.
+	bigIntOverhead = 40
+)
+
+// detailsDecimalFields is the number of decimal fields in ScreeningDetails,
+// counted once so the estimate stays correct as fields are added.
+var detailsDecimalFields = func() int64 {
+	decimalType := reflect.TypeOf(decimal.Decimal{})
+	detailsType := reflect.TypeOf(ScreeningDetails{})
+
+	var n int64
+	for i := 0; i < detailsType.NumField(); i++ {
+		if detailsType.Field(i).Type == decimalType {
+			n++
+		}
+	}
+	return n
+}()
+
+// estimateEntrySize estimates the memory held by a cached result.
+//
+// This is an approximation (allocator rounding and map growth are not
+// modeled) intended for budgeting, not exact accounting.
+func estimateEntrySize(result *TokenScreeningResult) int64 {
+	size := int64(unsafe.Sizeof(cacheEntry{})) + int64(unsafe.Sizeof(*result)) + mapEntryOverhead
+	size += int64(len(result.TokenMint)) // Shared by the map key and the field
+	size += int64(len(result.Level))
+	size += int64(len(result.Details.CreatorAddress))
+	size += detailsDecimalFields * bigIntOverhead
+
+	for _, reason := range result.FailureReasons {
+		size += stringHeaderSize + int64(len(reason))
+	}
+
+	for _, d := range result.Disagreements {
+		size += int64(unsafe.Sizeof(d)) + int64(len(d.Field)+len(d.Resolved))
+		for _, v := range d.Values {
+			size += int64(unsafe.Sizeof(v)) + int64(len(v.Source)+len(v.Value))
+		}
+	}
+
+	for source := range result.DataAsOf {
+		size += mapEntryOverhead + int64(len(source)) + int64(unsafe.Sizeof(time.Time{}))
+	}
+
+	for _, kind := range result.StaleData {
+		size += stringHeaderSize + int64(len(kind))
+	}
+
+	for _, check := range result.SkippedChecks {
+		size += stringHeaderSize + int64(len(check))
+	}
+
+	return size
+}
+
 // InMemoryCache provides a simple in-memory cache for screening results.
 //
 // This implementation is suitable for single-instance deployments.
@@ -35,15 +109,19 @@ func (e *cacheEntry) isExpired() bool {
 //
 // Features:
 //   - Thread-safe
+//   - Stored results are isolated from callers (copied on write and read)
 //   - Configurable TTL
 //   - Configurable max size (prevents unbounded memory growth)
+//   - Optional memory budget in bytes, with per-entry size estimation
 //   - Automatic expiration checks on read
 //   - Periodic cleanup goroutine (optional)
 type InMemoryCache struct {
-	entries map[string]*cacheEntry
-	ttl     time.Duration
-	maxSize int
-	mu      sync.RWMutex
+	entries  map[string]*cacheEntry
+	ttl      time.Duration
+	maxSize  int
+	maxBytes int64 // Zero means no memory budget
+	bytes    int64 // Estimated bytes held by entries
+	mu       sync.RWMutex
 
 	// For cleanup goroutine
 	done chan struct{}
@@ -61,6 +139,15 @@ type InMemoryCacheConfig struct {
 	// Defaults to 10,000 if zero.
 	MaxSize int
 
+	// MaxBytes is the maximum estimated memory used by cached entries.
+	// Each entry's footprint is estimated from its contents (strings,
+	// failure reasons, decimals) plus bookkeeping overhead. When a new
+	// entry would exceed the budget, expired entries are evicted first,
+	// then other entries until it fits. Results larger than the whole
+	// budget are rejected.
+	// Set to 0 to bound the cache by entry count only.
+	MaxBytes int64
+
 	// CleanupInterval is how often expired entries are removed.
 	// Set to 0 to disable background cleanup.
 	// If enabled, you must call Close() to stop the cleanup goroutine.
@@ -77,10 +164,11 @@ func NewInMemoryCache(cfg InMemoryCacheConfig) *InMemoryCache {
 	}
 
 	c := &InMemoryCache{
-		entries: make(map[string]*cacheEntry),
-		ttl:     cfg.TTL,
-		maxSize: cfg.MaxSize,
-		done:    make(chan struct{}),
+		entries:  make(map[string]*cacheEntry),
+		ttl:      cfg.TTL,
+		maxSize:  cfg.MaxSize,
+		maxBytes: cfg.MaxBytes,
+		done:     make(chan struct{}),
 	}
 
 	// Start background cleanup if interval is set
@@ -95,24 +183,42 @@ func NewInMemoryCache(cfg InMemoryCacheConfig) *InMemoryCache {
 //
 // Returns the result and true if found and not expired.
 // Returns nil and false if not found or expired.
-func (c *InMemoryCache) Get(_ context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+//
+// The returned result is a copy; callers may modify it freely.
+func (c *InMemoryCache) Get(ctx context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	result, _, ok := c.GetWithTTL(ctx, tokenMint)
+	return result, ok
+}
+
+// GetWithTTL retrieves a cached screening result and its remaining time-to-live.
+//
+// Returns the result, remaining TTL and true if found and not expired.
+// Returns nil, zero and false if not found or expired.
+func (c *InMemoryCache) GetWithTTL(_ context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool) {
 	c.mu.RLock()
 	entry, ok := c.entries[tokenMint]
 	c.mu.RUnlock()
 
 	if !ok {
-		return nil, false
+		return nil, 0, false
 	}
 
 	if entry.isExpired() {
-		// Lazy deletion on read
+		// Lazy deletion on read, unless the entry was replaced meanwhile
 		c.mu.Lock()
-		delete(c.entries, tokenMint)
+		if c.entries[tokenMint] == entry {
+			c.removeLocked(tokenMint)
+		}
 		c.mu.Unlock()
-		return nil, false
+		return nil, 0, false
 	}
 
-	return entry.result, true
+	return entry.result.Clone(), time.Until(entry.expiresAt), true
+}
+
+// TTL returns the time-to-live applied by Set.
+func (c *InMemoryCache) TTL() time.Duration {
+	return c.ttl
 }
 
 // Set stores a screening result in the cache.
@@ -121,8 +227,28 @@ func (c *InMemoryCache) Get(_ context.Context, tokenMint string) (*TokenScreenin
 // 1. First evict all expired entries
 // 2. If still at capacity, evict one entry (effectively random due to map iteration)
 //
+// If a memory budget is configured, entries are additionally evicted until
+// the new entry fits within it.
+//
+// The cache stores a copy of result, so later changes by the caller do not
+// affect the cached value.
+//
 // This prevents unbounded memory growth if cleanup goroutine fails or TTL is extended.
-func (c *InMemoryCache) Set(_ context.Context, result *TokenScreeningResult) error {
+func (c *InMemoryCache) Set(ctx context.Context, result *TokenScreeningResult) error {
+	return c.SetWithTTL(ctx, result, c.ttl)
+}
+
+// SetWithTTL stores a screening result with a custom time-to-live.
+//
+// Eviction behaves as for Set.
+func (c *InMemoryCache) SetWithTTL(_ context.Context, result *TokenScreeningResult, ttl time.Duration) error {
+	size := estimateEntrySize(result)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return fmt.Errorf("screening result of ~%d bytes exceeds cache budget of %d bytes", size, c.maxBytes)
+	}
+
+	stored := result.Clone() // Copy outside the lock
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -134,10 +260,19 @@ func (c *InMemoryCache) Set(_ context.Context, result *TokenScreeningResult) err
 		c.evictLocked()
 	}
 
+	// The replaced entry's memory is freed by this write
+	c.removeLocked(result.TokenMint)
+
+	if c.maxBytes > 0 && c.bytes+size > c.maxBytes {
+		c.evictBytesLocked(size)
+	}
+
 	c.entries[result.TokenMint] = &cacheEntry{
-		result:    result,
-		expiresAt: time.Now().Add(c.ttl),
+		result:    stored,
+		expiresAt: time.Now().Add(ttl),
+		size:      size,
 	}
+	c.bytes += size
 
 	return nil
 }
@@ -154,7 +289,7 @@ func (c *InMemoryCache) evictLocked() {
 	// Phase 1: Evict all expired entries
 	for key, entry := range c.entries {
 		if now.After(entry.expiresAt) {
-			delete(c.entries, key)
+			c.removeLocked(key)
 		}
 	}
 
@@ -163,18 +298,49 @@ func (c *InMemoryCache) evictLocked() {
 	// This is simpler than maintaining LRU order and sufficient for a screening cache.
 	if len(c.entries) >= c.maxSize {
 		for key := range c.entries {
-			delete(c.entries, key)
+			c.removeLocked(key)
 			break // Only evict one
 		}
 	}
 }
 
+// evictBytesLocked removes entries until size more bytes fit in the budget.
+// Must be called with c.mu held (write lock).
+//
+// Uses the same strategy as evictLocked: expired entries first, then
+// random entries (by map iteration order) until there is room.
+func (c *InMemoryCache) evictBytesLocked(size int64) {
+	now := time.Now()
+
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			c.removeLocked(key)
+		}
+	}
+
+	for key := range c.entries {
+		if c.bytes+size <= c.maxBytes {
+			return
+		}
+		c.removeLocked(key)
+	}
+}
+
+// removeLocked deletes an entry and releases its memory accounting.
+// Must be called with c.mu held (write lock).
+func (c *InMemoryCache) removeLocked(key string) {
+	if entry, ok := c.entries[key]; ok {
+		c.bytes -= entry.size
+		delete(c.entries, key)
+	}
+}
+
 // Delete removes an entry from the cache.
 func (c *InMemoryCache) Delete(_ context.Context, tokenMint string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.entries, tokenMint)
+	c.removeLocked(tokenMint)
 }
 
 // Clear removes all entries from the cache.
@@ -183,6 +349,7 @@ func (c *InMemoryCache) Clear(_ context.Context) {
 	defer c.mu.Unlock()
 
 	c.entries = make(map[string]*cacheEntry)
+	c.bytes = 0
 }
 
 // Size returns the number of entries in the cache (including expired).
@@ -193,6 +360,14 @@ func (c *InMemoryCache) Size() int {
 	return len(c.entries)
 }
 
+// Bytes returns the estimated memory used by entries (including expired).
+func (c *InMemoryCache) Bytes() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.bytes
+}
+
 // Close stops the background cleanup goroutine.
 // Must be called if CleanupInterval was set.
 func (c *InMemoryCache) Close() error {
@@ -223,7 +398,7 @@ func (c *InMemoryCache) cleanup() {
 	now := time.Now()
 	for key, entry := range c.entries {
 		if now.After(entry.expiresAt) {
-			delete(c.entries, key)
+			c.removeLocked(key)
 		}
 	}
 }
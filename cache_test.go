@@ -2,6 +2,7 @@ package tokenguard
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -326,3 +327,89 @@ func TestInMemoryCache_DefaultTTL(t *testing.T) {
 		t.Errorf("expected default TTL %v, got %v", DefaultCacheTTL, cache.ttl)
 	}
 }
+
+func TestInMemoryCache_MaxBytes(t *testing.T) {
+	ctx := context.Background()
+
+	small := &TokenScreeningResult{TokenMint: "mint-0", Score: 100}
+	entrySize := estimateEntrySize(small)
+
+	// Room for exactly three small entries.
+	cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute, MaxBytes: 3 * entrySize})
+	defer func() { _ = cache.Close() }()
+
+	for i := 0; i < 10; i++ {
+		result := &TokenScreeningResult{TokenMint: "mint-" + string(rune('0'+i)), Score: 100}
+		if err := cache.Set(ctx, result); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if cache.Bytes() > 3*entrySize {
+			t.Fatalf("cache uses %d bytes, budget is %d", cache.Bytes(), 3*entrySize)
+		}
+	}
+
+	if cache.Size() != 3 {
+		t.Errorf("expected 3 entries within budget, got %d", cache.Size())
+	}
+	if cache.Bytes() != 3*entrySize {
+		t.Errorf("expected %d bytes in use, got %d", 3*entrySize, cache.Bytes())
+	}
+
+	// A large result evicts as many entries as needed.
+	large := &TokenScreeningResult{
+		TokenMint:      "large",
+		FailureReasons: []string{strings.Repeat("x", int(entrySize))},
+	}
+	if err := cache.Set(ctx, large); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if cache.Bytes() > 3*entrySize {
+		t.Errorf("cache uses %d bytes after large set, budget is %d", cache.Bytes(), 3*entrySize)
+	}
+	if _, ok := cache.Get(ctx, "large"); !ok {
+		t.Error("expected large entry to be cached")
+	}
+
+	// A result bigger than the whole budget is rejected.
+	huge := &TokenScreeningResult{
+		TokenMint:      "huge",
+		FailureReasons: []string{strings.Repeat("x", int(4*entrySize))},
+	}
+	if err := cache.Set(ctx, huge); err == nil {
+		t.Error("expected error for result exceeding the budget")
+	}
+}
+
+func TestInMemoryCache_BytesAccounting(t *testing.T) {
+	ctx := context.Background()
+	cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	result := &TokenScreeningResult{TokenMint: "test-mint", FailureReasons: []string{"has_mint_authority"}}
+	_ = cache.Set(ctx, result)
+	_ = cache.Set(ctx, result) // Overwrite must not double-count
+
+	if got, want := cache.Bytes(), estimateEntrySize(result); got != want {
+		t.Errorf("expected %d bytes, got %d", want, got)
+	}
+
+	cache.Delete(ctx, "test-mint")
+	if cache.Bytes() != 0 {
+		t.Errorf("expected 0 bytes after delete, got %d", cache.Bytes())
+	}
+}
+
+func TestEstimateEntrySize_GrowsWithContent(t *testing.T) {
+	base := estimateEntrySize(&TokenScreeningResult{TokenMint: "test-mint"})
+	withReasons := estimateEntrySize(&TokenScreeningResult{
+		TokenMint:      "test-mint",
+		FailureReasons: []string{"has_mint_authority", "low_liquidity:$1000.00"},
+	})
+
+	if withReasons <= base {
+		t.Errorf("expected failure reasons to increase estimate: %d <= %d", withReasons, base)
+	}
+	if base < 200 || base > 2048 {
+		t.Errorf("base estimate %d outside plausible range", base)
+	}
+}
@@ -0,0 +1,613 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// DefaultConsensusTolerance is the default relative difference below which
+// numeric values from different sources are considered in agreement.
+var DefaultConsensusTolerance = decimal.NewFromFloat(0.1)
+
+// ============================================================================
+// Disagreements
+// ============================================================================
+
+// ConflictRule decides which source's value is used when sources disagree.
+type ConflictRule string
+
+// Conflict rules.
+const (
+	// ConflictMostConservative uses the riskiest reported value: an active
+	// authority over a revoked one, the higher concentration, the lower
+	// liquidity. Fields without a risk direction (e.g., price) use the
+	// highest-priority source.
+	ConflictMostConservative ConflictRule = "most_conservative"
+
+	// ConflictPreferPrimary uses the highest-priority source's value and
+	// only records the disagreement.
+	ConflictPreferPrimary ConflictRule = "prefer_primary"
+)
+
+// SourceValue is the value one source reported for a field.
+type SourceValue struct {
+	Source string `json:"source"`
+	Value  string `json:"value"`
+}
+
+// Disagreement records sources reporting different values for a field.
+type Disagreement struct {
+	// Field names the disputed field (e.g., "mint_authority").
+	Field string `json:"field"`
+
+	// Values lists each source's value, in priority order.
+	Values []SourceValue `json:"values"`
+
+	// Resolved is the value used after applying the conflict rule.
+	Resolved string `json:"resolved"`
+}
+
+// clone returns a deep copy of the disagreement.
+func (d Disagreement) clone() Disagreement {
+	d.Values = append([]SourceValue(nil), d.Values...)
+	return d
+}
+
+// appendDisagreements appends copies of src to dst, so results do not share
+// memory with cached provider data.
+func appendDisagreements(dst, src []Disagreement) []Disagreement {
+	for _, d := range src {
+		dst = append(dst, d.clone())
+	}
+	return dst
+}
+
+// ============================================================================
+// Provider Errors
+// ============================================================================
+
+// ProviderErrors is returned by composite providers when every source failed.
+//
+// It unwraps to each source's error, so errors.Is and errors.As match any of
+// them. ClassifyError classifies it as the most retryable of its errors: a
+// transient failure of one source means another attempt may succeed.
+type ProviderErrors struct {
+	Errs []error
+}
+
+// Error implements the error interface.
+func (e *ProviderErrors) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("all %d providers failed: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the source errors.
+func (e *ProviderErrors) Unwrap() []error {
+	return e.Errs
+}
+
+// class classifies the errors as the most retryable among them.
+func (e *ProviderErrors) class(classify ErrorClassifier) ErrorClass {
+	class := ErrorClassPersistent
+	for _, err := range e.Errs {
+		switch classify(err) {
+		case ErrorClassTransient:
+			return ErrorClassTransient
+		case ErrorClassNotFound:
+			class = ErrorClassNotFound
+		}
+	}
+	return class
+}
+
+// ============================================================================
+// Composite Providers
+// ============================================================================
+
+// CompositeConfig holds configuration for composite providers.
+type CompositeConfig struct {
+	// Consensus queries several sources in parallel and reconciles their
+	// data, recording disagreements. If false, sources are tried one at a
+	// time in priority order until one succeeds.
+	Consensus bool
+
+	// ConsensusSources is how many of the highest-priority sources are
+	// queried in consensus mode. If all of them fail, the remaining sources
+	// are tried in priority order.
+	// Defaults to 2 if zero.
+	ConsensusSources int
+
+	// ConflictRule resolves disagreements in consensus mode.
+	// Defaults to ConflictMostConservative if empty.
+	ConflictRule ConflictRule
+
+	// Tolerance is the relative difference (e.g., 0.1 for 10%) up to which
+	// numeric values such as liquidity are considered in agreement.
+	// Defaults to 0.1 if zero.
+	Tolerance decimal.Decimal
+
+	// Logger reports failovers and disagreements (optional).
+	Logger *zap.Logger
+}
+
+// composite queries prioritized sources of one kind of data.
+type composite[T any] struct {
+	kind    string // For errors and logs, e.g. "security"
+	sources []func(ctx context.Context, tokenMint string) (*T, error)
+	fields  []consensusField[T]
+	source  func(*T) *string    // Source name field
+	asOf    func(*T) *time.Time // Observation time field
+	finish  func(merged *T, results []*T, disagreements []Disagreement)
+	cfg     CompositeConfig
+}
+
+// compositeDefaults validates cfg and applies defaults.
+func compositeDefaults(kind string, cfg CompositeConfig, sources int) (CompositeConfig, error) {
+	if sources == 0 {
+		return cfg, fmt.Errorf("at least one %s provider is required", kind)
+	}
+	if cfg.ConsensusSources == 0 {
+		cfg.ConsensusSources = 2
+	}
+	if cfg.ConsensusSources < 0 {
+		return cfg, fmt.Errorf("consensus sources must be positive, got %d", cfg.ConsensusSources)
+	}
+	switch cfg.ConflictRule {
+	case "":
+		cfg.ConflictRule = ConflictMostConservative
+	case ConflictMostConservative, ConflictPreferPrimary:
+	default:
+		return cfg, fmt.Errorf("unknown conflict rule: %s", cfg.ConflictRule)
+	}
+	if cfg.Tolerance.IsZero() {
+		cfg.Tolerance = DefaultConsensusTolerance
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	return cfg, nil
+}
+
+// get returns data from the sources according to the configured mode.
+func (c *composite[T]) get(ctx context.Context, tokenMint string) (*T, error) {
+	var errs []error
+	next := 0
+
+	if c.cfg.Consensus {
+		n := min(c.cfg.ConsensusSources, len(c.sources))
+		results, failed := c.queryParallel(ctx, tokenMint, n)
+		errs = append(errs, failed...)
+		if len(results) > 0 {
+			return c.reconcile(tokenMint, results), nil
+		}
+		next = n
+	}
+
+	for i := next; i < len(c.sources); i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		data, err := c.sources[i](ctx, tokenMint)
+		if err == nil {
+			if len(errs) > 0 {
+				c.cfg.Logger.Warn("provider failover",
+					zap.String("kind", c.kind),
+					zap.String("token_mint", tokenMint),
+					zap.Int("source", i),
+					zap.Errors("errors", errs),
+				)
+			}
+			return data, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return nil, &ProviderErrors{Errs: errs}
+}
+
+// queryParallel queries the first n sources concurrently. It returns the
+// successful results in priority order, and the errors of the others.
+func (c *composite[T]) queryParallel(ctx context.Context, tokenMint string, n int) ([]*T, []error) {
+	results := make([]*T, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.sources[i](ctx, tokenMint)
+		}()
+	}
+	wg.Wait()
+
+	var succeeded []*T
+	var failed []error
+	for i := range n {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+		} else {
+			succeeded = append(succeeded, results[i])
+		}
+	}
+	return succeeded, failed
+}
+
+// reconcile merges results (in priority order) field by field, recording
+// disagreements on the merged copy.
+func (c *composite[T]) reconcile(tokenMint string, results []*T) *T {
+	merged := *results[0]
+	if len(results) == 1 {
+		return &merged
+	}
+
+	names := make([]string, len(results))
+	oldest := *c.asOf(results[0])
+	for i, r := range results {
+		names[i] = *c.source(r)
+		if at := *c.asOf(r); at.Before(oldest) {
+			oldest = at
+		}
+	}
+	*c.source(&merged) = strings.Join(names, "+")
+	*c.asOf(&merged) = oldest
+
+	var disagreements []Disagreement
+	for _, field := range c.fields {
+		if d, ok := c.reconcileField(field, names, results, &merged); ok {
+			disagreements = append(disagreements, d)
+		}
+	}
+
+	if len(disagreements) > 0 {
+		fields := make([]string, len(disagreements))
+		for i, d := range disagreements {
+			fields[i] = d.Field
+		}
+		c.cfg.Logger.Warn("providers disagree",
+			zap.String("kind", c.kind),
+			zap.String("token_mint", tokenMint),
+			zap.Strings("fields", fields),
+			zap.String("rule", string(c.cfg.ConflictRule)),
+		)
+	}
+	c.finish(&merged, results, disagreements)
+
+	return &merged
+}
+
+// reconcileField resolves one field into merged and reports a disagreement
+// if sources that know the field report different values.
+func (c *composite[T]) reconcileField(field consensusField[T], names []string, results []*T, merged *T) (Disagreement, bool) {
+	var (
+		known  []int
+		differ bool
+	)
+	for i, r := range results {
+		if !field.known(r) {
+			continue
+		}
+		if len(known) > 0 && field.differs(results[known[0]], r, c.cfg.Tolerance) {
+			differ = true
+		}
+		known = append(known, i)
+	}
+	if len(known) == 0 {
+		return Disagreement{}, false
+	}
+
+	// The highest-priority source that knows the field, unless sources
+	// disagree and a riskier value wins under the conservative rule
+	chosen := known[0]
+	if differ && c.cfg.ConflictRule == ConflictMostConservative && field.riskier != nil {
+		for _, i := range known[1:] {
+			if field.riskier(results[chosen], results[i]) {
+				chosen = i
+			}
+		}
+	}
+	field.copy(merged, results[chosen])
+
+	if !differ {
+		return Disagreement{}, false
+	}
+
+	d := Disagreement{Field: field.name, Resolved: field.format(results[chosen])}
+	for _, i := range known {
+		d.Values = append(d.Values, SourceValue{Source: names[i], Value: field.format(results[i])})
+	}
+	return d, true
+}
+
+// consensusField describes how to compare and merge one field.
+type consensusField[T any] struct {
+	name   string
+	known  func(*T) bool // Whether the source reported the field
+	format func(*T) string
+
+	// differs reports whether two known values disagree.
+	differs func(a, b *T, tolerance decimal.Decimal) bool
+
+	// riskier reports whether b's value is riskier than a's.
+	// Nil for fields without a risk direction.
+	riskier func(a, b *T) bool
+
+	copy func(dst, src *T)
+}
+
+// authorityField compares an authority address; empty means revoked.
+func authorityField[T any](name string, get func(*T) *string) consensusField[T] {
+	return consensusField[T]{
+		name:  name,
+		known: func(*T) bool { return true },
+		format: func(d *T) string {
+			if *get(d) == "" {
+				return "revoked"
+			}
+			return *get(d)
+		},
+		differs: func(a, b *T, _ decimal.Decimal) bool { return *get(a) != *get(b) },
+		riskier: func(a, b *T) bool { return *get(a) == "" && *get(b) != "" },
+		copy:    func(dst, src *T) { *get(dst) = *get(src) },
+	}
+}
+
+// flagField compares a risk flag. If onlyTrue is set, false is treated as
+// unknown, for flags some sources cannot determine.
+func flagField[T any](name string, get func(*T) *bool, onlyTrue bool) consensusField[T] {
+	return consensusField[T]{
+		name:    name,
+		known:   func(d *T) bool { return !onlyTrue || *get(d) },
+		format:  func(d *T) string { return strconv.FormatBool(*get(d)) },
+		differs: func(a, b *T, _ decimal.Decimal) bool { return *get(a) != *get(b) },
+		riskier: func(a, b *T) bool { return !*get(a) && *get(b) },
+		copy:    func(dst, src *T) { *get(dst) = *get(src) },
+	}
+}
+
+// addressField compares an optional address; empty is treated as unknown.
+func addressField[T any](name string, get func(*T) *string) consensusField[T] {
+	return consensusField[T]{
+		name:    name,
+		known:   func(d *T) bool { return *get(d) != "" },
+		format:  func(d *T) string { return *get(d) },
+		differs: func(a, b *T, _ decimal.Decimal) bool { return *get(a) != *get(b) },
+		copy:    func(dst, src *T) { *get(dst) = *get(src) },
+	}
+}
+
+// riskDirection tells which of two numeric values is riskier.
+type riskDirection int
+
+const (
+	noRiskDirection riskDirection = iota // E.g., price
+	higherRiskier                        // E.g., holder concentration
+	lowerRiskier                         // E.g., liquidity
+)
+
+// amountField compares a numeric value within the relative tolerance;
+// zero is treated as unknown.
+func amountField[T any](name string, get func(*T) *decimal.Decimal, direction riskDirection) consensusField[T] {
+	field := consensusField[T]{
+		name:   name,
+		known:  func(d *T) bool { return !get(d).IsZero() },
+		format: func(d *T) string { return get(d).String() },
+		differs: func(a, b *T, tolerance decimal.Decimal) bool {
+			x, y := get(a).Abs(), get(b).Abs()
+			return get(a).Sub(*get(b)).Abs().GreaterThan(decimal.Max(x, y).Mul(tolerance))
+		},
+		copy: func(dst, src *T) { *get(dst) = *get(src) },
+	}
+	switch direction {
+	case higherRiskier:
+		field.riskier = func(a, b *T) bool { return get(b).GreaterThan(*get(a)) }
+	case lowerRiskier:
+		field.riskier = func(a, b *T) bool { return get(b).LessThan(*get(a)) }
+	}
+	return field
+}
+
+// reportedAmountField is an amountField whose zero counts as known when
+// reported is set, e.g., the liquidity of drained pools.
+func reportedAmountField[T any](
+	name string, get func(*T) *decimal.Decimal, reported func(*T) *bool, direction riskDirection,
+) consensusField[T] {
+	field := amountField(name, get, direction)
+	field.known = func(d *T) bool { return *reported(d) || !get(d).IsZero() }
+	field.copy = func(dst, src *T) {
+		*get(dst) = *get(src)
+		*reported(dst) = *reported(src)
+	}
+	return field
+}
+
+// securityFields are reconciled between security data sources.
+//
+// Authorities are compared as reported; other fields are only compared when
+// both sources report them, since sources leave unknown fields at zero.
+var securityFields = []consensusField[SecurityData]{
+	authorityField("mint_authority", func(d *SecurityData) *string { return &d.MintAuthority }),
+	authorityField("freeze_authority", func(d *SecurityData) *string { return &d.FreezeAuthority }),
+	flagField("non_transferable", func(d *SecurityData) *bool { return &d.NonTransferable }, false),
+	flagField("has_transfer_fee", func(d *SecurityData) *bool { return &d.HasTransferFee }, false),
+	flagField("is_token2022", func(d *SecurityData) *bool { return &d.IsToken2022 }, false),
+	flagField("mutable_metadata", func(d *SecurityData) *bool { return &d.MutableMetadata }, true),
+	{
+		name:    "transfer_fee_bps",
+		known:   func(d *SecurityData) bool { return d.TransferFeeBPS != 0 },
+		format:  func(d *SecurityData) string { return strconv.Itoa(d.TransferFeeBPS) },
+		differs: func(a, b *SecurityData, _ decimal.Decimal) bool { return a.TransferFeeBPS != b.TransferFeeBPS },
+		riskier: func(a, b *SecurityData) bool { return b.TransferFeeBPS > a.TransferFeeBPS },
+		copy:    func(dst, src *SecurityData) { dst.TransferFeeBPS = src.TransferFeeBPS },
+	},
+	addressField("permanent_delegate", func(d *SecurityData) *string { return &d.PermanentDelegate }),
+	addressField("transfer_hook_program", func(d *SecurityData) *string { return &d.TransferHookProgram }),
+	amountField("creator_pct", func(d *SecurityData) *decimal.Decimal { return &d.CreatorPct }, higherRiskier),
+	amountField("top_holder_pct", func(d *SecurityData) *decimal.Decimal { return &d.TopHolderPct }, higherRiskier),
+	amountField("top10_holders_pct", func(d *SecurityData) *decimal.Decimal { return &d.Top10HoldersPct }, higherRiskier),
+}
+
+// marketFields are reconciled between market data sources.
+var marketFields = []consensusField[MarketData]{
+	reportedAmountField("liquidity_usd",
+		func(d *MarketData) *decimal.Decimal { return &d.LiquidityUSD },
+		func(d *MarketData) *bool { return &d.LiquidityReported },
+		lowerRiskier),
+	amountField("price_usd", func(d *MarketData) *decimal.Decimal { return &d.PriceUSD }, noRiskDirection),
+	amountField("market_cap_usd", func(d *MarketData) *decimal.Decimal { return &d.MarketCapUSD }, lowerRiskier),
+	amountField("volume_24h_usd", func(d *MarketData) *decimal.Decimal { return &d.Volume24hUSD }, lowerRiskier),
+}
+
+// holderFields are reconciled between holder data sources.
+var holderFields = []consensusField[HolderData]{
+	amountField("top_holder_pct", func(d *HolderData) *decimal.Decimal { return &d.TopHolderPct }, higherRiskier),
+	amountField("top10_holders_pct", func(d *HolderData) *decimal.Decimal { return &d.Top10HoldersPct }, higherRiskier),
+}
+
+// CompositeSecurityProvider combines security data sources.
+//
+// In failover mode it returns the first successful source in priority
+// order. In consensus mode it queries the top sources in parallel, resolves
+// conflicting fields with the conflict rule and lists them in
+// SecurityData.Disagreements; the screener copies them into the result.
+//
+// If every source fails, the error is a *ProviderErrors.
+type CompositeSecurityProvider struct {
+	composite[SecurityData]
+}
+
+// NewCompositeSecurityProvider creates a composite of providers, given in
+// priority order.
+func NewCompositeSecurityProvider(cfg CompositeConfig, providers ...SecurityDataProvider) (*CompositeSecurityProvider, error) {
+	cfg, err := compositeDefaults("security", cfg, len(providers))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &CompositeSecurityProvider{composite[SecurityData]{
+		kind:   "security",
+		fields: securityFields,
+		source: func(d *SecurityData) *string { return &d.Source },
+		asOf:   func(d *SecurityData) *time.Time { return &d.AsOf },
+		finish: func(d *SecurityData, results []*SecurityData, disagreements []Disagreement) {
+			d.Disagreements = disagreements
+
+			// Shares are known if any source reports them
+			d.HolderSharesUnknown = true
+			for _, r := range results {
+				d.HolderSharesUnknown = d.HolderSharesUnknown && r.HolderSharesUnknown
+			}
+
+			// Any source reporting an extension counts
+			seen := make(map[string]bool)
+			d.Extensions = nil
+			for _, r := range results {
+				for _, ext := range r.Extensions {
+					if !seen[ext] {
+						seen[ext] = true
+						d.Extensions = append(d.Extensions, ext)
+					}
+				}
+			}
+		},
+		cfg: cfg,
+	}}
+	for _, provider := range providers {
+		p.sources = append(p.sources, provider.GetSecurityData)
+	}
+	return p, nil
+}
+
+// GetSecurityData returns security data from the configured sources.
+func (p *CompositeSecurityProvider) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	return p.get(ctx, tokenMint)
+}
+
+// CompositeMarketProvider combines market data sources.
+//
+// It behaves like CompositeSecurityProvider, recording conflicts in
+// MarketData.Disagreements. Under the conservative rule the lowest reported
+// liquidity wins.
+type CompositeMarketProvider struct {
+	composite[MarketData]
+}
+
+// NewCompositeMarketProvider creates a composite of providers, given in
+// priority order.
+func NewCompositeMarketProvider(cfg CompositeConfig, providers ...MarketDataProvider) (*CompositeMarketProvider, error) {
+	cfg, err := compositeDefaults("market", cfg, len(providers))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &CompositeMarketProvider{composite[MarketData]{
+		kind:   "market",
+		fields: marketFields,
+		source: func(d *MarketData) *string { return &d.Source },
+		asOf:   func(d *MarketData) *time.Time { return &d.AsOf },
+		finish: func(d *MarketData, _ []*MarketData, disagreements []Disagreement) {
+			d.Disagreements = disagreements
+		},
+		cfg: cfg,
+	}}
+	for _, provider := range providers {
+		p.sources = append(p.sources, provider.GetMarketData)
+	}
+	return p, nil
+}
+
+// GetMarketData returns market data from the configured sources.
+func (p *CompositeMarketProvider) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	return p.get(ctx, tokenMint)
+}
+
+// CompositeHolderProvider combines holder distribution sources.
+//
+// It behaves like CompositeSecurityProvider, recording conflicts in
+// HolderData.Disagreements. Holders lists the highest-priority successful
+// source's holders.
+type CompositeHolderProvider struct {
+	composite[HolderData]
+}
+
+// NewCompositeHolderProvider creates a composite of providers, given in
+// priority order.
+func NewCompositeHolderProvider(cfg CompositeConfig, providers ...HolderDataProvider) (*CompositeHolderProvider, error) {
+	cfg, err := compositeDefaults("holder", cfg, len(providers))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &CompositeHolderProvider{composite[HolderData]{
+		kind:   "holder",
+		fields: holderFields,
+		source: func(d *HolderData) *string { return &d.Source },
+		asOf:   func(d *HolderData) *time.Time { return &d.AsOf },
+		finish: func(d *HolderData, _ []*HolderData, disagreements []Disagreement) {
+			d.Disagreements = disagreements
+		},
+		cfg: cfg,
+	}}
+	for _, provider := range providers {
+		p.sources = append(p.sources, provider.GetHolderData)
+	}
+	return p, nil
+}
+
+// GetHolderData returns holder data from the configured sources.
+func (p *CompositeHolderProvider) GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error) {
+	return p.get(ctx, tokenMint)
+}
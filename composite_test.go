@@ -0,0 +1,329 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// errUnavailable is a transient provider failure.
+var errUnavailable = &RPCError{Method: "getAccountInfo", StatusCode: http.StatusServiceUnavailable}
+
+func TestNewCompositeSecurityProvider_Validation(t *testing.T) {
+	if _, err := NewCompositeSecurityProvider(CompositeConfig{}); err == nil {
+		t.Error("expected error for missing providers")
+	}
+
+	primary := &staticSecurityData{data: &SecurityData{}}
+	if _, err := NewCompositeSecurityProvider(CompositeConfig{ConflictRule: "coin_flip"}, primary); err == nil {
+		t.Error("expected error for unknown conflict rule")
+	}
+}
+
+func TestCompositeSecurityProvider_Failover(t *testing.T) {
+	provider, err := NewCompositeSecurityProvider(CompositeConfig{},
+		&staticSecurityData{err: errUnavailable},
+		&staticSecurityData{data: &SecurityData{Source: "backup"}},
+	)
+	if err != nil {
+		t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+	}
+
+	data, err := provider.GetSecurityData(context.Background(), "test-mint")
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+	if data.Source != "backup" {
+		t.Errorf("expected data from the backup source, got %q", data.Source)
+	}
+}
+
+func TestCompositeSecurityProvider_AllFail(t *testing.T) {
+	notFound := fmt.Errorf("mint: %w", ErrTokenNotFound)
+	notMint := fmt.Errorf("mint: %w", ErrNotMint)
+
+	tests := []struct {
+		name string
+		errs []error
+		want ErrorClass
+	}{
+		{"any transient", []error{notFound, errUnavailable}, ErrorClassTransient},
+		{"not found and persistent", []error{notMint, notFound}, ErrorClassNotFound},
+		{"all persistent", []error{notMint, notMint}, ErrorClassPersistent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var providers []SecurityDataProvider
+			for _, err := range tt.errs {
+				providers = append(providers, &staticSecurityData{err: err})
+			}
+			provider, err := NewCompositeSecurityProvider(CompositeConfig{}, providers...)
+			if err != nil {
+				t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+			}
+
+			_, err = provider.GetSecurityData(context.Background(), "test-mint")
+
+			var providerErrs *ProviderErrors
+			if !errors.As(err, &providerErrs) || len(providerErrs.Errs) != len(tt.errs) {
+				t.Fatalf("expected ProviderErrors with %d errors, got %v", len(tt.errs), err)
+			}
+			if !errors.Is(err, tt.errs[0]) {
+				t.Error("expected source errors to be matched by errors.Is")
+			}
+			if got := ClassifyError(err); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompositeSecurityProvider_Consensus(t *testing.T) {
+	birdeyeData := &SecurityData{
+		Source:          BirdeyeSource,
+		Top10HoldersPct: decimal.NewFromInt(30),
+		MutableMetadata: true,
+	}
+	rpcData := &SecurityData{
+		Source:          SolanaRPCSource,
+		MintAuthority:   "MintAuthority111",
+		Top10HoldersPct: decimal.NewFromInt(45),
+		IsToken2022:     true,
+		Extensions:      []string{"transfer_hook"},
+	}
+
+	tests := []struct {
+		name          string
+		rule          ConflictRule
+		wantMintAuth  string
+		wantTop10     string
+		wantResolved  string
+		wantToken2022 bool
+	}{
+		{"most conservative", ConflictMostConservative, "MintAuthority111", "45", "MintAuthority111", true},
+		{"prefer primary", ConflictPreferPrimary, "", "30", "revoked", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewCompositeSecurityProvider(CompositeConfig{Consensus: true, ConflictRule: tt.rule},
+				&staticSecurityData{data: birdeyeData},
+				&staticSecurityData{data: rpcData},
+			)
+			if err != nil {
+				t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+			}
+
+			data, err := provider.GetSecurityData(context.Background(), "test-mint")
+			if err != nil {
+				t.Fatalf("GetSecurityData() error = %v", err)
+			}
+
+			if data.Source != "birdeye+solana-rpc" {
+				t.Errorf("unexpected source %q", data.Source)
+			}
+			if data.MintAuthority != tt.wantMintAuth || data.IsToken2022 != tt.wantToken2022 {
+				t.Errorf("unexpected resolution: mint authority %q, token-2022 %v", data.MintAuthority, data.IsToken2022)
+			}
+			if !data.Top10HoldersPct.Equal(decimal.RequireFromString(tt.wantTop10)) {
+				t.Errorf("expected top 10 share %s, got %s", tt.wantTop10, data.Top10HoldersPct)
+			}
+
+			// Metadata mutability is only reported by one source.
+			if !data.MutableMetadata || len(data.Extensions) != 1 {
+				t.Errorf("expected facts from both sources, got %+v", data)
+			}
+
+			fields := make(map[string]Disagreement)
+			for _, d := range data.Disagreements {
+				fields[d.Field] = d
+			}
+			if len(fields) != 3 {
+				t.Errorf("expected 3 disagreements, got %+v", data.Disagreements)
+			}
+
+			mintAuth := fields["mint_authority"]
+			want := []SourceValue{{BirdeyeSource, "revoked"}, {SolanaRPCSource, "MintAuthority111"}}
+			if len(mintAuth.Values) != 2 || mintAuth.Values[0] != want[0] || mintAuth.Values[1] != want[1] {
+				t.Errorf("unexpected mint authority values %+v", mintAuth.Values)
+			}
+			if mintAuth.Resolved != tt.wantResolved {
+				t.Errorf("expected resolution %q, got %q", tt.wantResolved, mintAuth.Resolved)
+			}
+		})
+	}
+}
+
+func TestCompositeSecurityProvider_HolderSharesUnknown(t *testing.T) {
+	mintOnly := &staticSecurityData{data: &SecurityData{Source: SolanaRPCSource, HolderSharesUnknown: true}}
+	indexer := &staticSecurityData{data: &SecurityData{Source: BirdeyeSource, Top10HoldersPct: decimal.NewFromInt(30)}}
+
+	tests := []struct {
+		name        string
+		second      SecurityDataProvider
+		wantUnknown bool
+	}{
+		{"reported by another source", indexer, false},
+		{"no source reports them", &staticSecurityData{err: errUnavailable}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewCompositeSecurityProvider(CompositeConfig{Consensus: true}, mintOnly, tt.second)
+			if err != nil {
+				t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+			}
+
+			data, err := provider.GetSecurityData(context.Background(), "test-mint")
+			if err != nil {
+				t.Fatalf("GetSecurityData() error = %v", err)
+			}
+			if data.HolderSharesUnknown != tt.wantUnknown {
+				t.Errorf("expected holder shares unknown=%v, got %v", tt.wantUnknown, data.HolderSharesUnknown)
+			}
+		})
+	}
+}
+
+func TestCompositeSecurityProvider_ConsensusDegradesToSingleSource(t *testing.T) {
+	provider, err := NewCompositeSecurityProvider(CompositeConfig{Consensus: true},
+		&staticSecurityData{data: &SecurityData{Source: BirdeyeSource}},
+		&staticSecurityData{err: errUnavailable},
+	)
+	if err != nil {
+		t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+	}
+
+	data, err := provider.GetSecurityData(context.Background(), "test-mint")
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+	if data.Source != BirdeyeSource || len(data.Disagreements) != 0 {
+		t.Errorf("expected the surviving source's data, got %+v", data)
+	}
+}
+
+func TestCompositeMarketProvider_LiquidityTolerance(t *testing.T) {
+	tests := []struct {
+		name          string
+		secondary     int64
+		wantLiquidity int64
+		wantDisagree  bool
+	}{
+		{"within tolerance", 95000, 100000, false},
+		{"beyond tolerance", 40000, 40000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewCompositeMarketProvider(CompositeConfig{Consensus: true},
+				&staticMarketData{data: &MarketData{Source: BirdeyeSource, LiquidityUSD: decimal.NewFromInt(100000)}},
+				&staticMarketData{data: &MarketData{Source: PoolsSource, LiquidityUSD: decimal.NewFromInt(tt.secondary)}},
+			)
+			if err != nil {
+				t.Fatalf("NewCompositeMarketProvider() error = %v", err)
+			}
+
+			data, err := provider.GetMarketData(context.Background(), "test-mint")
+			if err != nil {
+				t.Fatalf("GetMarketData() error = %v", err)
+			}
+
+			if !data.LiquidityUSD.Equal(decimal.NewFromInt(tt.wantLiquidity)) {
+				t.Errorf("expected liquidity %d, got %s", tt.wantLiquidity, data.LiquidityUSD)
+			}
+			if got := len(data.Disagreements) > 0; got != tt.wantDisagree {
+				t.Errorf("expected disagreement %v, got %+v", tt.wantDisagree, data.Disagreements)
+			}
+		})
+	}
+}
+
+func TestCompositeMarketProvider_ZeroLiquidity(t *testing.T) {
+	tests := []struct {
+		name          string
+		reported      bool
+		wantLiquidity int64
+		wantDisagree  bool
+	}{
+		{"drained pools", true, 0, true},
+		{"unreported", false, 50000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewCompositeMarketProvider(
+				CompositeConfig{Consensus: true, ConflictRule: ConflictMostConservative},
+				&staticMarketData{data: &MarketData{Source: BirdeyeSource, LiquidityUSD: decimal.NewFromInt(50000), LiquidityReported: true}},
+				&staticMarketData{data: &MarketData{Source: PoolsSource, LiquidityReported: tt.reported}},
+			)
+			if err != nil {
+				t.Fatalf("NewCompositeMarketProvider() error = %v", err)
+			}
+
+			data, err := provider.GetMarketData(context.Background(), "test-mint")
+			if err != nil {
+				t.Fatalf("GetMarketData() error = %v", err)
+			}
+
+			if !data.LiquidityUSD.Equal(decimal.NewFromInt(tt.wantLiquidity)) || !data.LiquidityReported {
+				t.Errorf("expected reported liquidity %d, got %s (reported %v)",
+					tt.wantLiquidity, data.LiquidityUSD, data.LiquidityReported)
+			}
+			if got := len(data.Disagreements) > 0; got != tt.wantDisagree {
+				t.Errorf("expected disagreement %v, got %+v", tt.wantDisagree, data.Disagreements)
+			}
+			if tt.wantDisagree && data.Disagreements[0].Resolved != "0" {
+				t.Errorf("expected the drained value to win, got %+v", data.Disagreements[0])
+			}
+		})
+	}
+}
+
+func TestScreener_Screen_RecordsDisagreements(t *testing.T) {
+	security, err := NewCompositeSecurityProvider(CompositeConfig{Consensus: true},
+		&staticSecurityData{data: &SecurityData{Source: BirdeyeSource}},
+		&staticSecurityData{data: &SecurityData{Source: SolanaRPCSource, MintAuthority: "MintAuthority111"}},
+	)
+	if err != nil {
+		t.Fatalf("NewCompositeSecurityProvider() error = %v", err)
+	}
+
+	screener, err := New(Config{
+		SecurityDataProvider: security,
+		MarketDataProvider:   &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100000)}},
+		Cache:                NewInMemoryCache(InMemoryCacheConfig{}),
+		Logger:               zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if !result.Details.HasMintAuthority || !contains(result.FailureReasons, "has_mint_authority") {
+		t.Errorf("expected the conservative authority to fail screening, got %v", result.FailureReasons)
+	}
+	if len(result.Disagreements) != 1 || result.Disagreements[0].Field != "mint_authority" {
+		t.Fatalf("expected mint authority disagreement in result, got %+v", result.Disagreements)
+	}
+
+	// Cached copies do not share disagreement values with callers.
+	result.Disagreements[0].Values[0].Value = "changed"
+	cached, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if cached.Disagreements[0].Values[0].Value != "revoked" {
+		t.Errorf("expected cached disagreement to be isolated, got %+v", cached.Disagreements[0])
+	}
+}
@@ -0,0 +1,625 @@
+package tokenguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultRugLiquidityDropPct is the default liquidity drop, from a token's
+// peak, at which the token is considered rugged.
+var DefaultRugLiquidityDropPct = decimal.NewFromInt(90)
+
+// minCreatorHistory is the number of other tokens a creator must have
+// launched before their failure rate is judged.
+const minCreatorHistory = 3
+
+// ============================================================================
+// Creator Reputation
+// ============================================================================
+
+// CreatorToken is what is known about one token launched by a creator.
+type CreatorToken struct {
+	// TokenMint is the token's mint address.
+	TokenMint string `json:"tokenMint"`
+
+	// FirstSeen is when the token was first screened.
+	FirstSeen time.Time `json:"firstSeen"`
+
+	// Failed reports whether the token's latest screening failed checks
+	// other than the creator check.
+	Failed bool `json:"failed"`
+
+	// Rugged reports whether the token lost its liquidity, detected from
+	// screenings or marked with MarkRugged. It is never cleared.
+	Rugged bool `json:"rugged"`
+
+	// PeakLiquidityUSD is the highest liquidity seen across screenings.
+	PeakLiquidityUSD decimal.Decimal `json:"peakLiquidityUsd"`
+}
+
+// CreatorReputation is a creator wallet's launch history.
+type CreatorReputation struct {
+	// Creator is the creator's wallet address.
+	Creator string `json:"creator"`
+
+	// Tokens lists the creator's screened tokens, oldest first.
+	Tokens []CreatorToken `json:"tokens,omitempty"`
+
+	// Flagged reports whether the creator was flagged manually.
+	Flagged    bool      `json:"flagged"`
+	FlagReason string    `json:"flagReason,omitempty"`
+	FlaggedAt  time.Time `json:"flaggedAt"`
+}
+
+// Launched returns the number of tokens screened for the creator.
+func (r *CreatorReputation) Launched() int {
+	launched, _, _ := r.history("")
+	return launched
+}
+
+// Failed returns the number of the creator's tokens whose latest screening
+// failed.
+func (r *CreatorReputation) Failed() int {
+	_, failed, _ := r.history("")
+	return failed
+}
+
+// Rugged returns the number of the creator's tokens that lost their
+// liquidity.
+func (r *CreatorReputation) Rugged() int {
+	_, _, rugged := r.history("")
+	return rugged
+}
+
+// history counts the creator's tokens other than excluding.
+func (r *CreatorReputation) history(excluding string) (launched, failed, rugged int) {
+	for _, token := range r.Tokens {
+		if token.TokenMint == excluding {
+			continue
+		}
+		launched++
+		if token.Failed {
+			failed++
+		}
+		if token.Rugged {
+			rugged++
+		}
+	}
+	return launched, failed, rugged
+}
+
+// CreatorScreening is the outcome of screening a token, as recorded in the
+// creator's history.
+type CreatorScreening struct {
+	Creator      string
+	TokenMint    string
+	Failed       bool // Failed checks other than the creator check
+	LiquidityUSD decimal.Decimal
+	ScreenedAt   time.Time
+
+	// LiquidityMeasured is set when LiquidityUSD was measured, so zero
+	// means the pools were drained. Zero liquidity without it is unknown
+	// and ignored.
+	LiquidityMeasured bool
+}
+
+// CreatorStore keeps reputations of token creators, keyed by creator wallet.
+//
+// The screener records every screened token under its creator and judges
+// new tokens by the creator's history (see Config.CreatorStore). Operators
+// use the same store to look up creators and flag them manually.
+type CreatorStore interface {
+	// Reputation returns the creator's history. Unknown creators have an
+	// empty history.
+	Reputation(ctx context.Context, creator string) (*CreatorReputation, error)
+
+	// RecordScreening adds a screened token to its creator's history, or
+	// updates it. A token whose liquidity dropped far enough from its peak
+	// is marked rugged.
+	RecordScreening(ctx context.Context, screening CreatorScreening) error
+
+	// MarkRugged marks a recorded token as rugged, for rugs detected
+	// elsewhere. Returns an error wrapping ErrTokenNotFound if the token
+	// was never recorded.
+	MarkRugged(ctx context.Context, tokenMint string) error
+
+	// Flag marks a creator as bad, whatever their history.
+	Flag(ctx context.Context, creator, reason string) error
+
+	// Unflag clears a creator's flag.
+	Unflag(ctx context.Context, creator string) error
+}
+
+// CreatorStoreConfig holds configuration for InMemoryCreatorStore.
+type CreatorStoreConfig struct {
+	// RugLiquidityDropPct is the drop in liquidity from a token's peak, as
+	// a percentage, at which the token is marked rugged.
+	// Defaults to 90 if zero.
+	RugLiquidityDropPct decimal.Decimal
+}
+
+// ============================================================================
+// Creator Book
+// ============================================================================
+
+// creatorEntry is the stored state of one creator.
+type creatorEntry struct {
+	tokens     map[string]*CreatorToken
+	flagged    bool
+	flagReason string
+	flaggedAt  time.Time
+}
+
+// creatorBook holds creator state for the stores. It is not thread-safe.
+type creatorBook struct {
+	creators  map[string]*creatorEntry
+	tokens    map[string]string // Creator by token mint
+	rugFactor decimal.Decimal   // Fraction of peak liquidity at or below which a token is rugged
+}
+
+// newCreatorBook creates an empty book, validating the rug threshold.
+func newCreatorBook(rugDropPct decimal.Decimal) (*creatorBook, error) {
+	if rugDropPct.IsZero() {
+		rugDropPct = DefaultRugLiquidityDropPct
+	}
+	hundred := decimal.NewFromInt(100)
+	if rugDropPct.IsNegative() || rugDropPct.GreaterThan(hundred) {
+		return nil, fmt.Errorf("rug liquidity drop must be between 0 and 100 percent")
+	}
+
+	return &creatorBook{
+		creators:  make(map[string]*creatorEntry),
+		tokens:    make(map[string]string),
+		rugFactor: hundred.Sub(rugDropPct).Div(hundred),
+	}, nil
+}
+
+// entry returns the creator's entry, creating it if needed.
+func (b *creatorBook) entry(creator string) *creatorEntry {
+	e, ok := b.creators[creator]
+	if !ok {
+		e = &creatorEntry{tokens: make(map[string]*CreatorToken)}
+		b.creators[creator] = e
+	}
+	return e
+}
+
+// reputation returns a copy of the creator's history.
+func (b *creatorBook) reputation(creator string) *CreatorReputation {
+	rep := &CreatorReputation{Creator: creator}
+	e, ok := b.creators[creator]
+	if !ok {
+		return rep
+	}
+
+	rep.Flagged, rep.FlagReason, rep.FlaggedAt = e.flagged, e.flagReason, e.flaggedAt
+	for _, token := range e.tokens {
+		rep.Tokens = append(rep.Tokens, *token)
+	}
+	sort.Slice(rep.Tokens, func(i, j int) bool {
+		if !rep.Tokens[i].FirstSeen.Equal(rep.Tokens[j].FirstSeen) {
+			return rep.Tokens[i].FirstSeen.Before(rep.Tokens[j].FirstSeen)
+		}
+		return rep.Tokens[i].TokenMint < rep.Tokens[j].TokenMint
+	})
+	return rep
+}
+
+// record returns the creator a screening is recorded under, the token's
+// state after it, and whether the state changed. The book is not modified;
+// store the state with put.
+func (b *creatorBook) record(screening CreatorScreening) (string, CreatorToken, bool) {
+	// A token belongs to the creator it was first recorded under
+	creator, known := b.tokens[screening.TokenMint]
+	if !known {
+		creator = screening.Creator
+	}
+
+	token := CreatorToken{TokenMint: screening.TokenMint, FirstSeen: screening.ScreenedAt}
+	if known {
+		token = *b.creators[creator].tokens[screening.TokenMint]
+	}
+	prev := token
+
+	token.Failed = screening.Failed
+	if screening.LiquidityMeasured || screening.LiquidityUSD.IsPositive() {
+		if screening.LiquidityUSD.GreaterThan(token.PeakLiquidityUSD) {
+			token.PeakLiquidityUSD = screening.LiquidityUSD
+		}
+		if token.PeakLiquidityUSD.IsPositive() &&
+			screening.LiquidityUSD.LessThanOrEqual(token.PeakLiquidityUSD.Mul(b.rugFactor)) {
+			token.Rugged = true
+		}
+	}
+
+	changed := !known || token.Failed != prev.Failed || token.Rugged != prev.Rugged ||
+		!token.PeakLiquidityUSD.Equal(prev.PeakLiquidityUSD)
+	return creator, token, changed
+}
+
+// markRugged returns the creator and rugged state of a recorded token. The
+// book is not modified; store the state with put.
+func (b *creatorBook) markRugged(tokenMint string) (string, CreatorToken, error) {
+	creator, ok := b.tokens[tokenMint]
+	if !ok {
+		return "", CreatorToken{}, fmt.Errorf("token %s has no recorded creator: %w", tokenMint, ErrTokenNotFound)
+	}
+
+	token := *b.creators[creator].tokens[tokenMint]
+	token.Rugged = true
+	return creator, token, nil
+}
+
+// flagged reports whether a creator is flagged.
+func (b *creatorBook) flagged(creator string) bool {
+	e, ok := b.creators[creator]
+	return ok && e.flagged
+}
+
+// put stores a token's state under creator.
+func (b *creatorBook) put(creator string, token CreatorToken) {
+	b.entry(creator).tokens[token.TokenMint] = &token
+	b.tokens[token.TokenMint] = creator
+}
+
+// flag sets or clears a creator's flag.
+func (b *creatorBook) flag(creator string, flagged bool, reason string, at time.Time) {
+	e := b.entry(creator)
+	if !flagged {
+		reason, at = "", time.Time{}
+	}
+	e.flagged, e.flagReason, e.flaggedAt = flagged, reason, at
+}
+
+// ============================================================================
+// In-Memory Creator Store
+// ============================================================================
+
+// InMemoryCreatorStore is a CreatorStore held in process memory.
+//
+// Reputations are lost on restart; use FileCreatorStore to keep them.
+//
+// Features:
+//   - Thread-safe
+//   - Rug detection from liquidity drops
+//   - Manual flagging
+type InMemoryCreatorStore struct {
+	mu   sync.RWMutex
+	book *creatorBook
+}
+
+// NewInMemoryCreatorStore creates an empty in-memory creator store.
+func NewInMemoryCreatorStore(cfg CreatorStoreConfig) (*InMemoryCreatorStore, error) {
+	book, err := newCreatorBook(cfg.RugLiquidityDropPct)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InMemoryCreatorStore{book: book}, nil
+}
+
+// Reputation returns the creator's history.
+func (s *InMemoryCreatorStore) Reputation(_ context.Context, creator string) (*CreatorReputation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.book.reputation(creator), nil
+}
+
+// RecordScreening adds a screened token to its creator's history.
+func (s *InMemoryCreatorStore) RecordScreening(_ context.Context, screening CreatorScreening) error {
+	if screening.Creator == "" || screening.TokenMint == "" {
+		return fmt.Errorf("creator and token mint are required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	creator, token, _ := s.book.record(screening)
+	s.book.put(creator, token)
+	return nil
+}
+
+// MarkRugged marks a recorded token as rugged.
+func (s *InMemoryCreatorStore) MarkRugged(_ context.Context, tokenMint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	creator, token, err := s.book.markRugged(tokenMint)
+	if err != nil {
+		return err
+	}
+	s.book.put(creator, token)
+	return nil
+}
+
+// Flag marks a creator as bad.
+func (s *InMemoryCreatorStore) Flag(_ context.Context, creator, reason string) error {
+	if creator == "" {
+		return fmt.Errorf("creator is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.book.flag(creator, true, reason, time.Now())
+	return nil
+}
+
+// Unflag clears a creator's flag.
+func (s *InMemoryCreatorStore) Unflag(_ context.Context, creator string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.book.flagged(creator) {
+		s.book.flag(creator, false, "", time.Time{})
+	}
+	return nil
+}
+
+// ============================================================================
+// File-Backed Creator Store
+// ============================================================================
+
+// creatorRecordOp identifies the kind of record in the creator log.
+type creatorRecordOp string
+
+const (
+	creatorRecordToken  creatorRecordOp = "token"
+	creatorRecordFlag   creatorRecordOp = "flag"
+	creatorRecordUnflag creatorRecordOp = "unflag"
+)
+
+// creatorRecord is one line of the append-only creator log.
+type creatorRecord struct {
+	Op      creatorRecordOp `json:"op"`
+	Creator string          `json:"creator"`
+	Token   *CreatorToken   `json:"token,omitempty"`
+	Reason  string          `json:"reason,omitempty"`
+	At      time.Time       `json:"at"`
+}
+
+// FileCreatorStore is a persistent CreatorStore backed by an append-only
+// log file.
+//
+// Every change appends one JSON line to the log (screenings that change
+// nothing are not written) before it is applied in memory, so a failed
+// write leaves the store as it was. The log is replayed on open, and
+// compacted on open when most of it is superseded, or on demand with
+// Compact.
+//
+// Features:
+//   - Thread-safe within a process (not safe for multiple processes)
+//   - Reputations survive restarts
+//   - Skips corrupt records and tolerates a torn final line left by a
+//     crash mid-write
+type FileCreatorStore struct {
+	log     *appendLog
+	records int // Records in the log
+	mu      sync.RWMutex
+	book    *creatorBook
+}
+
+// FileCreatorStoreConfig holds configuration for FileCreatorStore.
+type FileCreatorStoreConfig struct {
+	// Path is the location of the log file (required).
+	// The parent directory must exist.
+	Path string
+
+	// RugLiquidityDropPct is the drop in liquidity from a token's peak, as
+	// a percentage, at which the token is marked rugged.
+	// Defaults to 90 if zero.
+	RugLiquidityDropPct decimal.Decimal
+
+	// SyncWrites fsyncs the log after every write.
+	SyncWrites bool
+}
+
+// NewFileCreatorStore opens (or creates) a file-backed creator store and
+// replays its log.
+func NewFileCreatorStore(cfg FileCreatorStoreConfig) (*FileCreatorStore, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("creator store path is required")
+	}
+	book, err := newCreatorBook(cfg.RugLiquidityDropPct)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := openAppendLog(cfg.Path, "creator store file", cfg.SyncWrites)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileCreatorStore{log: log, book: book}
+	if err := s.replay(); err != nil {
+		_ = log.close()
+		return nil, err
+	}
+	if s.records > 2*s.liveRecords() {
+		if err := s.compactLocked(); err != nil {
+			_ = s.log.close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// replay rebuilds the book from the log file.
+func (s *FileCreatorStore) replay() error {
+	return s.log.replay(func(line []byte, _ int64) error {
+		var rec creatorRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+
+		switch {
+		case rec.Op == creatorRecordToken && rec.Token != nil:
+			s.book.put(rec.Creator, *rec.Token)
+		case rec.Op == creatorRecordFlag:
+			s.book.flag(rec.Creator, true, rec.Reason, rec.At)
+		case rec.Op == creatorRecordUnflag:
+			s.book.flag(rec.Creator, false, "", time.Time{})
+		default:
+			return fmt.Errorf("unknown creator record %q", rec.Op)
+		}
+		s.records++
+		return nil
+	})
+}
+
+// Reputation returns the creator's history.
+func (s *FileCreatorStore) Reputation(_ context.Context, creator string) (*CreatorReputation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.book.reputation(creator), nil
+}
+
+// RecordScreening appends the change a screening makes to its creator's
+// history to the log, then applies it.
+func (s *FileCreatorStore) RecordScreening(_ context.Context, screening CreatorScreening) error {
+	if screening.Creator == "" || screening.TokenMint == "" {
+		return fmt.Errorf("creator and token mint are required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creator, token, changed := s.book.record(screening)
+	if !changed {
+		return nil
+	}
+	if err := s.appendLocked(creatorRecord{Op: creatorRecordToken, Creator: creator, Token: &token}); err != nil {
+		return err
+	}
+	s.book.put(creator, token)
+	return nil
+}
+
+// MarkRugged appends a recorded token's rugged state to the log, then
+// applies it.
+func (s *FileCreatorStore) MarkRugged(_ context.Context, tokenMint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creator, token, err := s.book.markRugged(tokenMint)
+	if err != nil {
+		return err
+	}
+	if err := s.appendLocked(creatorRecord{Op: creatorRecordToken, Creator: creator, Token: &token}); err != nil {
+		return err
+	}
+	s.book.put(creator, token)
+	return nil
+}
+
+// Flag appends a creator's flag to the log, then applies it.
+func (s *FileCreatorStore) Flag(_ context.Context, creator, reason string) error {
+	if creator == "" {
+		return fmt.Errorf("creator is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at := time.Now()
+	if err := s.appendLocked(creatorRecord{Op: creatorRecordFlag, Creator: creator, Reason: reason, At: at}); err != nil {
+		return err
+	}
+	s.book.flag(creator, true, reason, at)
+	return nil
+}
+
+// Unflag appends the clearing of a creator's flag to the log, then applies
+// it.
+func (s *FileCreatorStore) Unflag(_ context.Context, creator string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.book.flagged(creator) {
+		return nil
+	}
+	if err := s.appendLocked(creatorRecord{Op: creatorRecordUnflag, Creator: creator}); err != nil {
+		return err
+	}
+	s.book.flag(creator, false, "", time.Time{})
+	return nil
+}
+
+// Compact rewrites the log with one record per token and flagged creator.
+func (s *FileCreatorStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.compactLocked()
+}
+
+// Close flushes and closes the log file.
+func (s *FileCreatorStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.close()
+}
+
+// liveRecords returns the number of records a compacted log would hold.
+// Must be called with s.mu held.
+func (s *FileCreatorStore) liveRecords() int {
+	n := len(s.book.tokens)
+	for _, e := range s.book.creators {
+		if e.flagged {
+			n++
+		}
+	}
+	return n
+}
+
+// appendLocked writes a record to the end of the log.
+// Must be called with s.mu held (write lock).
+func (s *FileCreatorStore) appendLocked(rec creatorRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode creator record: %w", err)
+	}
+
+	if _, err := s.log.append(append(line, '\n')); err != nil {
+		return err
+	}
+	s.records++
+
+	return nil
+}
+
+// compactLocked rewrites the log from the book.
+// Must be called with s.mu held (write lock).
+func (s *FileCreatorStore) compactLocked() error {
+	records := 0
+	err := s.log.rewrite(func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		for creator, e := range s.book.creators {
+			for _, token := range e.tokens {
+				if err := encoder.Encode(creatorRecord{Op: creatorRecordToken, Creator: creator, Token: token}); err != nil {
+					return fmt.Errorf("write compaction file: %w", err)
+				}
+				records++
+			}
+			if e.flagged {
+				rec := creatorRecord{Op: creatorRecordFlag, Creator: creator, Reason: e.flagReason, At: e.flaggedAt}
+				if err := encoder.Encode(rec); err != nil {
+					return fmt.Errorf("write compaction file: %w", err)
+				}
+				records++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.records = records
+	return nil
+}
@@ -0,0 +1,345 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const testCreator = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+
+func newTestCreatorStores(t *testing.T) map[string]CreatorStore {
+	t.Helper()
+
+	memory, err := NewInMemoryCreatorStore(CreatorStoreConfig{})
+	if err != nil {
+		t.Fatalf("NewInMemoryCreatorStore() error = %v", err)
+	}
+	file, err := NewFileCreatorStore(FileCreatorStoreConfig{Path: filepath.Join(t.TempDir(), "creators.log")})
+	if err != nil {
+		t.Fatalf("NewFileCreatorStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = file.Close() })
+
+	return map[string]CreatorStore{"memory": memory, "file": file}
+}
+
+func TestCreatorStore_History(t *testing.T) {
+	for name, store := range newTestCreatorStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			start := time.Now()
+			record := func(mint string, failed bool, liquidity int64) {
+				t.Helper()
+				err := store.RecordScreening(ctx, CreatorScreening{
+					Creator:      testCreator,
+					TokenMint:    mint,
+					Failed:       failed,
+					LiquidityUSD: decimal.NewFromInt(liquidity),
+					ScreenedAt:   start.Add(time.Duration(len(mint)) * time.Minute),
+				})
+				if err != nil {
+					t.Fatalf("RecordScreening() error = %v", err)
+				}
+			}
+
+			record("a", true, 5000)
+			record("bb", false, 80_000)
+			record("bb", false, 9_000) // Above 10% of the peak
+			record("ccc", false, 50_000)
+			record("ccc", true, 4_000) // Dropped 92%: rugged
+			record("ccc", false, 60_000)
+			if err := store.MarkRugged(ctx, "a"); err != nil {
+				t.Fatalf("MarkRugged() error = %v", err)
+			}
+
+			rep, err := store.Reputation(ctx, testCreator)
+			if err != nil {
+				t.Fatalf("Reputation() error = %v", err)
+			}
+			if rep.Launched() != 3 || rep.Failed() != 1 || rep.Rugged() != 2 {
+				t.Errorf("expected 3 launched, 1 failed, 2 rugged; got %d, %d, %d",
+					rep.Launched(), rep.Failed(), rep.Rugged())
+			}
+
+			var mints []string
+			for _, token := range rep.Tokens {
+				mints = append(mints, token.TokenMint)
+			}
+			if !reflect.DeepEqual(mints, []string{"a", "bb", "ccc"}) {
+				t.Errorf("expected tokens oldest first, got %v", mints)
+			}
+			if peak := rep.Tokens[2].PeakLiquidityUSD; !peak.Equal(decimal.NewFromInt(60_000)) {
+				t.Errorf("expected peak liquidity 60000, got %s", peak)
+			}
+
+			if err := store.MarkRugged(ctx, "unknown"); !errors.Is(err, ErrTokenNotFound) {
+				t.Errorf("expected ErrTokenNotFound for unrecorded token, got %v", err)
+			}
+			if rep, _ := store.Reputation(ctx, "unknown-creator"); rep.Launched() != 0 || rep.Flagged {
+				t.Errorf("expected empty history for unknown creator, got %+v", rep)
+			}
+		})
+	}
+}
+
+func TestCreatorStore_Flag(t *testing.T) {
+	for name, store := range newTestCreatorStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if err := store.Flag(ctx, testCreator, "rugged on another chain"); err != nil {
+				t.Fatalf("Flag() error = %v", err)
+			}
+			rep, _ := store.Reputation(ctx, testCreator)
+			if !rep.Flagged || rep.FlagReason != "rugged on another chain" || rep.FlaggedAt.IsZero() {
+				t.Errorf("expected flagged creator, got %+v", rep)
+			}
+
+			if err := store.Unflag(ctx, testCreator); err != nil {
+				t.Fatalf("Unflag() error = %v", err)
+			}
+			if rep, _ := store.Reputation(ctx, testCreator); rep.Flagged || rep.FlagReason != "" {
+				t.Errorf("expected unflagged creator, got %+v", rep)
+			}
+
+			if err := store.Flag(ctx, "", "reason"); err == nil {
+				t.Error("expected error for empty creator")
+			}
+		})
+	}
+}
+
+func TestFileCreatorStore_Persists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creators.log")
+
+	store, err := NewFileCreatorStore(FileCreatorStoreConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileCreatorStore() error = %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		_ = store.RecordScreening(ctx, CreatorScreening{
+			Creator:      testCreator,
+			TokenMint:    "token-1",
+			Failed:       i%2 == 0,
+			LiquidityUSD: decimal.NewFromInt(int64(1000 * (i + 1))),
+			ScreenedAt:   time.Now(),
+		})
+	}
+	_ = store.MarkRugged(ctx, "token-1")
+	_ = store.Flag(ctx, testCreator, "serial rugger")
+	_ = store.Flag(ctx, "other-creator", "mistake")
+	_ = store.Unflag(ctx, "other-creator")
+	want, _ := store.Reputation(ctx, testCreator)
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Simulate a crash mid-write
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	_, _ = f.WriteString(`{"op":"flag","creator":"torn`)
+	_ = f.Close()
+
+	reopened, err := NewFileCreatorStore(FileCreatorStoreConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileCreatorStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+
+	got, _ := reopened.Reputation(ctx, testCreator)
+	if !got.Flagged || got.FlagReason != want.FlagReason || !got.FlaggedAt.Equal(want.FlaggedAt) ||
+		len(got.Tokens) != 1 || got.Tokens[0].Failed != want.Tokens[0].Failed || !got.Tokens[0].Rugged ||
+		!got.Tokens[0].PeakLiquidityUSD.Equal(decimal.NewFromInt(5000)) {
+		t.Errorf("expected %+v after reopen, got %+v", want, got)
+	}
+	if other, _ := reopened.Reputation(ctx, "other-creator"); other.Flagged {
+		t.Error("expected unflag to persist")
+	}
+
+	// Most records were superseded, so reopening compacted the log
+	if reopened.records != 2 {
+		t.Errorf("expected compacted log of 2 records, got %d", reopened.records)
+	}
+}
+
+func TestFileCreatorStore_FailedWrite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creators.log")
+	store, err := NewFileCreatorStore(FileCreatorStoreConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileCreatorStore() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	screening := CreatorScreening{Creator: testCreator, TokenMint: "token-1", LiquidityUSD: decimal.NewFromInt(1000)}
+	if err := store.RecordScreening(ctx, screening); err != nil {
+		t.Fatalf("RecordScreening() error = %v", err)
+	}
+
+	// Writes through a read-only descriptor fail
+	writable := store.log.file
+	readOnly, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	store.log.file = readOnly
+	screening.LiquidityUSD = decimal.NewFromInt(50)
+	if err := store.RecordScreening(ctx, screening); err == nil {
+		t.Error("expected write error for screening")
+	}
+	if err := store.MarkRugged(ctx, "token-1"); err == nil {
+		t.Error("expected write error for rug")
+	}
+	if err := store.Flag(ctx, testCreator, "serial rugger"); err == nil {
+		t.Error("expected write error for flag")
+	}
+	store.log.file = writable
+	_ = readOnly.Close()
+
+	// Nothing that failed to persist was applied
+	rep, _ := store.Reputation(ctx, testCreator)
+	if rep.Flagged || rep.Rugged() != 0 || !rep.Tokens[0].PeakLiquidityUSD.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("expected failed writes not applied, got %+v", rep)
+	}
+
+	// The rug is recorded on retry
+	if err := store.RecordScreening(ctx, screening); err != nil {
+		t.Fatalf("RecordScreening() retry error = %v", err)
+	}
+	if rep, _ := store.Reputation(ctx, testCreator); rep.Rugged() != 1 {
+		t.Errorf("expected rug recorded on retry, got %+v", rep)
+	}
+}
+
+func TestFileCreatorStore_CorruptRecord(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creators.log")
+	log := `{"op":"flag","creator":"creator-a","reason":"first"}
+{"op":"flag","creator":
+{"op":"rename","creator":"creator-b"}
+{"op":"flag","creator":"creator-b","reason":"second"}
+`
+	if err := os.WriteFile(path, []byte(log), 0o600); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+
+	store, err := NewFileCreatorStore(FileCreatorStoreConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewFileCreatorStore() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, creator := range []string{"creator-a", "creator-b"} {
+		if rep, _ := store.Reputation(ctx, creator); !rep.Flagged {
+			t.Errorf("expected %s flagged around corrupt records", creator)
+		}
+	}
+}
+
+func TestNewCreatorStore_Validation(t *testing.T) {
+	if _, err := NewInMemoryCreatorStore(CreatorStoreConfig{RugLiquidityDropPct: decimal.NewFromInt(101)}); err == nil {
+		t.Error("expected error for drop over 100%")
+	}
+	if _, err := NewFileCreatorStore(FileCreatorStoreConfig{}); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestScreener_Screen_CreatorReputation(t *testing.T) {
+	healthy := &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}
+	thin := &MarketData{LiquidityUSD: decimal.NewFromInt(500)}
+	drained := &MarketData{LiquidityReported: true}
+	unreported := &MarketData{}
+
+	// Screenings of the creator's earlier tokens
+	good := []*MarketData{healthy}
+	rug := []*MarketData{healthy, thin}
+	failed := []*MarketData{thin}
+
+	tests := []struct {
+		name       string
+		history    [][]*MarketData // Screenings of the creator's earlier tokens
+		flag       bool
+		level      ScreeningLevel
+		wantPassed bool
+		wantScore  int
+		wantReason string
+	}{
+		{"new creator", nil, false, ScreeningLevelNormal, true, 100, ""},
+		{"good history", [][]*MarketData{good, good}, false, ScreeningLevelNormal, true, 100, ""},
+		{"flagged", nil, true, ScreeningLevelNormal, false, 50, "creator_flagged"},
+		{"rugged before", [][]*MarketData{good, rug}, false, ScreeningLevelNormal, false, 60, "creator_rug_history:1"},
+		{"one rug allowed by relaxed", [][]*MarketData{good, rug}, false, ScreeningLevelRelaxed, true, 100, ""},
+		{"mostly failed", [][]*MarketData{failed, failed, good, failed, failed}, false, ScreeningLevelNormal, true, 85, ""},
+		{"drained before", [][]*MarketData{good, {healthy, drained}}, false, ScreeningLevelNormal, false, 60, "creator_rug_history:1"},
+		{"unreported liquidity is not a rug", [][]*MarketData{good, {healthy, unreported}}, false, ScreeningLevelNormal, true, 100, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store, err := NewInMemoryCreatorStore(CreatorStoreConfig{})
+			if err != nil {
+				t.Fatalf("NewInMemoryCreatorStore() error = %v", err)
+			}
+			market := &staticMarketData{}
+			screener, err := New(Config{
+				SecurityDataProvider: &staticSecurityData{data: &SecurityData{CreatorAddress: testCreator}},
+				MarketDataProvider:   market,
+				CreatorStore:         store,
+				Logger:               zap.NewNop(),
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			wantFailed := 0
+			for i, screenings := range tt.history {
+				for _, data := range screenings {
+					market.data = data
+					_, _ = screener.Screen(ctx, string(rune('a'+i)), tt.level)
+				}
+				if screenings[len(screenings)-1] != healthy {
+					wantFailed++
+				}
+			}
+			if tt.flag {
+				_ = store.Flag(ctx, testCreator, "known rugger")
+			}
+
+			market.data = healthy
+			result, err := screener.Screen(ctx, "new-token", tt.level)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if result.Passed != tt.wantPassed || result.Score != tt.wantScore {
+				t.Errorf("expected passed=%v score=%d, got passed=%v score=%d (reasons %v)",
+					tt.wantPassed, tt.wantScore, result.Passed, result.Score, result.FailureReasons)
+			}
+			if tt.wantReason != "" && (len(result.FailureReasons) != 1 || result.FailureReasons[0] != tt.wantReason) {
+				t.Errorf("expected reason %s, got %v", tt.wantReason, result.FailureReasons)
+			}
+			if result.Details.CreatorAddress != testCreator || result.Details.CreatorTokens != len(tt.history) {
+				t.Errorf("expected creator details for %d tokens, got %+v", len(tt.history), result.Details)
+			}
+
+			// The token is recorded with the verdict of the other checks
+			rep, _ := store.Reputation(ctx, testCreator)
+			if rep.Launched() != len(tt.history)+1 || rep.Failed() != wantFailed {
+				t.Errorf("expected new token recorded as passed, got %+v", rep.Tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// Provider-Agnostic Token Data
+// ============================================================================
+
+// SecurityData describes a token's on-chain security properties.
+//
+// It is the provider-agnostic input to the authority, holder concentration
+// and LP lock checks. Providers fill in what they know; fields a provider
+// cannot determine are left at their zero value.
+type SecurityData struct {
+	// Source names the provider that produced the data (e.g., "birdeye").
+	Source string `json:"source"`
+
+	// AsOf is when the data was observed.
+	AsOf time.Time `json:"asOf"`
+
+	// MintAuthority is the address allowed to mint new tokens.
+	// Empty means the authority is revoked.
+	MintAuthority string `json:"mintAuthority,omitempty"`
+
+	// FreezeAuthority is the address allowed to freeze token accounts.
+	// Empty means the authority is revoked.
+	FreezeAuthority string `json:"freezeAuthority,omitempty"`
+
+	// CreatorAddress is the wallet that created the token, if known.
+	CreatorAddress string `json:"creatorAddress,omitempty"`
+
+	// CreatedAt is when the token was created (zero if unknown).
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Supply is the total supply in whole tokens (zero if unknown).
+	Supply decimal.Decimal `json:"supply"`
+
+	// Decimals is the number of decimal places of the token.
+	Decimals int `json:"decimals"`
+
+	// Holder shares, as percentages of supply (0-100).
+	CreatorPct      decimal.Decimal `json:"creatorPct"`      // Held by the creator
+	TopHolderPct    decimal.Decimal `json:"topHolderPct"`    // Held by the largest single holder
+	Top10HoldersPct decimal.Decimal `json:"top10HoldersPct"` // Held by the ten largest holders
+
+	// HolderSharesUnknown is set by providers that cannot report the holder
+	// shares above (e.g., a source reading only the mint account). The
+	// shares are then zero but meaningless, and the checks judging them are
+	// skipped (see TokenScreeningResult.SkippedChecks).
+	HolderSharesUnknown bool `json:"holderSharesUnknown,omitempty"`
+
+	// Token-2022 features
+	IsToken2022     bool `json:"isToken2022"`     // Uses the Token-2022 program
+	HasTransferFee  bool `json:"hasTransferFee"`  // Transfer fee extension enabled
+	TransferFeeBPS  int  `json:"transferFeeBps"`  // Transfer fee in basis points
+	NonTransferable bool `json:"nonTransferable"` // Token is non-transferable (soulbound)
+	MutableMetadata bool `json:"mutableMetadata"` // Metadata can be changed
+
+	// PermanentDelegate can transfer or burn tokens from any account
+	// (Token-2022 extension). Empty if none.
+	PermanentDelegate string `json:"permanentDelegate,omitempty"`
+
+	// TransferHookProgram is invoked on every transfer and can block sells
+	// (Token-2022 extension). Empty if none.
+	TransferHookProgram string `json:"transferHookProgram,omitempty"`
+
+	// Extensions lists the Token-2022 extensions enabled on the mint, when
+	// the provider reports them (e.g., "transfer_fee_config").
+	Extensions []string `json:"extensions,omitempty"`
+
+	// Disagreements lists fields on which sources disagreed, when the data
+	// was reconciled from several sources (see CompositeSecurityProvider).
+	Disagreements []Disagreement `json:"disagreements,omitempty"`
+}
+
+// HasMintAuthority returns true if the token has an active mint authority.
+func (d *SecurityData) HasMintAuthority() bool {
+	return d.MintAuthority != ""
+}
+
+// HasFreezeAuthority returns true if the token has an active freeze authority.
+func (d *SecurityData) HasFreezeAuthority() bool {
+	return d.FreezeAuthority != ""
+}
+
+// MarketData describes a token's trading activity.
+//
+// It is the provider-agnostic input to the liquidity and market activity
+// checks. Providers that do not observe trading (e.g., pool reserves) leave
+// volume, trade counts and LastTradeAt zero, and the activity checks that
+// depend on them are skipped; likewise for zero Holders and MarketCapUSD.
+type MarketData struct {
+	// Source names the provider that produced the data (e.g., "birdeye").
+	Source string `json:"source"`
+
+	// AsOf is when the data was observed.
+	AsOf time.Time `json:"asOf"`
+
+	// Prices and sizes in USD
+	LiquidityUSD      decimal.Decimal `json:"liquidityUsd"`      // Total liquidity across pools
+	PriceUSD          decimal.Decimal `json:"priceUsd"`          // Current price
+	MarketCapUSD      decimal.Decimal `json:"marketCapUsd"`      // Market capitalization
+	Volume24hUSD      decimal.Decimal `json:"volume24hUsd"`      // Trading volume over 24 hours
+	PriceChange24hPct decimal.Decimal `json:"priceChange24hPct"` // Price change over 24 hours
+
+	// LiquidityReported is set when the provider measured liquidity, so a
+	// zero LiquidityUSD means drained pools rather than an unknown value.
+	LiquidityReported bool `json:"liquidityReported"`
+
+	// PriceChanges are price changes over further windows (e.g., 5m, 1h),
+	// when the provider reports them.
+	PriceChanges []PriceChange `json:"priceChanges,omitempty"`
+
+	// Activity counts
+	Holders   int `json:"holders"`   // Unique holders
+	Trades24h int `json:"trades24h"` // Trades in the last 24 hours
+	Buys24h   int `json:"buys24h"`   // Buy trades in the last 24 hours
+	Sells24h  int `json:"sells24h"`  // Sell trades in the last 24 hours
+
+	// LastTradeAt is the time of the most recent trade (zero if unknown).
+	LastTradeAt time.Time `json:"lastTradeAt"`
+
+	// Pools breaks liquidity down by pool, deepest first, when the provider
+	// reports it.
+	Pools []PoolLiquidity `json:"pools,omitempty"`
+
+	// Disagreements lists fields on which sources disagreed, when the data
+	// was reconciled from several sources (see CompositeMarketProvider).
+	Disagreements []Disagreement `json:"disagreements,omitempty"`
+}
+
+// PriceChange is the price change over a trailing window.
+type PriceChange struct {
+	Window time.Duration   `json:"window"`
+	Pct    decimal.Decimal `json:"pct"`
+}
+
+// reportsActivity reports whether the provider observed trading activity.
+func (d *MarketData) reportsActivity() bool {
+	return !d.Volume24hUSD.IsZero() || d.Trades24h > 0 || !d.LastTradeAt.IsZero()
+}
+
+// LiquidityByVenue sums pool liquidity per venue.
+// It returns nil if the provider did not report pools.
+func (d *MarketData) LiquidityByVenue() map[Venue]decimal.Decimal {
+	if len(d.Pools) == 0 {
+		return nil
+	}
+
+	byVenue := make(map[Venue]decimal.Decimal)
+	for _, pool := range d.Pools {
+		byVenue[pool.Venue] = byVenue[pool.Venue].Add(pool.LiquidityUSD)
+	}
+	return byVenue
+}
+
+// PoolLiquidity is the liquidity of a token in one pool.
+type PoolLiquidity struct {
+	Venue     Venue  `json:"venue"`     // Venue (AMM program) of the pool
+	Address   string `json:"address"`   // Pool account address
+	QuoteMint string `json:"quoteMint"` // Mint the token is paired against
+
+	TokenReserve decimal.Decimal `json:"tokenReserve"` // Token reserve in whole tokens
+	QuoteReserve decimal.Decimal `json:"quoteReserve"` // Quote reserve in whole tokens
+	PriceUSD     decimal.Decimal `json:"priceUsd"`     // Token spot price in this pool
+	LiquidityUSD decimal.Decimal `json:"liquidityUsd"` // USD value of the pool's reserves
+}
+
+// HolderShare is one owner's holdings of a token.
+type HolderShare struct {
+	// Owner is the wallet (or program authority) owning the token accounts.
+	Owner string `json:"owner"`
+
+	// Amount is the owner's combined balance in whole tokens.
+	Amount decimal.Decimal `json:"amount"`
+
+	// Pct is the owner's share of total supply (0-100).
+	Pct decimal.Decimal `json:"pct"`
+
+	// Accounts is the number of token accounts merged into this share.
+	Accounts int `json:"accounts"`
+}
+
+// HolderData describes how a token's supply is distributed among owners.
+//
+// It is the provider-agnostic input to the holder concentration check and,
+// when available, takes precedence over the shares in SecurityData.
+type HolderData struct {
+	// Source names the provider that produced the data (e.g., "solana-rpc").
+	Source string `json:"source"`
+
+	// AsOf is when the data was observed.
+	AsOf time.Time `json:"asOf"`
+
+	// Supply is the total supply in whole tokens.
+	Supply decimal.Decimal `json:"supply"`
+
+	// Holders lists the largest owners known to the provider, largest first.
+	Holders []HolderShare `json:"holders"`
+
+	// TopHolderPct is the share held by the largest single owner (0-100).
+	TopHolderPct decimal.Decimal `json:"topHolderPct"`
+
+	// Top10HoldersPct is the share held by the ten largest owners (0-100).
+	Top10HoldersPct decimal.Decimal `json:"top10HoldersPct"`
+
+	// Disagreements lists fields on which sources disagreed, when the data
+	// was reconciled from several sources (see CompositeHolderProvider).
+	Disagreements []Disagreement `json:"disagreements,omitempty"`
+}
+
+// ErrTokenNotFound is returned (wrapped) by providers when the token does
+// not exist. It is classified as ErrorClassNotFound by ClassifyError.
+var ErrTokenNotFound = errors.New("token not found")
+
+// ErrNotMint is returned (wrapped) by providers when the address exists but
+// is not a token mint. It is classified as ErrorClassPersistent.
+var ErrNotMint = errors.New("account is not a token mint")
+
+// SecurityDataProvider provides token security data from any source.
+type SecurityDataProvider interface {
+	GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error)
+}
+
+// HolderDataProvider provides token holder distribution from any source.
+type HolderDataProvider interface {
+	GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error)
+}
+
+// MarketDataProvider provides token market data from any source.
+type MarketDataProvider interface {
+	GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error)
+}
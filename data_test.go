@@ -0,0 +1,94 @@
+package tokenguard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// staticSecurityData is a SecurityDataProvider returning fixed data.
+type staticSecurityData struct {
+	data *SecurityData
+	err  error
+}
+
+func (p *staticSecurityData) GetSecurityData(_ context.Context, _ string) (*SecurityData, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.data, nil
+}
+
+// staticMarketData is a MarketDataProvider returning fixed data.
+type staticMarketData struct {
+	data *MarketData
+	err  error
+}
+
+func (p *staticMarketData) GetMarketData(_ context.Context, _ string) (*MarketData, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.data, nil
+}
+
+func TestScreener_Screen_ProviderAgnosticData(t *testing.T) {
+	screener, err := New(Config{
+		SecurityDataProvider: &staticSecurityData{data: &SecurityData{
+			Source:          "custom",
+			FreezeAuthority: "FreezeAuthority111",
+			CreatorPct:      decimal.NewFromInt(5),
+			TopHolderPct:    decimal.NewFromInt(30),
+			Top10HoldersPct: decimal.NewFromInt(50),
+		}},
+		MarketDataProvider: &staticMarketData{data: &MarketData{
+			Source:       "custom",
+			LiquidityUSD: decimal.NewFromInt(100000),
+		}},
+		Logger: zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if result.Passed {
+		t.Error("expected token to fail screening")
+	}
+	if !result.Details.HasFreezeAuthority || result.Details.HasMintAuthority {
+		t.Errorf("unexpected authorities: %+v", result.Details)
+	}
+	// The largest holder is reported separately from the creator.
+	if !contains(result.FailureReasons, "high_single_holder:30.00%") {
+		t.Errorf("expected single holder failure, got %v", result.FailureReasons)
+	}
+	if !result.Details.LPLockedPct.Equal(decimal.NewFromInt(95)) {
+		t.Errorf("expected LP locked estimated from creator share, got %s", result.Details.LPLockedPct)
+	}
+}
+
+func TestNew_PrefersProviderAgnosticData(t *testing.T) {
+	security := &staticSecurityData{data: &SecurityData{}}
+	market := &staticMarketData{data: &MarketData{}}
+
+	screener, err := New(Config{
+		SecurityDataProvider: security,
+		MarketDataProvider:   market,
+		SecurityProvider:     &mockSecurityProvider{},
+		OverviewProvider:     &mockOverviewProvider{},
+		Logger:               zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if screener.security != security || screener.market != market {
+		t.Error("expected provider-agnostic providers to take precedence")
+	}
+}
@@ -0,0 +1,345 @@
+package tokenguard
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultHolderDataTTL is the default time-to-live for cached security data
+// (holder distribution, creator holdings, Token-2022 flags).
+const DefaultHolderDataTTL = 2 * time.Minute
+
+// DefaultRevokedSecurityTTL is the default time-to-live for cached security
+// data of tokens whose mint and freeze authorities are both revoked.
+const DefaultRevokedSecurityTTL = 10 * time.Minute
+
+// DataCacheSource is the source named in disagreements between a provider
+// and remembered authority revocations.
+const DataCacheSource = "data-cache"
+
+// DefaultLiquidityDataTTL is the default time-to-live for cached market data
+// (liquidity). Liquidity moves by the second, so this is deliberately short.
+const DefaultLiquidityDataTTL = 10 * time.Second
+
+// ============================================================================
+// Per-Fact Data Cache
+// ============================================================================
+
+// dataEntry holds a cached provider response with expiration.
+type dataEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// isExpired returns true if the entry has passed its expiration time.
+func (e *dataEntry[T]) isExpired(now time.Time) bool {
+	return now.After(e.expiresAt)
+}
+
+// authorityFacts records authorities that have been observed as revoked.
+//
+// On Solana a revoked mint or freeze authority can never be re-enabled,
+// so once observed these facts never expire.
+type authorityFacts struct {
+	mintRevoked   bool
+	freezeRevoked bool
+}
+
+// DataCache caches screening inputs at the granularity of individual data
+// facts rather than whole screening results.
+//
+// Different inputs change at very different rates:
+//   - Revoked mint/freeze authority: permanent (can never be re-enabled)
+//   - Holder distribution: changes over minutes
+//   - Liquidity: changes by the second
+//
+// Re-screening a known token therefore only refetches the inputs whose TTL
+// has elapsed. Security data of a token with both authorities revoked is
+// kept for RevokedSecurityTTL, since its authority fields can no longer
+// change. This is complementary to Cache, which stores final results.
+//
+// Features:
+//   - Thread-safe
+//   - Separate TTLs for holder, liquidity and fully revoked security data
+//   - Remembered authority revocations (never expire, but are bounded)
+//   - Configurable max size per data kind
+type DataCache struct {
+	security    map[string]*dataEntry[*SecurityData]
+	market      map[string]*dataEntry[*MarketData]
+	holders     map[string]*dataEntry[*HolderData]
+	authorities map[string]authorityFacts
+
+	holderTTL    time.Duration
+	liquidityTTL time.Duration
+	revokedTTL   time.Duration
+	maxSize      int
+	mu           sync.RWMutex
+}
+
+// DataCacheConfig holds configuration for DataCache.
+type DataCacheConfig struct {
+	// HolderTTL is the time-to-live for security and holder data (holder
+	// distribution, creator holdings, Token-2022 flags).
+	// Defaults to 2 minutes if zero.
+	HolderTTL time.Duration
+
+	// LiquidityTTL is the time-to-live for market data (liquidity).
+	// Defaults to 10 seconds if zero.
+	LiquidityTTL time.Duration
+
+	// RevokedSecurityTTL is the time-to-live for security data of tokens
+	// whose mint and freeze authorities are both revoked. The holder facts
+	// it carries (creator holdings, holder shares) may then be this old;
+	// Config.MaxDataAge still bounds them.
+	// Defaults to 10 minutes if zero.
+	RevokedSecurityTTL time.Duration
+
+	// MaxSize is the maximum number of tokens tracked per data kind,
+	// including remembered authority revocations.
+	// Defaults to 10,000 if zero.
+	MaxSize int
+}
+
+// NewDataCache creates a new per-fact data cache.
+func NewDataCache(cfg DataCacheConfig) *DataCache {
+	if cfg.HolderTTL == 0 {
+		cfg.HolderTTL = DefaultHolderDataTTL
+	}
+	if cfg.LiquidityTTL == 0 {
+		cfg.LiquidityTTL = DefaultLiquidityDataTTL
+	}
+	if cfg.RevokedSecurityTTL == 0 {
+		cfg.RevokedSecurityTTL = DefaultRevokedSecurityTTL
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = DefaultMaxCacheSize
+	}
+
+	return &DataCache{
+		security:     make(map[string]*dataEntry[*SecurityData]),
+		market:       make(map[string]*dataEntry[*MarketData]),
+		holders:      make(map[string]*dataEntry[*HolderData]),
+		authorities:  make(map[string]authorityFacts),
+		holderTTL:    cfg.HolderTTL,
+		liquidityTTL: cfg.LiquidityTTL,
+		revokedTTL:   cfg.RevokedSecurityTTL,
+		maxSize:      cfg.MaxSize,
+	}
+}
+
+// Security returns cached security data for a token if it has not expired.
+func (c *DataCache) Security(tokenMint string) (*SecurityData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.security[tokenMint]
+	if !ok || entry.isExpired(time.Now()) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// SetSecurity stores freshly fetched security data for a token.
+//
+// Any revoked authority is recorded permanently. If the data claims an
+// authority is active that was previously observed as revoked, the revocation
+// wins, since revocation is irreversible: the stored copy is corrected and
+// the conflict is recorded as a Disagreement with DataCacheSource, so a
+// stale or faulty provider response stays visible.
+//
+// The returned value is the data as stored (with revocations applied), which
+// callers should use in place of the input.
+func (c *DataCache) SetSecurity(tokenMint string, security *SecurityData) *SecurityData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	facts := c.authorities[tokenMint]
+	if !security.HasMintAuthority() {
+		facts.mintRevoked = true
+	}
+	if !security.HasFreezeAuthority() {
+		facts.freezeRevoked = true
+	}
+	if facts.mintRevoked || facts.freezeRevoked {
+		if _, known := c.authorities[tokenMint]; !known && len(c.authorities) >= c.maxSize {
+			c.evictAuthorities()
+		}
+		c.authorities[tokenMint] = facts
+	}
+
+	security = applyAuthorityFacts(security, facts)
+
+	ttl := c.holderTTL
+	if facts.mintRevoked && facts.freezeRevoked {
+		ttl = c.revokedTTL
+	}
+
+	if _, isUpdate := c.security[tokenMint]; !isUpdate && len(c.security) >= c.maxSize {
+		evictData(c.security)
+	}
+	c.security[tokenMint] = &dataEntry[*SecurityData]{
+		value:     security,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	return security
+}
+
+// Market returns cached market data for a token if it has not expired.
+func (c *DataCache) Market(tokenMint string) (*MarketData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.market[tokenMint]
+	if !ok || entry.isExpired(time.Now()) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// SetMarket stores freshly fetched market data for a token.
+func (c *DataCache) SetMarket(tokenMint string, market *MarketData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, isUpdate := c.market[tokenMint]; !isUpdate && len(c.market) >= c.maxSize {
+		evictData(c.market)
+	}
+	c.market[tokenMint] = &dataEntry[*MarketData]{
+		value:     market,
+		expiresAt: time.Now().Add(c.liquidityTTL),
+	}
+}
+
+// Holders returns cached holder distribution for a token if it has not expired.
+func (c *DataCache) Holders(tokenMint string) (*HolderData, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.holders[tokenMint]
+	if !ok || entry.isExpired(time.Now()) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// SetHolders stores freshly fetched holder distribution for a token.
+// It expires with the holder TTL, like security data.
+func (c *DataCache) SetHolders(tokenMint string, holders *HolderData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, isUpdate := c.holders[tokenMint]; !isUpdate && len(c.holders) >= c.maxSize {
+		evictData(c.holders)
+	}
+	c.holders[tokenMint] = &dataEntry[*HolderData]{
+		value:     holders,
+		expiresAt: time.Now().Add(c.holderTTL),
+	}
+}
+
+// AuthoritiesRevoked reports whether the token's mint and freeze authorities
+// have ever been observed as revoked.
+func (c *DataCache) AuthoritiesRevoked(tokenMint string) (mintRevoked, freezeRevoked bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	facts := c.authorities[tokenMint]
+	return facts.mintRevoked, facts.freezeRevoked
+}
+
+// Forget removes all volatile data cached for a token.
+//
+// Permanent authority facts are kept, since they can never become untrue.
+func (c *DataCache) Forget(tokenMint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.security, tokenMint)
+	delete(c.market, tokenMint)
+	delete(c.holders, tokenMint)
+}
+
+// evictAuthorities makes room for one token's authority facts.
+// Must be called with the write lock held.
+//
+// Facts of tokens without cached security data are evicted first, since
+// they are the least likely to be screened again soon.
+func (c *DataCache) evictAuthorities() {
+	for key := range c.authorities {
+		if _, cached := c.security[key]; !cached {
+			delete(c.authorities, key)
+			return
+		}
+	}
+	for key := range c.authorities {
+		delete(c.authorities, key)
+		return
+	}
+}
+
+// applyAuthorityFacts returns security with known revocations applied,
+// recording each correction as a disagreement. The input is never modified;
+// a copy is returned if a correction is needed.
+func applyAuthorityFacts(security *SecurityData, facts authorityFacts) *SecurityData {
+	fixMint := facts.mintRevoked && security.HasMintAuthority()
+	fixFreeze := facts.freezeRevoked && security.HasFreezeAuthority()
+	if !fixMint && !fixFreeze {
+		return security
+	}
+
+	corrected := *security
+	corrected.Disagreements = append([]Disagreement(nil), security.Disagreements...)
+	if fixMint {
+		corrected.Disagreements = append(corrected.Disagreements, revokedDisagreement("mint_authority", security))
+		corrected.MintAuthority = ""
+	}
+	if fixFreeze {
+		corrected.Disagreements = append(corrected.Disagreements, revokedDisagreement("freeze_authority", security))
+		corrected.FreezeAuthority = ""
+	}
+	return &corrected
+}
+
+// revokedDisagreement records a provider reporting an active authority that
+// was observed as revoked.
+func revokedDisagreement(field string, security *SecurityData) Disagreement {
+	authority := security.MintAuthority
+	if field == "freeze_authority" {
+		authority = security.FreezeAuthority
+	}
+	return Disagreement{
+		Field: field,
+		Values: []SourceValue{
+			{Source: security.Source, Value: authority},
+			{Source: DataCacheSource, Value: "revoked"},
+		},
+		Resolved: "revoked",
+	}
+}
+
+// evictData makes room in a data map.
+// Must be called with the owning cache's write lock held.
+//
+// Expired entries are evicted first; if none were expired, one entry is
+// evicted (random due to map iteration order), matching InMemoryCache.
+func evictData[T any](entries map[string]*dataEntry[T]) {
+	now := time.Now()
+	evicted := false
+	for key, entry := range entries {
+		if entry.isExpired(now) {
+			delete(entries, key)
+			evicted = true
+		}
+	}
+
+	if !evicted {
+		for key := range entries {
+			delete(entries, key)
+			break // Only evict one
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package tokenguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	birdeye "github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func TestDataCache_SecurityTTL(t *testing.T) {
+	cache := NewDataCache(DataCacheConfig{HolderTTL: 10 * time.Millisecond, RevokedSecurityTTL: time.Minute})
+
+	cache.SetSecurity("test-mint", &SecurityData{MintAuthority: "SomeMintAuthority", Top10HoldersPct: decimal.NewFromInt(30)})
+	cache.SetSecurity("revoked-mint", &SecurityData{Top10HoldersPct: decimal.NewFromInt(30)})
+
+	if _, ok := cache.Security("test-mint"); !ok {
+		t.Fatal("expected security hit immediately after set")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Security("test-mint"); ok {
+		t.Error("expected security miss after holder TTL")
+	}
+	// Authorities that can no longer change need not be refetched as often
+	if _, ok := cache.Security("revoked-mint"); !ok {
+		t.Error("expected fully revoked security to outlive holder TTL")
+	}
+}
+
+func TestDataCache_OverviewTTL(t *testing.T) {
+	cache := NewDataCache(DataCacheConfig{
+		HolderTTL:    time.Minute,
+		LiquidityTTL: 10 * time.Millisecond,
+	})
+
+	cache.SetSecurity("test-mint", &SecurityData{})
+	cache.SetMarket("test-mint", &MarketData{LiquidityUSD: decimal.NewFromInt(1000)})
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Market("test-mint"); ok {
+		t.Error("expected market miss after liquidity TTL")
+	}
+	if _, ok := cache.Security("test-mint"); !ok {
+		t.Error("expected security to outlive liquidity TTL")
+	}
+}
+
+func TestDataCache_RevokedAuthoritiesArePermanent(t *testing.T) {
+	cache := NewDataCache(DataCacheConfig{HolderTTL: time.Minute})
+
+	cache.SetSecurity("test-mint", &SecurityData{
+		MintAuthority:   "",
+		FreezeAuthority: "SomeFreezeAuthority",
+	})
+
+	mintRevoked, freezeRevoked := cache.AuthoritiesRevoked("test-mint")
+	if !mintRevoked {
+		t.Error("expected mint authority to be recorded as revoked")
+	}
+	if freezeRevoked {
+		t.Error("expected freeze authority to not be recorded as revoked")
+	}
+
+	// A later response claiming an active mint authority contradicts an
+	// irreversible revocation and must be corrected.
+	input := &SecurityData{MintAuthority: "SomeMintAuthority"}
+	stored := cache.SetSecurity("test-mint", input)
+
+	if stored.HasMintAuthority() {
+		t.Error("expected revoked mint authority to override provider response")
+	}
+	if !input.HasMintAuthority() || len(input.Disagreements) != 0 {
+		t.Error("expected input to be left unmodified")
+	}
+	if len(stored.Disagreements) != 1 || stored.Disagreements[0].Field != "mint_authority" ||
+		stored.Disagreements[0].Values[1].Source != DataCacheSource || stored.Disagreements[0].Resolved != "revoked" {
+		t.Errorf("expected the correction recorded as a disagreement, got %+v", stored.Disagreements)
+	}
+
+	// Forgetting volatile data keeps the permanent facts.
+	cache.Forget("test-mint")
+	if _, ok := cache.Security("test-mint"); ok {
+		t.Error("expected security miss after Forget")
+	}
+	if mintRevoked, _ := cache.AuthoritiesRevoked("test-mint"); !mintRevoked {
+		t.Error("expected mint revocation to survive Forget")
+	}
+}
+
+func TestDataCache_MaxSize(t *testing.T) {
+	cache := NewDataCache(DataCacheConfig{MaxSize: 2})
+
+	for _, mint := range []string{"mint-A", "mint-B", "mint-C"} {
+		cache.SetMarket(mint, &MarketData{})
+	}
+
+	if len(cache.market) != 2 {
+		t.Errorf("expected 2 market entries, got %d", len(cache.market))
+	}
+
+	for _, mint := range []string{"mint-A", "mint-B", "mint-C"} {
+		cache.SetSecurity(mint, &SecurityData{})
+	}
+	if len(cache.authorities) != 2 {
+		t.Errorf("expected 2 authority entries, got %d", len(cache.authorities))
+	}
+	if mintRevoked, _ := cache.AuthoritiesRevoked("mint-C"); !mintRevoked {
+		t.Error("expected newest revocation to be kept")
+	}
+}
+
+func TestScreener_Screen_WithDataCache(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	securityCalls := 0
+	countingProvider := &countingSecurityProvider{
+		inner: &mockSecurityProvider{
+			security: &birdeye.TokenSecurity{
+				CreatorPercentage:  "5",
+				Top10HolderPercent: "30",
+			},
+		},
+		callCount: &securityCalls,
+	}
+
+	overviewCalls := 0
+	overviewProvider := &countingOverviewProvider{
+		inner: &mockOverviewProvider{
+			overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(100000)},
+		},
+		callCount: &overviewCalls,
+	}
+
+	screener, err := New(Config{
+		SecurityProvider: countingProvider,
+		OverviewProvider: overviewProvider,
+		DataCache: NewDataCache(DataCacheConfig{
+			HolderTTL:    time.Minute,
+			LiquidityTTL: 10 * time.Millisecond,
+		}),
+		Logger: logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to create screener: %v", err)
+	}
+
+	if _, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal); err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	// All checks share one security fetch within a screening.
+	if securityCalls != 1 {
+		t.Errorf("expected 1 security call, got %d", securityCalls)
+	}
+	if overviewCalls != 1 {
+		t.Errorf("expected 1 overview call, got %d", overviewCalls)
+	}
+
+	// Re-screening after liquidity expires only refetches liquidity.
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal); err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if securityCalls != 1 {
+		t.Errorf("expected security data to be served from cache, got %d calls", securityCalls)
+	}
+	if overviewCalls != 2 {
+		t.Errorf("expected liquidity to be refetched, got %d overview calls", overviewCalls)
+	}
+}
+
+// countingOverviewProvider wraps an overview provider to count calls.
+type countingOverviewProvider struct {
+	inner     TokenOverviewProvider
+	callCount *int
+}
+
+func (c *countingOverviewProvider) GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error) {
+	*c.callCount++
+	return c.inner.GetTokenOverview(ctx, address)
+}
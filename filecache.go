@@ -0,0 +1,391 @@
+package tokenguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFileCacheMaxBytes is the default disk budget for FileCache.
+const DefaultFileCacheMaxBytes = 64 << 20 // 64MB
+
+// ============================================================================
+// File-Backed Cache Implementation
+// ============================================================================
+
+// fileRecordOp identifies the kind of record in the cache log.
+type fileRecordOp string
+
+const (
+	fileRecordSet    fileRecordOp = "set"
+	fileRecordDelete fileRecordOp = "del"
+)
+
+// fileRecord is one line of the append-only cache log.
+type fileRecord struct {
+	Op        fileRecordOp          `json:"op"`
+	TokenMint string                `json:"tokenMint"`
+	ExpiresAt time.Time             `json:"expiresAt,omitempty"`
+	Result    *TokenScreeningResult `json:"result,omitempty"`
+}
+
+// fileIndexEntry locates the latest live record for a token in the log.
+type fileIndexEntry struct {
+	offset    int64
+	length    int64
+	expiresAt time.Time
+}
+
+// FileCache is a persistent Cache backed by an append-only log file.
+//
+// Every Set and Delete appends one JSON line to the log, and an in-memory
+// index maps each token to the offset of its latest record, so reads cost a
+// single positioned read. On open the log is replayed to rebuild the index,
+// which means screening history survives process restarts.
+//
+// Expiration uses absolute timestamps stored in the log, so TTLs are honored
+// across restarts: an entry that expired while the process was down is
+// discarded during replay.
+//
+// Features:
+//   - Thread-safe within a process (not safe for multiple processes)
+//   - Configurable TTL
+//   - Bounded disk usage via compaction and eviction
+//   - Tolerates a torn final line left by a crash mid-write, and skips
+//     corrupt records followed by valid ones
+type FileCache struct {
+	log      *appendLog
+	index    map[string]*fileIndexEntry
+	live     int64 // Bytes occupied by records referenced from the index
+	ttl      time.Duration
+	maxBytes int64
+	mu       sync.RWMutex
+}
+
+// FileCacheConfig holds configuration for FileCache.
+type FileCacheConfig struct {
+	// Path is the location of the cache log file (required).
+	// The parent directory must exist.
+	Path string
+
+	// TTL is the time-to-live for cached entries.
+	// Defaults to 5 minutes if zero.
+	TTL time.Duration
+
+	// MaxBytes bounds the size of the log file on disk.
+	// When a write would exceed it, the log is compacted; if live entries
+	// would still fill more than three quarters of it, the entries closest
+	// to expiry are evicted first, so that compactions stay infrequent.
+	// Defaults to 64MB if zero.
+	MaxBytes int64
+
+	// SyncWrites fsyncs the log after every write.
+	// Slower, but guarantees acknowledged writes survive a power loss.
+	SyncWrites bool
+}
+
+// NewFileCache opens (or creates) a file-backed cache and replays its log.
+func NewFileCache(cfg FileCacheConfig) (*FileCache, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("cache path is required")
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultCacheTTL
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = DefaultFileCacheMaxBytes
+	}
+
+	log, err := openAppendLog(cfg.Path, "cache file", cfg.SyncWrites)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &FileCache{
+		log:      log,
+		index:    make(map[string]*fileIndexEntry),
+		ttl:      cfg.TTL,
+		maxBytes: cfg.MaxBytes,
+	}
+
+	if err := c.replay(); err != nil {
+		_ = log.close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// replay rebuilds the index from the log file.
+func (c *FileCache) replay() error {
+	now := time.Now()
+	return c.log.replay(func(line []byte, offset int64) error {
+		var rec fileRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+
+		length := int64(len(line))
+		c.forgetLocked(rec.TokenMint)
+		if rec.Op == fileRecordSet && now.Before(rec.ExpiresAt) {
+			c.index[rec.TokenMint] = &fileIndexEntry{
+				offset:    offset,
+				length:    length,
+				expiresAt: rec.ExpiresAt,
+			}
+			c.live += length
+		}
+		return nil
+	})
+}
+
+// Get retrieves a cached screening result.
+//
+// Returns the result and true if found and not expired.
+// Returns nil and false if not found, expired, or unreadable.
+func (c *FileCache) Get(_ context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	result, _, ok := c.get(tokenMint)
+	return result, ok
+}
+
+// GetWithTTL retrieves a cached screening result and its remaining time-to-live.
+func (c *FileCache) GetWithTTL(_ context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool) {
+	result, expiresAt, ok := c.get(tokenMint)
+	if !ok {
+		return nil, 0, false
+	}
+	return result, time.Until(expiresAt), true
+}
+
+// TTL returns the time-to-live applied by Set.
+func (c *FileCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// get reads the latest record for a token along with its expiration.
+func (c *FileCache) get(tokenMint string) (*TokenScreeningResult, time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.index[tokenMint]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, time.Time{}, false
+	}
+
+	buf := make([]byte, entry.length)
+	if err := c.log.readAt(buf, entry.offset); err != nil {
+		return nil, time.Time{}, false
+	}
+
+	var rec fileRecord
+	if err := json.Unmarshal(buf, &rec); err != nil || rec.Result == nil {
+		return nil, time.Time{}, false
+	}
+
+	return rec.Result, entry.expiresAt, true
+}
+
+// Set stores a screening result in the cache.
+func (c *FileCache) Set(_ context.Context, result *TokenScreeningResult) error {
+	return c.set(result, time.Now().Add(c.ttl))
+}
+
+// set appends a record for result that expires at expiresAt.
+func (c *FileCache) set(result *TokenScreeningResult, expiresAt time.Time) error {
+	line, err := encodeFileRecord(fileRecord{
+		Op:        fileRecordSet,
+		TokenMint: result.TokenMint,
+		ExpiresAt: expiresAt,
+		Result:    result,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.makeRoomLocked(result.TokenMint, int64(len(line))); err != nil {
+		return err
+	}
+
+	offset, err := c.log.append(line)
+	if err != nil {
+		return err
+	}
+
+	c.forgetLocked(result.TokenMint)
+	c.index[result.TokenMint] = &fileIndexEntry{
+		offset:    offset,
+		length:    int64(len(line)),
+		expiresAt: expiresAt,
+	}
+	c.live += int64(len(line))
+
+	return nil
+}
+
+// Delete removes an entry from the cache.
+func (c *FileCache) Delete(_ context.Context, tokenMint string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.index[tokenMint]; !ok {
+		return nil
+	}
+
+	line, err := encodeFileRecord(fileRecord{Op: fileRecordDelete, TokenMint: tokenMint})
+	if err != nil {
+		return err
+	}
+	if _, err := c.log.append(line); err != nil {
+		return err
+	}
+
+	c.forgetLocked(tokenMint)
+	return nil
+}
+
+// Size returns the number of entries in the cache (including expired).
+func (c *FileCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.index)
+}
+
+// DiskUsage returns the current size of the log file in bytes.
+func (c *FileCache) DiskUsage() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.log.size
+}
+
+// Compact rewrites the log so it contains only live, unexpired entries.
+func (c *FileCache) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.compactLocked()
+}
+
+// Close flushes and closes the log file.
+func (c *FileCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.log.close()
+}
+
+// forgetLocked drops a token from the index.
+// Must be called with c.mu held (write lock).
+func (c *FileCache) forgetLocked(tokenMint string) {
+	if entry, ok := c.index[tokenMint]; ok {
+		c.live -= entry.length
+		delete(c.index, tokenMint)
+	}
+}
+
+// makeRoomLocked ensures a record of n bytes for tokenMint fits in the
+// disk budget. Must be called with c.mu held (write lock).
+//
+// Strategy:
+// 1. If the log has room, do nothing
+// 2. Evict expired entries, then entries closest to expiry, until the live
+// data plus the new record fits under the low-water mark (3/4 of MaxBytes)
+// 3. Compact the log to reclaim the space
+//
+// Evicting below the budget leaves room for many appends before the next
+// compaction, instead of rewriting the log on every write at capacity.
+func (c *FileCache) makeRoomLocked(tokenMint string, n int64) error {
+	if c.log.size+n <= c.maxBytes {
+		return nil
+	}
+	if n > c.maxBytes {
+		return fmt.Errorf("cache record of %d bytes exceeds max size of %d bytes", n, c.maxBytes)
+	}
+
+	// The record being replaced will not survive compaction either way.
+	c.forgetLocked(tokenMint)
+
+	now := time.Now()
+	for key, entry := range c.index {
+		if now.After(entry.expiresAt) {
+			c.forgetLocked(key)
+		}
+	}
+
+	lowWater := c.maxBytes / 4 * 3
+	if c.live+n > lowWater {
+		keys := make([]string, 0, len(c.index))
+		for key := range c.index {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return c.index[keys[i]].expiresAt.Before(c.index[keys[j]].expiresAt)
+		})
+		for _, key := range keys {
+			if c.live+n <= lowWater {
+				break
+			}
+			c.forgetLocked(key)
+		}
+	}
+
+	return c.compactLocked()
+}
+
+// compactLocked rewrites the log with only live, unexpired records.
+// Must be called with c.mu held (write lock).
+func (c *FileCache) compactLocked() error {
+	now := time.Now()
+	index := make(map[string]*fileIndexEntry, len(c.index))
+
+	err := c.log.rewrite(func(w io.Writer) error {
+		var offset int64
+		for key, entry := range c.index {
+			if now.After(entry.expiresAt) {
+				continue
+			}
+
+			buf := make([]byte, entry.length)
+			if err := c.log.readAt(buf, entry.offset); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf); err != nil {
+				return fmt.Errorf("write compaction file: %w", err)
+			}
+
+			index[key] = &fileIndexEntry{
+				offset:    offset,
+				length:    entry.length,
+				expiresAt: entry.expiresAt,
+			}
+			offset += entry.length
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.index = index
+	c.live = c.log.size
+
+	return nil
+}
+
+// encodeFileRecord serializes a record as a single newline-terminated line.
+func encodeFileRecord(rec fileRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(rec); err != nil {
+		return nil, fmt.Errorf("encode cache record: %w", err)
+	}
+	return buf.Bytes(), nil
+}
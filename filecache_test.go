@@ -0,0 +1,322 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestFileCache(t *testing.T, cfg FileCacheConfig) *FileCache {
+	t.Helper()
+
+	if cfg.Path == "" {
+		cfg.Path = filepath.Join(t.TempDir(), "cache.log")
+	}
+	cache, err := NewFileCache(cfg)
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+	return cache
+}
+
+func TestNewFileCache_RequiresPath(t *testing.T) {
+	if _, err := NewFileCache(FileCacheConfig{}); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestFileCache_SetGet(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestFileCache(t, FileCacheConfig{TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	result := &TokenScreeningResult{
+		TokenMint: "test-mint",
+		Passed:    true,
+		Score:     85,
+		Level:     ScreeningLevelStrict,
+		Details: ScreeningDetails{
+			LiquidityUSD:    decimal.RequireFromString("75000.123456789012345"),
+			Top10HoldersPct: decimal.NewFromFloat(35.2),
+		},
+		FailureReasons: []string{"has_freeze_authority"},
+		ScreenedAt:     time.Now(),
+	}
+
+	if err := cache.Set(ctx, result); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := cache.Get(ctx, "test-mint")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if got.Score != 85 || got.Level != ScreeningLevelStrict {
+		t.Errorf("result mismatch: got %+v", got)
+	}
+	if !got.Details.LiquidityUSD.Equal(result.Details.LiquidityUSD) {
+		t.Errorf("LiquidityUSD mismatch: got %s", got.Details.LiquidityUSD)
+	}
+	if len(got.FailureReasons) != 1 || got.FailureReasons[0] != "has_freeze_authority" {
+		t.Errorf("FailureReasons mismatch: got %v", got.FailureReasons)
+	}
+
+	if _, ok := cache.Get(ctx, "nonexistent"); ok {
+		t.Error("expected cache miss")
+	}
+}
+
+func TestFileCache_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.log")
+
+	cache := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	for _, mint := range []string{"mint-A", "mint-B", "mint-C"} {
+		if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: mint, Score: 90}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-A", Score: 40}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := cache.Delete(ctx, "mint-B"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	defer func() { _ = reopened.Close() }()
+
+	got, ok := reopened.Get(ctx, "mint-A")
+	if !ok {
+		t.Fatal("expected mint-A to survive restart")
+	}
+	if got.Score != 40 {
+		t.Errorf("expected latest score 40, got %d", got.Score)
+	}
+	if _, ok := reopened.Get(ctx, "mint-B"); ok {
+		t.Error("expected deleted mint-B to stay deleted")
+	}
+	if _, ok := reopened.Get(ctx, "mint-C"); !ok {
+		t.Error("expected mint-C to survive restart")
+	}
+}
+
+func TestFileCache_TTLAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.log")
+
+	cache := newTestFileCache(t, FileCacheConfig{Path: path, TTL: 10 * time.Millisecond})
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	_ = cache.Close()
+
+	time.Sleep(20 * time.Millisecond)
+
+	reopened := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	defer func() { _ = reopened.Close() }()
+
+	if _, ok := reopened.Get(ctx, "test-mint"); ok {
+		t.Error("expected entry that expired while closed to be discarded")
+	}
+	if reopened.Size() != 0 {
+		t.Errorf("expected size 0, got %d", reopened.Size())
+	}
+}
+
+func TestFileCache_TornWrite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.log")
+
+	cache := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint", Score: 70}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	_ = cache.Close()
+
+	// Simulate a crash halfway through appending a record.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, _ = f.WriteString(`{"op":"set","tokenMint":"other`)
+	_ = f.Close()
+
+	reopened := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	defer func() { _ = reopened.Close() }()
+
+	if got, ok := reopened.Get(ctx, "test-mint"); !ok || got.Score != 70 {
+		t.Error("expected records before the torn write to survive")
+	}
+
+	// New writes must land after the valid prefix.
+	if err := reopened.Set(ctx, &TokenScreeningResult{TokenMint: "other", Score: 60}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, ok := reopened.Get(ctx, "other"); !ok || got.Score != 60 {
+		t.Error("expected write after recovery to be readable")
+	}
+}
+
+func TestFileCache_CorruptRecord(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.log")
+
+	cache := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-A", Score: 70})
+	_ = cache.Close()
+
+	// A corrupt record in the middle of the log, followed by valid ones
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, _ = f.WriteString("{\"op\":\"set\",\"tokenMint\":\"mint-\n")
+	_ = f.Close()
+	cache = newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-B", Score: 60})
+	_ = cache.Close()
+
+	reopened := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	defer func() { _ = reopened.Close() }()
+
+	for mint, score := range map[string]int{"mint-A": 70, "mint-B": 60} {
+		if got, ok := reopened.Get(ctx, mint); !ok || got.Score != score {
+			t.Errorf("expected %s to survive a corrupt record before it", mint)
+		}
+	}
+}
+
+func TestFileCache_FailedWrite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.log")
+	cache := newTestFileCache(t, FileCacheConfig{Path: path, TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-A", Score: 70})
+	size := cache.DiskUsage()
+
+	// Writes through a read-only descriptor fail
+	writable := cache.log.file
+	readOnly, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	cache.log.file = readOnly
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-B"}); err == nil {
+		t.Fatal("expected write error")
+	}
+	cache.log.file = writable
+	_ = readOnly.Close()
+
+	if cache.DiskUsage() != size {
+		t.Errorf("expected size %d after failed write, got %d", size, cache.DiskUsage())
+	}
+	if _, ok := cache.Get(ctx, "mint-B"); ok {
+		t.Error("expected failed write not to be indexed")
+	}
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "mint-C", Score: 50}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, ok := cache.Get(ctx, "mint-C"); !ok || got.Score != 50 {
+		t.Error("expected write after a failed write to be readable")
+	}
+}
+
+func TestFileCache_BoundsDiskUsage(t *testing.T) {
+	ctx := context.Background()
+	const maxBytes = 32 << 10 // Room for about 36 of the 100 tokens
+	cache := newTestFileCache(t, FileCacheConfig{TTL: time.Minute, MaxBytes: maxBytes})
+	defer func() { _ = cache.Close() }()
+
+	compactions := 0
+	for i := 0; i < 200; i++ {
+		result := &TokenScreeningResult{
+			TokenMint:      fmt.Sprintf("mint-%d", i%100),
+			Score:          i % 100,
+			FailureReasons: []string{"low_liquidity:$1000.00"},
+		}
+		before := cache.DiskUsage()
+		if err := cache.Set(ctx, result); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		usage := cache.DiskUsage()
+		if usage > maxBytes {
+			t.Fatalf("disk usage %d exceeds budget %d", usage, maxBytes)
+		}
+		if usage < before+100 { // Records are about 900 bytes
+			compactions++
+		}
+	}
+
+	// Evicting to the low-water mark spreads compactions out
+	if compactions > 200/4 {
+		t.Errorf("expected infrequent compactions, got %d in 200 writes", compactions)
+	}
+
+	// The most recent write must always be retained.
+	if got, ok := cache.Get(ctx, "mint-99"); !ok || got.Score != 99 {
+		t.Error("expected most recent entry to be present")
+	}
+}
+
+func TestFileCache_Compact(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestFileCache(t, FileCacheConfig{TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	// Fixed timestamps, so every record encodes to the same length
+	screenedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	for i := 0; i < 10; i++ {
+		result := &TokenScreeningResult{TokenMint: "test-mint", Score: i, ScreenedAt: screenedAt}
+		if err := cache.set(result, expiresAt); err != nil {
+			t.Fatalf("set() error = %v", err)
+		}
+	}
+	before := cache.DiskUsage()
+
+	if err := cache.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+
+	if after := cache.DiskUsage(); after*10 != before {
+		t.Errorf("expected compaction to keep 1 of 10 records, got %d -> %d bytes", before, after)
+	}
+	if got, ok := cache.Get(ctx, "test-mint"); !ok || got.Score != 9 {
+		t.Error("expected latest value after compaction")
+	}
+}
+
+func TestFileCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestFileCache(t, FileCacheConfig{TTL: time.Minute, MaxBytes: 8192})
+	defer func() { _ = cache.Close() }()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				mint := fmt.Sprintf("mint-%d", (g*50+i)%20)
+				_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: mint, Score: i})
+				cache.Get(ctx, mint)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if usage := cache.DiskUsage(); usage > 8192 {
+		t.Errorf("disk usage %d exceeds budget", usage)
+	}
+}
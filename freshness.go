@@ -0,0 +1,103 @@
+package tokenguard
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StaleDataAction is what happens to a screening whose liquidity or holder
+// data is older than Config.MaxDataAge.
+type StaleDataAction string
+
+// Stale data actions.
+const (
+	// StaleDataFail fails the screening with a "stale_<kind>_data" reason.
+	// Such results are not cached, so the next screening tries again.
+	StaleDataFail StaleDataAction = "fail"
+
+	// StaleDataFlag only lists the stale data in
+	// TokenScreeningResult.StaleData; the checks are judged as usual.
+	StaleDataFlag StaleDataAction = "flag"
+)
+
+// Kinds of data subject to the maximum age, as listed in
+// TokenScreeningResult.StaleData.
+const (
+	staleLiquidity = "liquidity"
+	staleHolder    = "holder"
+)
+
+// validStaleDataAction checks if a stale data action is valid.
+func validStaleDataAction(action StaleDataAction) bool {
+	switch action {
+	case StaleDataFail, StaleDataFlag:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordAsOf notes when data from source was observed. A source serving
+// several kinds of data is reported at its oldest observation. Unknown
+// (zero) times are not recorded.
+func recordAsOf(result *TokenScreeningResult, source string, asOf time.Time) {
+	if asOf.IsZero() {
+		return
+	}
+	if result.DataAsOf == nil {
+		result.DataAsOf = make(map[string]time.Time)
+	}
+	if prev, ok := result.DataAsOf[source]; !ok || asOf.Before(prev) {
+		result.DataAsOf[source] = asOf
+	}
+}
+
+// checkFreshness records the observation time of data used by a check and
+// applies the stale data action if it is older than the maximum age.
+// Data of unknown age is not judged stale.
+func (s *Screener) checkFreshness(
+	tokenMint string,
+	kind string,
+	source string,
+	asOf time.Time,
+	result *TokenScreeningResult,
+) {
+	recordAsOf(result, source, asOf)
+
+	if s.maxDataAge == 0 || asOf.IsZero() {
+		return
+	}
+	age := result.ScreenedAt.Sub(asOf)
+	if age <= s.maxDataAge {
+		return
+	}
+
+	result.StaleData = append(result.StaleData, kind)
+	if s.staleAction == StaleDataFail {
+		result.Passed = false
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("stale_%s_data:%s", kind, age.Truncate(time.Second)))
+	}
+
+	s.logger.Debug("stale screening data",
+		zap.String("token_mint", tokenMint),
+		zap.String("kind", kind),
+		zap.String("source", source),
+		zap.Duration("age", age),
+		zap.Duration("max_age", s.maxDataAge),
+	)
+}
+
+// cachedTooOld reports whether cached data observed at asOf is already
+// older than the maximum age. Such entries are refetched rather than judged
+// stale, since the provider may have fresher data.
+func (s *Screener) cachedTooOld(asOf time.Time) bool {
+	return s.maxDataAge > 0 && !asOf.IsZero() && time.Since(asOf) > s.maxDataAge
+}
+
+// failedStale reports whether result failed because of stale data.
+func (s *Screener) failedStale(result *TokenScreeningResult) bool {
+	return s.staleAction == StaleDataFail && len(result.StaleData) > 0
+}
@@ -0,0 +1,161 @@
+package tokenguard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// newFreshnessScreener screens clean data observed at the given times.
+func newFreshnessScreener(t *testing.T, securityAsOf, marketAsOf time.Time, cfg Config) *Screener {
+	t.Helper()
+
+	cfg.SecurityDataProvider = &staticSecurityData{data: &SecurityData{Source: "rpc", AsOf: securityAsOf}}
+	cfg.MarketDataProvider = &staticMarketData{data: &MarketData{
+		Source:       "pools",
+		AsOf:         marketAsOf,
+		LiquidityUSD: decimal.NewFromInt(100000),
+	}}
+	cfg.Logger = zap.NewNop()
+
+	screener, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return screener
+}
+
+func TestNew_FreshnessValidation(t *testing.T) {
+	base := Config{
+		SecurityDataProvider: &staticSecurityData{},
+		MarketDataProvider:   &staticMarketData{},
+		Logger:               zap.NewNop(),
+	}
+
+	cfg := base
+	cfg.MaxDataAge = -time.Second
+	if _, err := New(cfg); err == nil {
+		t.Error("expected error for negative max data age")
+	}
+
+	cfg = base
+	cfg.StaleDataAction = "ignore"
+	if _, err := New(cfg); err == nil {
+		t.Error("expected error for invalid stale data action")
+	}
+}
+
+func TestScreener_Screen_RecordsDataAsOf(t *testing.T) {
+	securityAsOf := time.Now().Add(-time.Hour).Truncate(time.Second)
+	marketAsOf := time.Now().Add(-time.Second).Truncate(time.Second)
+	screener := newFreshnessScreener(t, securityAsOf, marketAsOf, Config{})
+
+	result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if !result.DataAsOf["rpc"].Equal(securityAsOf) || !result.DataAsOf["pools"].Equal(marketAsOf) {
+		t.Errorf("unexpected DataAsOf %v", result.DataAsOf)
+	}
+	// Without a maximum age, old data is accepted.
+	if !result.Passed || len(result.StaleData) != 0 {
+		t.Errorf("expected pass without stale data, got %v %v", result.FailureReasons, result.StaleData)
+	}
+}
+
+func TestScreener_Screen_StaleData(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		securityAsOf time.Time
+		marketAsOf   time.Time
+		action       StaleDataAction
+		wantPassed   bool
+		wantStale    []string
+	}{
+		{"fresh data", now, now, StaleDataFail, true, nil},
+		{"stale liquidity fails", now, now.Add(-time.Hour), StaleDataFail, false, []string{"liquidity"}},
+		{"stale holder shares fail", now.Add(-time.Hour), now, "", false, []string{"holder"}},
+		{"stale data flagged", now.Add(-time.Hour), now.Add(-time.Hour), StaleDataFlag, true, []string{"liquidity", "holder"}},
+		{"unknown age accepted", time.Time{}, time.Time{}, StaleDataFail, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+			screener := newFreshnessScreener(t, tt.securityAsOf, tt.marketAsOf, Config{
+				Cache:           cache,
+				MaxDataAge:      time.Minute,
+				StaleDataAction: tt.action,
+			})
+
+			result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if result.Passed != tt.wantPassed {
+				t.Errorf("expected passed=%v, got %v (reasons %v)", tt.wantPassed, result.Passed, result.FailureReasons)
+			}
+			if strings.Join(result.StaleData, ",") != strings.Join(tt.wantStale, ",") {
+				t.Errorf("expected stale data %v, got %v", tt.wantStale, result.StaleData)
+			}
+			if result.Score != 100 {
+				t.Errorf("expected stale data not to affect the score, got %d", result.Score)
+			}
+
+			_, cached := cache.Get(context.Background(), "test-mint")
+			if wantCached := tt.wantPassed; cached != wantCached {
+				t.Errorf("expected cached=%v, got %v", wantCached, cached)
+			}
+		})
+	}
+}
+
+func TestScreener_Screen_StaleReason(t *testing.T) {
+	screener := newFreshnessScreener(t, time.Now(), time.Now().Add(-90*time.Second), Config{MaxDataAge: time.Minute})
+
+	result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if len(result.FailureReasons) != 1 || !strings.HasPrefix(result.FailureReasons[0], "stale_liquidity_data:1m30s") {
+		t.Errorf("expected stale liquidity reason, got %v", result.FailureReasons)
+	}
+
+	clone := result.Clone()
+	clone.DataAsOf["pools"] = time.Time{}
+	clone.StaleData[0] = "changed"
+	if result.DataAsOf["pools"].IsZero() || result.StaleData[0] != "liquidity" {
+		t.Error("expected clone to copy DataAsOf and StaleData")
+	}
+}
+
+func TestScreener_Screen_RefetchesOldCachedData(t *testing.T) {
+	old := time.Now().Add(-90 * time.Second)
+	data := NewDataCache(DataCacheConfig{HolderTTL: time.Hour, LiquidityTTL: time.Hour})
+	data.SetSecurity("test-mint", &SecurityData{Source: "rpc", AsOf: old})
+	data.SetMarket("test-mint", &MarketData{Source: "pools", AsOf: old, LiquidityUSD: decimal.NewFromInt(100000)})
+
+	screener := newFreshnessScreener(t, time.Now(), time.Now(), Config{
+		DataCache:  data,
+		MaxDataAge: time.Minute,
+	})
+
+	result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if !result.Passed || len(result.StaleData) != 0 {
+		t.Errorf("expected old cached data refetched, got stale %v (reasons %v)", result.StaleData, result.FailureReasons)
+	}
+	if market, ok := data.Market("test-mint"); !ok || market.AsOf.Before(result.ScreenedAt.Add(-time.Second)) {
+		t.Error("expected the refetched market data cached")
+	}
+}
@@ -0,0 +1,275 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Token account layout (shared by both token programs).
+const (
+	tokenAccountSize         = 165
+	tokenAccountOwnerOffset  = 32
+	tokenAccountAmountOffset = 64
+	tokenAccountAmountEnd    = 72
+)
+
+// IncineratorAddress is the Solana burn address.
+const IncineratorAddress = "1nc1nerator11111111111111111111111111111111"
+
+// DefaultExcludedOwners are the owners SolanaRPCHolderProvider always leaves
+// out of holder lists: the Raydium pool authorities and the burn address.
+var DefaultExcludedOwners = []string{
+	RaydiumAMMv4Authority,
+	RaydiumCPMMAuthority,
+	IncineratorAddress,
+}
+
+// ============================================================================
+// RPC Holder Provider
+// ============================================================================
+
+// SolanaRPCHolderProvider computes holder distribution directly from chain
+// data, as an independent alternative to indexer-provided percentages.
+//
+// It reads the mint's largest token accounts (getTokenLargestAccounts) and
+// total supply (getTokenSupply), resolves each token account to its owner
+// (getMultipleAccounts) and merges accounts held by the same owner.
+//
+// The RPC method only reports the 20 largest token accounts, so the holder
+// list is partial and the top-10 share counts only owners among them. For
+// concentration checks this is the relevant part of the distribution.
+//
+// Liquidity is not a holder: owners in DefaultExcludedOwners, and
+// program-derived owners whose account belongs to a supported AMM program
+// (Orca and Meteora pools, pump.fun bonding curves), are left out of the
+// holder list. Their holdings still count toward supply.
+type SolanaRPCHolderProvider struct {
+	rpc           *rpcClient
+	exclude       map[string]struct{}
+	ownerPrograms map[string]struct{} // Programs whose accounts are not holders
+}
+
+// SolanaRPCHolderConfig holds configuration for SolanaRPCHolderProvider.
+type SolanaRPCHolderConfig struct {
+	// RPC holds connection settings (Endpoint is required).
+	RPC SolanaRPCConfig
+
+	// ExcludeOwners lists further owners whose holdings are not a
+	// concentration risk and are left out of the holder list, in addition
+	// to DefaultExcludedOwners. Their holdings still count toward supply.
+	ExcludeOwners []string
+
+	// ExcludeOwnerPrograms lists further programs, such as token lockers,
+	// whose program-derived accounts are left out of the holder list, in
+	// addition to the supported AMM programs.
+	ExcludeOwnerPrograms []string
+}
+
+// NewSolanaRPCHolderProvider creates a new RPC-backed holder provider.
+func NewSolanaRPCHolderProvider(cfg SolanaRPCHolderConfig) (*SolanaRPCHolderProvider, error) {
+	rpc, err := newRPCClient(cfg.RPC)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[string]struct{}, len(DefaultExcludedOwners)+len(cfg.ExcludeOwners))
+	for _, owner := range append(DefaultExcludedOwners, cfg.ExcludeOwners...) {
+		exclude[owner] = struct{}{}
+	}
+	ownerPrograms := make(map[string]struct{}, len(venuePrograms)+len(cfg.ExcludeOwnerPrograms))
+	for program := range venuePrograms {
+		ownerPrograms[program] = struct{}{}
+	}
+	for _, program := range cfg.ExcludeOwnerPrograms {
+		ownerPrograms[program] = struct{}{}
+	}
+
+	return &SolanaRPCHolderProvider{rpc: rpc, exclude: exclude, ownerPrograms: ownerPrograms}, nil
+}
+
+// GetHolderData fetches and merges the token's largest holders.
+func (p *SolanaRPCHolderProvider) GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error) {
+	supply, err := p.rpc.getTokenSupply(ctx, tokenMint)
+	if err != nil {
+		return nil, classifyMintParamError(tokenMint, err)
+	}
+
+	largest, err := p.rpc.getTokenLargestAccounts(ctx, tokenMint)
+	if err != nil {
+		return nil, classifyMintParamError(tokenMint, err)
+	}
+
+	addresses := make([]string, len(largest))
+	for i, account := range largest {
+		addresses[i] = account.Address
+	}
+
+	accounts, err := p.rpc.getMultipleAccounts(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]ownerBalance, 0, len(largest))
+	var derived []string // Resolved owners that may be program accounts
+	seen := make(map[string]struct{})
+	for i, account := range largest {
+		amount, ok := new(big.Int).SetString(account.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("token account %s: invalid amount %q", account.Address, account.Amount)
+		}
+
+		owner := tokenAccountOwner(accounts[i], account.Address)
+		balances = append(balances, ownerBalance{owner: owner, amount: amount})
+		if _, dup := seen[owner]; dup || owner == account.Address {
+			continue
+		}
+		seen[owner] = struct{}{}
+		if _, excluded := p.exclude[owner]; !excluded && isProgramDerived(owner) {
+			derived = append(derived, owner)
+		}
+	}
+
+	totalSupply, ok := new(big.Int).SetString(supply.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("mint %s: invalid supply %q", tokenMint, supply.Amount)
+	}
+
+	exclude, err := p.programOwners(ctx, derived)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeHolders(SolanaRPCSource, time.Now(), totalSupply, int32(supply.Decimals), balances, exclude), nil
+}
+
+// programOwners returns the excluded owners extended with the given
+// program-derived owners whose account belongs to an excluded program.
+// Wallets cannot be program-derived, so only those owners are looked up.
+func (p *SolanaRPCHolderProvider) programOwners(ctx context.Context, derived []string) (map[string]struct{}, error) {
+	if len(derived) == 0 {
+		return p.exclude, nil
+	}
+
+	accounts, err := p.rpc.getMultipleAccounts(ctx, derived)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[string]struct{}, len(p.exclude)+len(derived))
+	for owner := range p.exclude {
+		exclude[owner] = struct{}{}
+	}
+	for i, account := range accounts {
+		if account == nil {
+			continue
+		}
+		if _, ok := p.ownerPrograms[account.Owner]; ok {
+			exclude[derived[i]] = struct{}{}
+		}
+	}
+	return exclude, nil
+}
+
+// isProgramDerived reports whether address is off the ed25519 curve, and so
+// can only be controlled by a program.
+func isProgramDerived(address string) bool {
+	key, err := base58Decode(address)
+	return err == nil && len(key) == 32 && !isOnCurve(key)
+}
+
+// tokenAccountOwner returns the owner of a token account, falling back to
+// the account address if it could not be read (e.g., closed meanwhile).
+func tokenAccountOwner(account *rpcAccount, address string) string {
+	if account == nil || (account.Owner != TokenProgramID && account.Owner != Token2022ProgramID) {
+		return address
+	}
+
+	data, err := account.bytes()
+	if err != nil || len(data) < tokenAccountSize {
+		return address
+	}
+
+	return base58Encode(data[tokenAccountOwnerOffset : tokenAccountOwnerOffset+32])
+}
+
+// ============================================================================
+// Holder Aggregation
+// ============================================================================
+
+// ownerBalance is a token account balance in base units, with its owner.
+type ownerBalance struct {
+	owner  string
+	amount *big.Int
+}
+
+// mergeHolders merges balances per owner and computes supply shares.
+//
+// Owners in exclude are dropped after merging. Shares are relative to
+// supply; a zero supply yields zero shares.
+func mergeHolders(
+	source string,
+	asOf time.Time,
+	supply *big.Int,
+	decimals int32,
+	balances []ownerBalance,
+	exclude map[string]struct{},
+) *HolderData {
+	merged := make(map[string]*HolderShare)
+	raw := make(map[string]*big.Int)
+
+	for _, balance := range balances {
+		if _, skip := exclude[balance.owner]; skip {
+			continue
+		}
+
+		share, ok := merged[balance.owner]
+		if !ok {
+			share = &HolderShare{Owner: balance.owner}
+			merged[balance.owner] = share
+			raw[balance.owner] = new(big.Int)
+		}
+		share.Accounts++
+		raw[balance.owner].Add(raw[balance.owner], balance.amount)
+	}
+
+	supplyDec := decimal.NewFromBigInt(supply, -decimals)
+	hundred := decimal.NewFromInt(100)
+
+	data := &HolderData{
+		Source:  source,
+		AsOf:    asOf,
+		Supply:  supplyDec,
+		Holders: make([]HolderShare, 0, len(merged)),
+	}
+
+	for owner, share := range merged {
+		share.Amount = decimal.NewFromBigInt(raw[owner], -decimals)
+		if supply.Sign() > 0 {
+			share.Pct = share.Amount.Div(supplyDec).Mul(hundred)
+		}
+		data.Holders = append(data.Holders, *share)
+	}
+
+	sort.Slice(data.Holders, func(i, j int) bool {
+		if cmp := data.Holders[i].Amount.Cmp(data.Holders[j].Amount); cmp != 0 {
+			return cmp > 0
+		}
+		return data.Holders[i].Owner < data.Holders[j].Owner
+	})
+
+	for i, share := range data.Holders {
+		if i == 0 {
+			data.TopHolderPct = share.Pct
+		}
+		if i < 10 {
+			data.Top10HoldersPct = data.Top10HoldersPct.Add(share.Pct)
+		}
+	}
+
+	return data
+}
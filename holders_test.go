@@ -0,0 +1,208 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Owners in the recorded holder distribution of revokedMint. The Orca pool
+// and the Raydium authority hold the token's liquidity.
+const (
+	raydiumAuthority = RaydiumAMMv4Authority
+	orcaPool         = "EnTwZbp3qbieGBkKUmDPqpvQoBFzrgrD856syCSe1ZKF"
+	whaleOwner       = "FwXUzUvKvbsf4LFjqef6SXP92EZrrzj9kZmzbV8Q5uCj"
+	secondOwner      = "CRFJ3yzGasb2m9TFVaJM4bZnW5BHULbDGq8bYbZHjtvH"
+	closedTokenAcct  = "3X142aKbZLPmHG8bv7f3k5CPAWSe4gh5JYy4po9t2Han"
+)
+
+func newTestRPCHolderProvider(t *testing.T, server *rpcStandIn, exclude ...string) *SolanaRPCHolderProvider {
+	t.Helper()
+
+	provider, err := NewSolanaRPCHolderProvider(SolanaRPCHolderConfig{
+		RPC:           SolanaRPCConfig{Endpoint: server.URL},
+		ExcludeOwners: exclude,
+	})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHolderProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestSolanaRPCHolderProvider_MergesOwners(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider := newTestRPCHolderProvider(t, server)
+
+	data, err := provider.GetHolderData(context.Background(), revokedMint)
+	if err != nil {
+		t.Fatalf("GetHolderData() error = %v", err)
+	}
+
+	if data.Source != SolanaRPCSource || !data.Supply.Equal(decimal.NewFromInt(1000000000)) {
+		t.Errorf("unexpected source/supply: %q/%s", data.Source, data.Supply)
+	}
+
+	// 13 token accounts of 12 owners, two of which hold liquidity and are
+	// excluded by default; two accounts belong to the same whale.
+	if len(data.Holders) != 10 {
+		t.Fatalf("expected 10 owners, got %d", len(data.Holders))
+	}
+	for _, holder := range data.Holders {
+		if holder.Owner == raydiumAuthority || holder.Owner == orcaPool {
+			t.Errorf("expected liquidity owner %s excluded", holder.Owner)
+		}
+	}
+	whale := data.Holders[0]
+	if whale.Owner != whaleOwner || whale.Accounts != 2 || !whale.Amount.Equal(decimal.NewFromInt(200000000)) {
+		t.Errorf("expected merged whale holding 200M over 2 accounts, got %+v", whale)
+	}
+
+	// An account closed between calls is attributed to its own address.
+	if last := data.Holders[len(data.Holders)-1]; last.Owner != closedTokenAcct {
+		t.Errorf("expected unresolvable account to fall back to its address, got %q", last.Owner)
+	}
+
+	if !data.TopHolderPct.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("expected top holder 20%%, got %s", data.TopHolderPct)
+	}
+	if want := decimal.RequireFromString("46.5"); !data.Top10HoldersPct.Equal(want) {
+		t.Errorf("expected top 10 share %s, got %s", want, data.Top10HoldersPct)
+	}
+	// One batch resolves the owners, one checks the program-derived ones
+	if got := server.callCount("getMultipleAccounts"); got != 2 {
+		t.Errorf("expected owners resolved in 2 batches, got %d calls", got)
+	}
+}
+
+func TestSolanaRPCHolderProvider_ExcludeOwners(t *testing.T) {
+	provider := newTestRPCHolderProvider(t, newRPCStandIn(t), whaleOwner)
+
+	data, err := provider.GetHolderData(context.Background(), revokedMint)
+	if err != nil {
+		t.Fatalf("GetHolderData() error = %v", err)
+	}
+
+	if data.Holders[0].Owner != secondOwner || !data.TopHolderPct.Equal(decimal.NewFromInt(8)) {
+		t.Errorf("expected next owner with 8%% on top after exclusion, got %+v", data.Holders[0])
+	}
+	if want := decimal.RequireFromString("26.5"); !data.Top10HoldersPct.Equal(want) {
+		t.Errorf("expected top 10 share %s, got %s", want, data.Top10HoldersPct)
+	}
+}
+
+func TestSolanaRPCHolderProvider_ExcludeOwnerPrograms(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider, err := NewSolanaRPCHolderProvider(SolanaRPCHolderConfig{
+		RPC: SolanaRPCConfig{Endpoint: server.URL},
+		// The whale is an escrow of a token locker in the recordings
+		ExcludeOwnerPrograms: []string{"strmRqUCoQUgGUan5YhzUZa6KqdzwX5L6FpUxfmKg5m"},
+	})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHolderProvider() error = %v", err)
+	}
+
+	data, err := provider.GetHolderData(context.Background(), revokedMint)
+	if err != nil {
+		t.Fatalf("GetHolderData() error = %v", err)
+	}
+	if data.Holders[0].Owner != secondOwner {
+		t.Errorf("expected locked whale holdings excluded, got %+v", data.Holders[0])
+	}
+}
+
+func TestSolanaRPCHolderProvider_UnknownMint(t *testing.T) {
+	provider := newTestRPCHolderProvider(t, newRPCStandIn(t))
+
+	_, err := provider.GetHolderData(context.Background(), missingAccountAddr)
+
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != rpcInvalidParams {
+		t.Fatalf("expected invalid params RPC error, got %v", err)
+	}
+	if ClassifyError(err) != ErrorClassPersistent {
+		t.Errorf("expected persistent class, got %v", ClassifyError(err))
+	}
+}
+
+func TestSolanaRPCHolderProvider_LargestAccountsNotFound(t *testing.T) {
+	provider := newTestRPCHolderProvider(t, newRPCStandIn(t))
+
+	// The supply was read, then the node could not find the mint
+	_, err := provider.GetHolderData(context.Background(), usdcMint)
+	if !errors.Is(err, ErrTokenNotFound) || ClassifyError(err) != ErrorClassNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClassifyMintParamError(t *testing.T) {
+	notFound := &RPCError{Code: rpcInvalidParams, Message: "Invalid param: could not find account"}
+	if err := classifyMintParamError("mint", notFound); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+
+	notMint := &RPCError{Code: rpcInvalidParams, Message: "Invalid param: not a Token mint"}
+	if err := classifyMintParamError("mint", notMint); !errors.Is(err, ErrNotMint) || !errors.Is(err, notMint) {
+		t.Errorf("expected ErrNotMint wrapping the RPC error, got %v", err)
+	}
+
+	other := errors.New("connection reset")
+	if err := classifyMintParamError("mint", other); err != other {
+		t.Errorf("expected other errors unchanged, got %v", err)
+	}
+}
+
+func TestMergeHolders_ZeroSupply(t *testing.T) {
+	data := mergeHolders("test", time.Now(), new(big.Int), 6, []ownerBalance{
+		{owner: "owner-A", amount: big.NewInt(0)},
+	}, nil)
+
+	if len(data.Holders) != 1 || !data.Holders[0].Pct.IsZero() || !data.Top10HoldersPct.IsZero() {
+		t.Errorf("expected zero shares for zero supply, got %+v", data)
+	}
+}
+
+func TestScreener_Screen_WithHolderProvider(t *testing.T) {
+	ctx := context.Background()
+	server := newRPCStandIn(t)
+
+	screener, err := New(Config{
+		// Security data claims low concentration; on-chain holders disagree.
+		SecurityDataProvider: &staticSecurityData{data: &SecurityData{
+			Top10HoldersPct: decimal.NewFromInt(10),
+			TopHolderPct:    decimal.NewFromInt(2),
+		}},
+		MarketDataProvider: &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100000)}},
+		HolderDataProvider: newTestRPCHolderProvider(t, server),
+		DataCache:          NewDataCache(DataCacheConfig{}),
+		Logger:             zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := screener.Screen(ctx, revokedMint, ScreeningLevelStrict)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if !result.Details.Top10HoldersPct.Equal(decimal.RequireFromString("46.5")) {
+		t.Errorf("expected on-chain top 10 share, got %s", result.Details.Top10HoldersPct)
+	}
+	if !contains(result.FailureReasons, "high_top10_concentration:46.50%") ||
+		!contains(result.FailureReasons, "high_single_holder:20.00%") {
+		t.Errorf("expected concentration failures, got %v", result.FailureReasons)
+	}
+
+	// Holder data is cached with the holder TTL.
+	if _, err := screener.Screen(ctx, revokedMint, ScreeningLevelNormal); err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if got := server.callCount("getTokenLargestAccounts"); got != 1 {
+		t.Errorf("expected holder data served from data cache, got %d fetches", got)
+	}
+}
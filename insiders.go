@@ -0,0 +1,471 @@
+package tokenguard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Defaults for the insider cluster check.
+const (
+	DefaultClusterMaxHolders = 20
+	DefaultClusterDepth      = 1
+)
+
+// Defaults for SolanaRPCHistoryProvider.
+const (
+	DefaultFundingMaxPages        = 3
+	DefaultFundingMaxTransactions = 5
+	DefaultFundingCacheSize       = 10000
+)
+
+// lamportsPerSOL is the number of lamports in one SOL.
+const lamportsPerSOL = 1_000_000_000
+
+// ============================================================================
+// Transaction History
+// ============================================================================
+
+// Funder is a wallet that sent SOL to another wallet.
+type Funder struct {
+	// Address is the funding wallet.
+	Address string `json:"address"`
+
+	// AmountSOL is the SOL sent in the funding transfer.
+	AmountSOL decimal.Decimal `json:"amountSol"`
+
+	// At is when the transfer happened (zero if unknown).
+	At time.Time `json:"at"`
+}
+
+// TransactionHistoryProvider looks up wallets' transaction history, for the
+// insider cluster check.
+type TransactionHistoryProvider interface {
+	// GetFunders returns the wallets that funded wallet with SOL, earliest
+	// first. An empty result means the funders are unknown.
+	GetFunders(ctx context.Context, wallet string) ([]Funder, error)
+}
+
+// fundingCache is implemented by history providers that cache funder
+// lookups. The screener does not charge for cached lookups.
+type fundingCache interface {
+	cachedFunders(wallet string) ([]Funder, bool)
+}
+
+// InsiderClusterConfig configures the insider cluster check.
+type InsiderClusterConfig struct {
+	// MaxHolders is the number of largest holders whose funding is traced.
+	// Defaults to 20 if zero.
+	MaxHolders int
+
+	// Depth is the number of funding hops traced from each holder: 1
+	// links holders funded by the same wallet, 2 also links holders whose
+	// funders share a funder, and so on. Each hop costs a lookup per wallet.
+	// Defaults to 1 if zero.
+	Depth int
+
+	// ExcludeFunders lists wallets that fund many unrelated wallets, such
+	// as exchange hot wallets, and so do not link the wallets they fund.
+	ExcludeFunders []string
+}
+
+// insiderClusters holds the validated insider cluster settings.
+type insiderClusters struct {
+	maxHolders int
+	depth      int
+	exclude    map[string]struct{}
+}
+
+// newInsiderClusters validates cfg and applies defaults.
+func newInsiderClusters(cfg InsiderClusterConfig) (insiderClusters, error) {
+	if cfg.MaxHolders < 0 || cfg.Depth < 0 {
+		return insiderClusters{}, fmt.Errorf("insider cluster max holders and depth must not be negative")
+	}
+	if cfg.MaxHolders == 0 {
+		cfg.MaxHolders = DefaultClusterMaxHolders
+	}
+	if cfg.Depth == 0 {
+		cfg.Depth = DefaultClusterDepth
+	}
+
+	exclude := make(map[string]struct{}, len(cfg.ExcludeFunders))
+	for _, funder := range cfg.ExcludeFunders {
+		exclude[funder] = struct{}{}
+	}
+
+	return insiderClusters{maxHolders: cfg.MaxHolders, depth: cfg.Depth, exclude: exclude}, nil
+}
+
+// ============================================================================
+// Funding Graph
+// ============================================================================
+
+// holderCluster is a group of holders linked by funding.
+type holderCluster struct {
+	holders []string
+	pct     decimal.Decimal // Combined share of supply (0-100)
+}
+
+// fundingGraph groups wallets connected by funding edges (union-find).
+type fundingGraph struct {
+	parent map[string]string
+}
+
+// find returns the representative of wallet's group.
+func (g *fundingGraph) find(wallet string) string {
+	root, ok := g.parent[wallet]
+	if !ok {
+		g.parent[wallet] = wallet
+		return wallet
+	}
+	if root != wallet {
+		root = g.find(root)
+		g.parent[wallet] = root
+	}
+	return root
+}
+
+// link records that a funded b (or the reverse).
+func (g *fundingGraph) link(a, b string) {
+	rootA, rootB := g.find(a), g.find(b)
+	if rootA != rootB {
+		g.parent[rootA] = rootB
+	}
+}
+
+// funders returns a wallet's funders, charging for the lookup unless the
+// provider has it cached.
+func (s *Screener) funders(ctx context.Context, wallet string) ([]Funder, error) {
+	if cache, ok := s.history.(fundingCache); ok {
+		if funders, ok := cache.cachedFunders(wallet); ok {
+			return funders, nil
+		}
+	}
+
+	if err := s.spend(ctx, s.costs.Funding); err != nil {
+		return nil, fmt.Errorf("get funders of %s: %w", wallet, err)
+	}
+	funders, err := s.history.GetFunders(ctx, wallet)
+	if err != nil {
+		return nil, fmt.Errorf("get funders of %s: %w", wallet, err)
+	}
+	return funders, nil
+}
+
+// clusterHolders traces who funded the largest holders and groups holders
+// connected through funding. Only groups of two or more holders are
+// returned, largest share first.
+//
+// Each wallet's earliest funder is followed, hop by hop up to the
+// configured depth; excluded funders are not followed.
+func (s *Screener) clusterHolders(ctx context.Context, holders []HolderShare) ([]holderCluster, error) {
+	if len(holders) > s.clusters.maxHolders {
+		holders = holders[:s.clusters.maxHolders]
+	}
+
+	graph := &fundingGraph{parent: make(map[string]string)}
+	visited := make(map[string]struct{})
+	frontier := make([]string, 0, len(holders))
+	for _, holder := range holders {
+		graph.find(holder.Owner)
+		frontier = append(frontier, holder.Owner)
+	}
+
+	for hop := 0; hop < s.clusters.depth && len(frontier) > 0; hop++ {
+		var next []string
+		for _, wallet := range frontier {
+			if _, ok := visited[wallet]; ok {
+				continue
+			}
+			visited[wallet] = struct{}{}
+
+			funders, err := s.funders(ctx, wallet)
+			if err != nil {
+				return nil, err
+			}
+			if len(funders) == 0 {
+				continue
+			}
+
+			funder := funders[0].Address
+			if _, skip := s.clusters.exclude[funder]; skip || funder == wallet {
+				continue
+			}
+			graph.link(wallet, funder)
+			next = append(next, funder)
+		}
+		frontier = next
+	}
+
+	byRoot := make(map[string]*holderCluster)
+	for _, holder := range holders {
+		root := graph.find(holder.Owner)
+		cluster, ok := byRoot[root]
+		if !ok {
+			cluster = &holderCluster{}
+			byRoot[root] = cluster
+		}
+		cluster.holders = append(cluster.holders, holder.Owner)
+		cluster.pct = cluster.pct.Add(holder.Pct)
+	}
+
+	var clusters []holderCluster
+	for _, cluster := range byRoot {
+		if len(cluster.holders) > 1 {
+			clusters = append(clusters, *cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if cmp := clusters[i].pct.Cmp(clusters[j].pct); cmp != 0 {
+			return cmp > 0
+		}
+		return clusters[i].holders[0] < clusters[j].holders[0]
+	})
+
+	return clusters, nil
+}
+
+// ============================================================================
+// RPC History Provider
+// ============================================================================
+
+// SolanaRPCHistoryProvider reads wallet and token transaction history over
+// Solana JSON-RPC (getSignaturesForAddress and getTransaction). It is both
+// a TransactionHistoryProvider and a LaunchHistoryProvider.
+//
+// Signatures are paged from newest to oldest, then the oldest transactions
+// are read: for a wallet, for SOL transfers (system transfers and account
+// creations) into it; for a mint, for buys of the token (see
+// GetLaunchBuys). Wallets with more history than MaxPages covers have
+// unknown funders.
+//
+// Features:
+//   - Bounded paging and transaction reads per lookup
+//   - Top-level and inner (CPI) instructions are inspected
+//   - Failed transactions are ignored
+//   - Cache of complete funder lookups and launches
+type SolanaRPCHistoryProvider struct {
+	rpc                   *rpcClient
+	maxPages              int
+	maxTransactions       int
+	maxLaunchTransactions int
+	fundingCacheSize      int
+	launchCacheSize       int
+	pageSize              int
+
+	mu       sync.Mutex
+	funders  map[string][]Funder    // Complete funder lookups by wallet
+	launches map[string][]LaunchBuy // Complete launches by mint
+}
+
+// SolanaRPCHistoryConfig holds configuration for SolanaRPCHistoryProvider.
+type SolanaRPCHistoryConfig struct {
+	// RPC holds connection settings (Endpoint is required).
+	RPC SolanaRPCConfig
+
+	// MaxPages is the maximum number of signature pages (1000 each) read
+	// per lookup.
+	// Defaults to 3 if zero.
+	MaxPages int
+
+	// MaxTransactions is the maximum number of the oldest transactions
+	// read per lookup.
+	// Defaults to 5 if zero.
+	MaxTransactions int
+
+	// MaxLaunchTransactions is the maximum number of a mint's oldest
+	// transactions read for launch buys.
+	// Defaults to 100 if zero.
+	MaxLaunchTransactions int
+
+	// FundingCacheSize is the maximum number of cached funder lookups.
+	// Defaults to 10000 if zero.
+	FundingCacheSize int
+
+	// LaunchCacheSize is the maximum number of cached launches.
+	// Defaults to 10000 if zero.
+	LaunchCacheSize int
+}
+
+// NewSolanaRPCHistoryProvider creates a new RPC-backed history provider.
+func NewSolanaRPCHistoryProvider(cfg SolanaRPCHistoryConfig) (*SolanaRPCHistoryProvider, error) {
+	rpc, err := newRPCClient(cfg.RPC)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxPages == 0 {
+		cfg.MaxPages = DefaultFundingMaxPages
+	}
+	if cfg.MaxTransactions == 0 {
+		cfg.MaxTransactions = DefaultFundingMaxTransactions
+	}
+	if cfg.MaxLaunchTransactions == 0 {
+		cfg.MaxLaunchTransactions = DefaultLaunchMaxTransactions
+	}
+	if cfg.FundingCacheSize == 0 {
+		cfg.FundingCacheSize = DefaultFundingCacheSize
+	}
+	if cfg.LaunchCacheSize == 0 {
+		cfg.LaunchCacheSize = DefaultLaunchCacheSize
+	}
+	if cfg.MaxPages < 0 || cfg.MaxTransactions < 0 || cfg.MaxLaunchTransactions < 0 ||
+		cfg.FundingCacheSize < 0 || cfg.LaunchCacheSize < 0 {
+		return nil, fmt.Errorf("max pages, max transactions and cache size must not be negative")
+	}
+
+	return &SolanaRPCHistoryProvider{
+		rpc:                   rpc,
+		maxPages:              cfg.MaxPages,
+		maxTransactions:       cfg.MaxTransactions,
+		maxLaunchTransactions: cfg.MaxLaunchTransactions,
+		fundingCacheSize:      cfg.FundingCacheSize,
+		launchCacheSize:       cfg.LaunchCacheSize,
+		pageSize:              signaturesPageSize,
+		funders:               make(map[string][]Funder),
+		launches:              make(map[string][]LaunchBuy),
+	}, nil
+}
+
+// GetFunders returns the wallets that sent SOL to wallet in its oldest
+// transactions, earliest first. Each funder is listed once, at its first
+// transfer. Wallets without transactions, or with more history than
+// MaxPages covers, have no funders. Lookups that reached the wallet's
+// oldest transaction are cached, including those of wallets with fewer
+// than MaxTransactions transactions: funding comes first, so later
+// transactions rarely add funders.
+func (p *SolanaRPCHistoryProvider) GetFunders(ctx context.Context, wallet string) ([]Funder, error) {
+	if cached, ok := p.cachedFunders(wallet); ok {
+		return cached, nil
+	}
+
+	oldest, first, err := p.oldestSignatures(ctx, wallet, p.maxTransactions)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, nil // Later transfers are not the wallet's funding
+	}
+
+	var funders []Funder
+	seen := make(map[string]struct{})
+	for i := len(oldest) - 1; i >= 0; i-- {
+		tx, err := p.rpc.getTransaction(ctx, oldest[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if tx == nil || tx.failed() {
+			continue
+		}
+
+		var at time.Time
+		if tx.BlockTime != nil {
+			at = time.Unix(*tx.BlockTime, 0)
+		}
+		for _, ix := range tx.instructions() {
+			source, lamports, ok := solTransferTo(ix, wallet)
+			if !ok {
+				continue
+			}
+			if _, dup := seen[source]; dup {
+				continue
+			}
+			seen[source] = struct{}{}
+			funders = append(funders, Funder{
+				Address:   source,
+				AmountSOL: decimal.NewFromInt(int64(lamports)).Div(decimal.NewFromInt(lamportsPerSOL)),
+				At:        at,
+			})
+		}
+	}
+
+	p.mu.Lock()
+	storeBounded(p.funders, p.fundingCacheSize, wallet, funders)
+	p.mu.Unlock()
+	return funders, nil
+}
+
+// cachedFunders returns a wallet's cached funders.
+func (p *SolanaRPCHistoryProvider) cachedFunders(wallet string) ([]Funder, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	funders, ok := p.funders[wallet]
+	return funders, ok
+}
+
+// storeBounded stores value under key, evicting an arbitrary entry when m
+// holds limit entries.
+func storeBounded[V any](m map[string]V, limit int, key string, value V) {
+	if _, ok := m[key]; !ok && len(m) >= limit {
+		for k := range m {
+			delete(m, k)
+			break
+		}
+	}
+	m[key] = value
+}
+
+// oldestSignatures pages an address's signatures from newest to oldest
+// and returns the n oldest seen, newest first. first reports whether the
+// address's first transaction was reached within MaxPages.
+func (p *SolanaRPCHistoryProvider) oldestSignatures(ctx context.Context, address string, n int) (oldest []rpcSignature, first bool, err error) {
+	var before string
+	for page := 0; page < p.maxPages; page++ {
+		signatures, err := p.rpc.getSignaturesForAddress(ctx, address, before, p.pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		oldest = append(oldest, signatures...)
+		if len(oldest) > n {
+			oldest = oldest[len(oldest)-n:]
+		}
+
+		if len(signatures) < p.pageSize {
+			return oldest, true, nil // Reached the first transaction
+		}
+		before = signatures[len(signatures)-1].Signature
+	}
+
+	return oldest, false, nil
+}
+
+// solTransferTo reports whether a parsed instruction moves SOL into wallet,
+// returning the sender and amount.
+func solTransferTo(ix rpcInstruction, wallet string) (string, uint64, bool) {
+	if ix.Program != "system" || len(ix.Parsed) == 0 || ix.Parsed[0] != '{' {
+		return "", 0, false
+	}
+
+	var parsed struct {
+		Type string `json:"type"`
+		Info struct {
+			Source      string `json:"source"`
+			Destination string `json:"destination"`
+			NewAccount  string `json:"newAccount"`
+			Lamports    uint64 `json:"lamports"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(ix.Parsed, &parsed); err != nil {
+		return "", 0, false
+	}
+
+	var recipient string
+	switch parsed.Type {
+	case "transfer", "transferWithSeed":
+		recipient = parsed.Info.Destination
+	case "createAccount", "createAccountWithSeed":
+		recipient = parsed.Info.NewAccount
+	default:
+		return "", 0, false
+	}
+	if recipient != wallet || parsed.Info.Source == wallet || parsed.Info.Lamports == 0 {
+		return "", 0, false
+	}
+
+	return parsed.Info.Source, parsed.Info.Lamports, true
+}
@@ -0,0 +1,262 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// staticHolderData is a HolderDataProvider returning fixed data.
+type staticHolderData struct {
+	data *HolderData
+}
+
+func (p *staticHolderData) GetHolderData(_ context.Context, _ string) (*HolderData, error) {
+	return p.data, nil
+}
+
+// fundingHistory is a TransactionHistoryProvider returning each wallet's
+// funder from a map.
+type fundingHistory struct {
+	funders map[string]string
+	err     error
+	calls   int
+}
+
+func (h *fundingHistory) GetFunders(_ context.Context, wallet string) ([]Funder, error) {
+	h.calls++
+	if h.err != nil {
+		return nil, h.err
+	}
+	funder, ok := h.funders[wallet]
+	if !ok {
+		return nil, nil
+	}
+	return []Funder{{Address: funder, AmountSOL: decimal.NewFromInt(1)}}, nil
+}
+
+// splitHolders returns n holders of pct each, named prefix-0 and so on.
+func splitHolders(prefix string, n int, pct int64) []HolderShare {
+	holders := make([]HolderShare, n)
+	for i := range holders {
+		holders[i] = HolderShare{Owner: fmt.Sprintf("%s-%d", prefix, i), Pct: decimal.NewFromInt(pct)}
+	}
+	return holders
+}
+
+func TestScreener_Screen_InsiderClusters(t *testing.T) {
+	// Eight fresh wallets of 6% each, split between two intermediaries
+	// funded by the deployer, plus four independent holders.
+	holders := append(splitHolders("fresh", 8, 6), splitHolders("retail", 4, 5)...)
+	funders := map[string]string{
+		"intermediary-a": "deployer",
+		"intermediary-b": "deployer",
+		"retail-0":       "binance-hot-wallet",
+		"retail-1":       "binance-hot-wallet",
+	}
+	for i := 0; i < 8; i++ {
+		funders[fmt.Sprintf("fresh-%d", i)] = "intermediary-" + string(rune('a'+i%2))
+	}
+
+	tests := []struct {
+		name        string
+		cfg         InsiderClusterConfig
+		wantPassed  bool
+		wantPct     int64
+		wantWallets int
+		wantGroups  int
+		wantLookups int
+	}{
+		{"shared funders", InsiderClusterConfig{}, true, 24, 4, 3, 12},
+		{"traced to deployer", InsiderClusterConfig{Depth: 2}, false, 48, 8, 2, 15},
+		{"exchange excluded", InsiderClusterConfig{Depth: 2, ExcludeFunders: []string{"binance-hot-wallet"}}, false, 48, 8, 1, 14},
+		{"only largest holders", InsiderClusterConfig{MaxHolders: 4}, true, 12, 2, 2, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fundingHistory{funders: funders}
+			screener, err := New(Config{
+				SecurityDataProvider:       &staticSecurityData{data: &SecurityData{}},
+				MarketDataProvider:         &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+				HolderDataProvider:         &staticHolderData{data: &HolderData{Holders: holders}},
+				TransactionHistoryProvider: history,
+				InsiderClusters:            tt.cfg,
+				Logger:                     zap.NewNop(),
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if result.Passed != tt.wantPassed {
+				t.Errorf("expected passed=%v, got %v (reasons %v)", tt.wantPassed, result.Passed, result.FailureReasons)
+			}
+			if !tt.wantPassed && !reflect.DeepEqual(result.FailureReasons, []string{"high_cluster_concentration:48.00%"}) {
+				t.Errorf("expected cluster failure reason, got %v", result.FailureReasons)
+			}
+			details := result.Details
+			if !details.LargestClusterPct.Equal(decimal.NewFromInt(tt.wantPct)) ||
+				details.LargestClusterWallets != tt.wantWallets || details.InsiderClusters != tt.wantGroups {
+				t.Errorf("expected largest cluster %d%% of %d wallets in %d groups, got %s%% of %d in %d",
+					tt.wantPct, tt.wantWallets, tt.wantGroups,
+					details.LargestClusterPct, details.LargestClusterWallets, details.InsiderClusters)
+			}
+			if history.calls != tt.wantLookups {
+				t.Errorf("expected %d funding lookups, got %d", tt.wantLookups, history.calls)
+			}
+			// One security, market and holder call each, plus the lookups
+			if want := int64(3 + tt.wantLookups); result.CreditsUsed != want {
+				t.Errorf("expected %d credits used, got %d", want, result.CreditsUsed)
+			}
+		})
+	}
+}
+
+func TestScreener_Screen_InsiderClustersErrors(t *testing.T) {
+	history := &fundingHistory{err: errors.New("history unavailable")}
+	cfg := Config{
+		SecurityDataProvider:       &staticSecurityData{data: &SecurityData{}},
+		MarketDataProvider:         &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+		TransactionHistoryProvider: history,
+		Logger:                     zap.NewNop(),
+	}
+
+	// Skipped without holder lists
+	screener, _ := New(cfg)
+	if _, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal); err != nil || history.calls != 0 {
+		t.Errorf("expected check skipped without holder provider, got %v after %d lookups", err, history.calls)
+	}
+
+	cfg.HolderDataProvider = &staticHolderData{data: &HolderData{Holders: splitHolders("holder", 2, 10)}}
+	screener, _ = New(cfg)
+	if _, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal); err == nil {
+		t.Error("expected lookup error to fail screening")
+	}
+
+	cfg.InsiderClusters.Depth = -1
+	if _, err := New(cfg); err == nil {
+		t.Error("expected error for negative depth")
+	}
+}
+
+func TestSolanaRPCHistoryProvider_GetFunders(t *testing.T) {
+	const (
+		firstFunder  = "5tzFkiKscXHK5ZXCGbXZxdw7gTjjD1mBwuoFbhUvuAi9"
+		secondFunder = "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin"
+	)
+
+	tests := []struct {
+		name            string
+		pageSize        int
+		maxPages        int
+		maxTransactions int
+		want            []string
+		wantCached      bool
+	}{
+		{"all history", signaturesPageSize, 0, 0, []string{firstFunder, secondFunder}, true},
+		{"paged", 2, 0, 0, []string{firstFunder, secondFunder}, true},
+		{"oldest transaction only", signaturesPageSize, 0, 1, []string{firstFunder}, true},
+		{"history beyond max pages", 2, 1, 0, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRPCStandIn(t)
+			provider, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{
+				RPC:             SolanaRPCConfig{Endpoint: server.URL},
+				MaxPages:        tt.maxPages,
+				MaxTransactions: tt.maxTransactions,
+			})
+			if err != nil {
+				t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+			}
+			provider.pageSize = tt.pageSize
+
+			funders, err := provider.GetFunders(context.Background(), systemWalletAddr)
+			if err != nil {
+				t.Fatalf("GetFunders() error = %v", err)
+			}
+
+			var got []string
+			for _, funder := range funders {
+				got = append(got, funder.Address)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected funders %v, got %v", tt.want, got)
+			}
+			if len(got) > 0 {
+				first := funders[0]
+				if !first.AmountSOL.Equal(decimal.RequireFromString("2.5")) || !first.At.Equal(time.Unix(1740000000, 0)) {
+					t.Errorf("expected 2.5 SOL at first transaction, got %s at %v", first.AmountSOL, first.At)
+				}
+			}
+
+			lookups := server.callCount("getSignaturesForAddress")
+			if _, err := provider.GetFunders(context.Background(), systemWalletAddr); err != nil {
+				t.Fatalf("GetFunders() repeat error = %v", err)
+			}
+			if cached := server.callCount("getSignaturesForAddress") == lookups; cached != tt.wantCached {
+				t.Errorf("expected cached=%v, got %v", tt.wantCached, cached)
+			}
+		})
+	}
+}
+
+func TestScreener_Screen_CachedFundersAreFree(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{
+		RPC:             SolanaRPCConfig{Endpoint: server.URL},
+		MaxTransactions: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+	}
+	screener, err := New(Config{
+		SecurityDataProvider: &staticSecurityData{data: &SecurityData{}},
+		MarketDataProvider:   &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+		HolderDataProvider: &staticHolderData{data: &HolderData{
+			Holders: []HolderShare{{Owner: systemWalletAddr, Pct: decimal.NewFromInt(10)}},
+		}},
+		TransactionHistoryProvider: provider,
+		CallCosts:                  CallCosts{Funding: 100},
+		Logger:                     zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// One security, market and holder call each, plus the funder lookup
+	for i, want := range []int64{103, 3} {
+		result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+		if err != nil {
+			t.Fatalf("Screen() error = %v", err)
+		}
+		if result.CreditsUsed != want {
+			t.Errorf("screening %d: expected %d credits used, got %d", i+1, want, result.CreditsUsed)
+		}
+	}
+}
+
+func TestSolanaRPCHistoryProvider_NoTransactions(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{RPC: SolanaRPCConfig{Endpoint: server.URL}})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+	}
+
+	funders, err := provider.GetFunders(context.Background(), missingAccountAddr)
+	if err != nil || len(funders) != 0 {
+		t.Errorf("expected no funders, got %v, %v", funders, err)
+	}
+}
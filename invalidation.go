@@ -0,0 +1,434 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultInvalidationChannel is the default pub/sub channel for invalidations.
+const DefaultInvalidationChannel = "tokenguard:invalidate"
+
+// ============================================================================
+// Invalidation Interfaces
+// ============================================================================
+
+// Invalidator removes a token's cached result everywhere it may be served.
+//
+// Use it when a replica learns a cached verdict is no longer valid (e.g.,
+// liquidity was pulled), so that no replica keeps serving it until TTL expiry.
+type Invalidator interface {
+	Invalidate(ctx context.Context, tokenMint string) error
+}
+
+// InvalidationBus broadcasts token invalidations between replicas.
+type InvalidationBus interface {
+	// Publish broadcasts an invalidation to all subscribers, including
+	// subscribers in this process.
+	Publish(ctx context.Context, tokenMint string) error
+
+	// Subscribe registers handler to be called for every invalidation.
+	// It returns once the subscription is active, along with a function
+	// that removes the handler.
+	Subscribe(ctx context.Context, handler func(tokenMint string)) (unsubscribe func(), err error)
+}
+
+// ============================================================================
+// Broadcast Invalidator
+// ============================================================================
+
+// BroadcastInvalidator invalidates a token in a shared cache (if any) and
+// broadcasts the invalidation so each replica evicts its local copy.
+type BroadcastInvalidator struct {
+	bus    InvalidationBus
+	shared Cache
+}
+
+// NewBroadcastInvalidator creates an invalidator publishing on bus.
+//
+// shared is an optional cache shared by all replicas (e.g., RedisCache) that
+// is cleared before broadcasting, so replicas re-reading it after eviction
+// do not pick the stale result back up. It may be nil.
+func NewBroadcastInvalidator(bus InvalidationBus, shared Cache) (*BroadcastInvalidator, error) {
+	if bus == nil {
+		return nil, fmt.Errorf("invalidation bus is required")
+	}
+
+	return &BroadcastInvalidator{bus: bus, shared: shared}, nil
+}
+
+// Invalidate removes the token from the shared cache and notifies replicas.
+func (i *BroadcastInvalidator) Invalidate(ctx context.Context, tokenMint string) error {
+	if tokenMint == "" {
+		return fmt.Errorf("token mint is required")
+	}
+
+	if i.shared != nil {
+		if err := deleteFromCache(ctx, i.shared, tokenMint); err != nil {
+			return fmt.Errorf("invalidate shared cache: %w", err)
+		}
+	}
+
+	if err := i.bus.Publish(ctx, tokenMint); err != nil {
+		return fmt.Errorf("publish invalidation: %w", err)
+	}
+
+	return nil
+}
+
+// deleteFromCache removes a token from any cache supporting deletion.
+//
+// Caches in this package expose either Delete(ctx, key) error (remote and
+// persistent caches) or Delete(ctx, key) (in-process caches); caches with
+// neither are left untouched and expire by TTL.
+func deleteFromCache(ctx context.Context, cache Cache, tokenMint string) error {
+	switch c := cache.(type) {
+	case interface {
+		Delete(context.Context, string) error
+	}:
+		return c.Delete(ctx, tokenMint)
+	case interface{ Delete(context.Context, string) }:
+		c.Delete(ctx, tokenMint)
+	}
+	return nil
+}
+
+// SubscribeInvalidations evicts tokens from this cache when invalidations
+// are published on bus. Call the returned function to stop.
+func (c *InMemoryCache) SubscribeInvalidations(ctx context.Context, bus InvalidationBus) (func(), error) {
+	return bus.Subscribe(ctx, func(tokenMint string) {
+		c.Delete(context.Background(), tokenMint)
+	})
+}
+
+// SubscribeInvalidations evicts tokens from this cache when invalidations
+// are published on bus. Call the returned function to stop.
+func (c *ShardedCache) SubscribeInvalidations(ctx context.Context, bus InvalidationBus) (func(), error) {
+	return bus.Subscribe(ctx, func(tokenMint string) {
+		c.Delete(context.Background(), tokenMint)
+	})
+}
+
+// ============================================================================
+// In-Process Bus
+// ============================================================================
+
+// handlerSet is a concurrency-safe set of invalidation handlers.
+type handlerSet struct {
+	mu       sync.RWMutex
+	handlers map[uint64]func(string)
+	nextID   uint64
+}
+
+// add registers a handler and returns a function removing it.
+func (h *handlerSet) add(handler func(string)) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.handlers == nil {
+		h.handlers = make(map[uint64]func(string))
+	}
+	id := h.nextID
+	h.nextID++
+	h.handlers[id] = handler
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.handlers, id)
+	}
+}
+
+// dispatch calls every handler. Handlers run outside the lock, so they may
+// subscribe or unsubscribe.
+func (h *handlerSet) dispatch(tokenMint string) {
+	h.mu.RLock()
+	handlers := make([]func(string), 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler)
+	}
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(tokenMint)
+	}
+}
+
+// LocalBus is an in-process InvalidationBus.
+//
+// It is useful for tests and for single-process deployments that run several
+// caches (e.g., one per screening level) which should invalidate together.
+// Handlers are called synchronously from Publish.
+type LocalBus struct {
+	handlers handlerSet
+}
+
+// NewLocalBus creates a new in-process bus.
+func NewLocalBus() *LocalBus {
+	return &LocalBus{}
+}
+
+// Publish delivers the invalidation to all current subscribers.
+func (b *LocalBus) Publish(_ context.Context, tokenMint string) error {
+	b.handlers.dispatch(tokenMint)
+	return nil
+}
+
+// Subscribe registers handler for invalidations.
+func (b *LocalBus) Subscribe(_ context.Context, handler func(tokenMint string)) (func(), error) {
+	return b.handlers.add(handler), nil
+}
+
+// ============================================================================
+// Redis Pub/Sub Bus
+// ============================================================================
+
+// RedisBus is an InvalidationBus using Redis PUBLISH/SUBSCRIBE.
+//
+// Publishing uses a small connection pool; receiving uses one dedicated
+// connection that is re-established automatically after failures.
+// Invalidations published while the subscriber is disconnected are lost
+// (Redis pub/sub is fire-and-forget); affected entries still expire by TTL.
+type RedisBus struct {
+	pool           *redisPool
+	channel        string
+	reconnectDelay time.Duration
+	logger         *zap.Logger
+	handlers       handlerSet
+
+	mu      sync.Mutex
+	conn    *respConn     // Current subscriber connection
+	started bool          // Subscriber goroutine running
+	ready   chan struct{} // Closed once the first subscription is confirmed
+	closed  bool
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// RedisBusConfig holds configuration for RedisBus.
+type RedisBusConfig struct {
+	// Addr is the server address in host:port form (required).
+	Addr string
+
+	// Password is sent with AUTH when non-empty.
+	Password string
+
+	// Channel is the pub/sub channel name.
+	// Defaults to "tokenguard:invalidate" if empty.
+	Channel string
+
+	// PoolSize is the maximum number of publishing connections.
+	// Defaults to 2 if zero.
+	PoolSize int
+
+	// DialTimeout bounds establishing a new connection.
+	// Defaults to 5 seconds if zero.
+	DialTimeout time.Duration
+
+	// Timeout bounds each publish when the context has no deadline.
+	// Defaults to 3 seconds if zero.
+	Timeout time.Duration
+
+	// ReconnectDelay is the pause before re-subscribing after a failure.
+	// Defaults to 1 second if zero.
+	ReconnectDelay time.Duration
+
+	// Logger reports subscriber disconnects (optional).
+	Logger *zap.Logger
+}
+
+// NewRedisBus creates a new Redis pub/sub bus.
+//
+// No connection is made until the first Publish or Subscribe.
+func NewRedisBus(cfg RedisBusConfig) (*RedisBus, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("redis address is required")
+	}
+	if cfg.Channel == "" {
+		cfg.Channel = DefaultInvalidationChannel
+	}
+	if cfg.PoolSize == 0 {
+		cfg.PoolSize = 2
+	}
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultRedisTimeout
+	}
+	if cfg.ReconnectDelay == 0 {
+		cfg.ReconnectDelay = time.Second
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	return &RedisBus{
+		pool: newRedisPool(redisPoolConfig{
+			addr:        cfg.Addr,
+			password:    cfg.Password,
+			size:        cfg.PoolSize,
+			dialTimeout: cfg.DialTimeout,
+			timeout:     cfg.Timeout,
+		}),
+		channel:        cfg.Channel,
+		reconnectDelay: cfg.ReconnectDelay,
+		logger:         cfg.Logger,
+		ready:          make(chan struct{}),
+		done:           make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}, nil
+}
+
+// Publish broadcasts an invalidation to all subscribed replicas.
+func (b *RedisBus) Publish(ctx context.Context, tokenMint string) error {
+	conn, err := b.pool.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.do("PUBLISH", b.channel, tokenMint)
+	b.pool.put(conn, err)
+	if err != nil {
+		return fmt.Errorf("redis publish: %w", err)
+	}
+	return nil
+}
+
+// Subscribe registers handler for invalidations.
+//
+// The first call starts the subscriber connection and waits (bounded by
+// ctx) until the server confirms the subscription.
+func (b *RedisBus) Subscribe(ctx context.Context, handler func(tokenMint string)) (func(), error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, errors.New("redis bus is closed")
+	}
+	unsubscribe := b.handlers.add(handler)
+	if !b.started {
+		b.started = true
+		go b.run()
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.ready:
+		return unsubscribe, nil
+	case <-ctx.Done():
+		unsubscribe()
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the subscriber and closes all connections.
+func (b *RedisBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	if b.conn != nil {
+		_ = b.conn.close() // Unblocks the subscriber's read
+	}
+	started := b.started
+	b.mu.Unlock()
+
+	if started {
+		<-b.stopped
+	}
+	return b.pool.close()
+}
+
+// run keeps a subscriber connection alive until Close is called.
+func (b *RedisBus) run() {
+	defer close(b.stopped)
+
+	var readyOnce sync.Once
+	markReady := func() { readyOnce.Do(func() { close(b.ready) }) }
+
+	for {
+		err := b.listen(markReady)
+
+		select {
+		case <-b.done:
+			return
+		default:
+		}
+
+		b.logger.Warn("invalidation subscriber disconnected",
+			zap.String("channel", b.channel),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(b.reconnectDelay):
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// listen subscribes on a fresh connection and dispatches messages until the
+// connection fails.
+func (b *RedisBus) listen(markReady func()) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.pool.cfg.dialTimeout)
+	conn, err := b.pool.dial(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		_ = conn.close()
+		return nil
+	}
+	b.conn = conn
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.conn = nil
+		b.mu.Unlock()
+		_ = conn.close()
+	}()
+
+	// Subscriptions are long-lived; clear the dial deadline.
+	if err := conn.conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	if err := conn.writeCommand("SUBSCRIBE", b.channel); err != nil {
+		return err
+	}
+	if err := conn.w.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		reply, err := conn.readReply()
+		if err != nil {
+			return err
+		}
+		if err := replyError(reply); err != nil {
+			return err
+		}
+		if len(reply.array) != 3 {
+			continue
+		}
+
+		switch reply.array[0].str {
+		case "subscribe":
+			markReady()
+		case "message":
+			b.handlers.dispatch(reply.array[2].str)
+		}
+	}
+}
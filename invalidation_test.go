@@ -0,0 +1,219 @@
+package tokenguard
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or the timeout elapses.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func newTestRedisBus(t *testing.T, server *fakeRedis) *RedisBus {
+	t.Helper()
+
+	bus, err := NewRedisBus(RedisBusConfig{
+		Addr:           server.addr(),
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewRedisBus() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+	return bus
+}
+
+func TestNewBroadcastInvalidator_RequiresBus(t *testing.T) {
+	if _, err := NewBroadcastInvalidator(nil, nil); err == nil {
+		t.Error("expected error for missing bus")
+	}
+}
+
+func TestLocalBus_EvictsSubscribedCaches(t *testing.T) {
+	ctx := context.Background()
+	bus := NewLocalBus()
+	memory := NewInMemoryCache(InMemoryCacheConfig{})
+	sharded := NewShardedCache(ShardedCacheConfig{Shards: 4})
+	defer sharded.Close()
+
+	for _, cache := range []Cache{memory, sharded} {
+		if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "other-mint"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	if _, err := memory.SubscribeInvalidations(ctx, bus); err != nil {
+		t.Fatalf("SubscribeInvalidations() error = %v", err)
+	}
+	unsubscribe, err := sharded.SubscribeInvalidations(ctx, bus)
+	if err != nil {
+		t.Fatalf("SubscribeInvalidations() error = %v", err)
+	}
+
+	invalidator, err := NewBroadcastInvalidator(bus, nil)
+	if err != nil {
+		t.Fatalf("NewBroadcastInvalidator() error = %v", err)
+	}
+	if err := invalidator.Invalidate(ctx, "test-mint"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	for _, cache := range []Cache{memory, sharded} {
+		if _, ok := cache.Get(ctx, "test-mint"); ok {
+			t.Errorf("%T: expected invalidated entry to be evicted", cache)
+		}
+		if _, ok := cache.Get(ctx, "other-mint"); !ok {
+			t.Errorf("%T: expected other entries to be kept", cache)
+		}
+	}
+
+	// After unsubscribing, the sharded cache no longer reacts.
+	unsubscribe()
+	if err := invalidator.Invalidate(ctx, "other-mint"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, ok := sharded.Get(ctx, "other-mint"); !ok {
+		t.Error("expected unsubscribed cache to keep its entry")
+	}
+	if _, ok := memory.Get(ctx, "other-mint"); ok {
+		t.Error("expected subscribed cache to evict entry")
+	}
+}
+
+func TestBroadcastInvalidator_ClearsSharedCache(t *testing.T) {
+	ctx := context.Background()
+	shared := NewInMemoryCache(InMemoryCacheConfig{})
+	if err := shared.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	invalidator, err := NewBroadcastInvalidator(NewLocalBus(), shared)
+	if err != nil {
+		t.Fatalf("NewBroadcastInvalidator() error = %v", err)
+	}
+	if err := invalidator.Invalidate(ctx, "test-mint"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	if _, ok := shared.Get(ctx, "test-mint"); ok {
+		t.Error("expected entry removed from shared cache")
+	}
+	if err := invalidator.Invalidate(ctx, ""); err == nil {
+		t.Error("expected error for empty token mint")
+	}
+}
+
+func TestRedisBus_InvalidatesAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	shared := newTestRedisCache(t, server, RedisCacheConfig{TTL: time.Minute})
+
+	// Two replicas, each with a local L1 in front of the shared Redis L2.
+	var replicas []*TieredCache
+	var l1s []*InMemoryCache
+	for i := 0; i < 2; i++ {
+		l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+		tiered, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: shared})
+		if err != nil {
+			t.Fatalf("NewTieredCache() error = %v", err)
+		}
+		if _, err := l1.SubscribeInvalidations(ctx, newTestRedisBus(t, server)); err != nil {
+			t.Fatalf("SubscribeInvalidations() error = %v", err)
+		}
+		replicas = append(replicas, tiered)
+		l1s = append(l1s, l1)
+	}
+
+	if err := replicas[0].Set(ctx, &TokenScreeningResult{TokenMint: "test-mint", Passed: true}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	// Replica 1 promotes the shared result into its L1.
+	if _, ok := replicas[1].Get(ctx, "test-mint"); !ok {
+		t.Fatal("expected shared hit on second replica")
+	}
+
+	invalidator, err := NewBroadcastInvalidator(newTestRedisBus(t, server), shared)
+	if err != nil {
+		t.Fatalf("NewBroadcastInvalidator() error = %v", err)
+	}
+	if err := invalidator.Invalidate(ctx, "test-mint"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	for i, l1 := range l1s {
+		evicted := waitFor(t, time.Second, func() bool {
+			_, ok := l1.Get(ctx, "test-mint")
+			return !ok
+		})
+		if !evicted {
+			t.Errorf("replica %d: expected L1 entry to be invalidated", i)
+		}
+	}
+	if _, ok := replicas[1].Get(ctx, "test-mint"); ok {
+		t.Error("expected no stale result after invalidation")
+	}
+}
+
+func TestRedisBus_ResubscribesAfterDisconnect(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	bus := newTestRedisBus(t, server)
+
+	received := make(chan string, 10)
+	if _, err := bus.Subscribe(ctx, func(tokenMint string) { received <- tokenMint }); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if got := server.subscriberCount(DefaultInvalidationChannel); got != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", got)
+	}
+
+	server.dropConnections()
+	waitFor(t, time.Second, func() bool {
+		return server.subscriberCount(DefaultInvalidationChannel) == 0
+	})
+
+	resubscribed := waitFor(t, 2*time.Second, func() bool {
+		return server.subscriberCount(DefaultInvalidationChannel) == 1
+	})
+	if !resubscribed {
+		t.Fatal("expected bus to resubscribe after disconnect")
+	}
+
+	if err := bus.Publish(ctx, "test-mint"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case got := <-received:
+		if got != "test-mint" {
+			t.Errorf("expected test-mint, got %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected invalidation after resubscribing")
+	}
+}
+
+func TestRedisBus_SubscribeAfterClose(t *testing.T) {
+	server := newFakeRedis(t)
+	bus := newTestRedisBus(t, server)
+
+	if err := bus.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := bus.Subscribe(context.Background(), func(string) {}); err == nil {
+		t.Error("expected error subscribing to closed bus")
+	}
+}
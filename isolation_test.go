@@ -0,0 +1,189 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	birdeye "github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// These tests exercise callers mutating results returned by caches while
+// other goroutines read the same entries. Run them with -race: without
+// isolation they report data races in addition to failing assertions.
+
+func TestTokenScreeningResult_Clone(t *testing.T) {
+	original := &TokenScreeningResult{
+		TokenMint:      "test-mint",
+		Score:          60,
+		Details:        ScreeningDetails{LiquidityUSD: decimal.NewFromInt(1000)},
+		FailureReasons: []string{"has_mint_authority"},
+	}
+
+	clone := original.Clone()
+	clone.Score = 0
+	clone.Details.LiquidityUSD = decimal.Zero
+	clone.FailureReasons[0] = "changed"
+	clone.FailureReasons = append(clone.FailureReasons, "appended")
+
+	if original.Score != 60 || !original.Details.LiquidityUSD.Equal(decimal.NewFromInt(1000)) {
+		t.Error("expected scalar fields to be independent")
+	}
+	if len(original.FailureReasons) != 1 || original.FailureReasons[0] != "has_mint_authority" {
+		t.Errorf("expected FailureReasons to be independent, got %v", original.FailureReasons)
+	}
+
+	if (*TokenScreeningResult)(nil).Clone() != nil {
+		t.Error("expected nil clone of nil result")
+	}
+	if got := (&TokenScreeningResult{FailureReasons: []string{}}).Clone(); got.FailureReasons == nil {
+		t.Error("expected empty FailureReasons to stay non-nil")
+	}
+}
+
+// isolatedCaches returns the in-process caches that must isolate results.
+func isolatedCaches(t *testing.T) map[string]Cache {
+	t.Helper()
+
+	l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	tiered, err := NewTieredCache(TieredCacheConfig{
+		L1: l1,
+		L2: NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute}),
+	})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	return map[string]Cache{
+		"InMemoryCache": NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute}),
+		"ShardedCache":  NewShardedCache(ShardedCacheConfig{TTL: time.Minute}),
+		"TieredCache":   tiered,
+	}
+}
+
+func TestCaches_CallerMutationDoesNotLeak(t *testing.T) {
+	ctx := context.Background()
+
+	for name, cache := range isolatedCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			input := &TokenScreeningResult{
+				TokenMint:      "test-mint",
+				Passed:         true,
+				Score:          100,
+				FailureReasons: []string{},
+			}
+			if err := cache.Set(ctx, input); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			// Mutating the value passed to Set does not change the cache.
+			input.Score = 1
+			input.FailureReasons = append(input.FailureReasons, "mutated_input")
+
+			got, _ := cache.Get(ctx, "test-mint")
+			got.Passed = false
+			got.Details.HasMintAuthority = true
+			got.FailureReasons = append(got.FailureReasons, "mutated_output")
+
+			again, ok := cache.Get(ctx, "test-mint")
+			if !ok {
+				t.Fatal("expected cache hit")
+			}
+			if !again.Passed || again.Score != 100 || again.Details.HasMintAuthority {
+				t.Errorf("cached result was mutated: %+v", again)
+			}
+			if len(again.FailureReasons) != 0 {
+				t.Errorf("cached FailureReasons were mutated: %v", again.FailureReasons)
+			}
+		})
+	}
+}
+
+func TestCaches_ConcurrentCallerMutation(t *testing.T) {
+	ctx := context.Background()
+
+	for name, cache := range isolatedCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 4; i++ {
+				_ = cache.Set(ctx, &TokenScreeningResult{
+					TokenMint:      fmt.Sprintf("mint-%d", i),
+					Score:          100,
+					FailureReasons: make([]string, 0, 8), // Spare capacity invites aliasing
+				})
+			}
+
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < 200; i++ {
+						mint := fmt.Sprintf("mint-%d", i%4)
+						got, ok := cache.Get(ctx, mint)
+						if !ok {
+							continue
+						}
+
+						// Callers annotate results they receive.
+						got.Score -= g
+						got.Details.LiquidityUSD = decimal.NewFromInt(int64(i))
+						got.FailureReasons = append(got.FailureReasons, fmt.Sprintf("caller-%d", g))
+
+						if i%50 == 0 {
+							_ = cache.Set(ctx, got)
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestScreener_Screen_CachedResultIsolated(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	screener, err := New(Config{
+		SecurityProvider: &mockSecurityProvider{
+			security: &birdeye.TokenSecurity{CreatorPercentage: "5", Top10HolderPercent: "30"},
+		},
+		OverviewProvider: &mockOverviewProvider{
+			overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(100000)},
+		},
+		Cache:  NewInMemoryCache(InMemoryCacheConfig{}),
+		Logger: logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to create screener: %v", err)
+	}
+
+	first, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	first.Passed = false
+	first.FailureReasons = append(first.FailureReasons, "caller_veto")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal)
+			if err != nil {
+				t.Errorf("Screen() error = %v", err)
+				return
+			}
+			if !result.Passed || len(result.FailureReasons) != 0 {
+				t.Errorf("cached result leaked caller mutation: %+v", result)
+			}
+			result.FailureReasons = append(result.FailureReasons, "another_veto")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,222 @@
+package tokenguard
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultSniperWindowSlots is the default number of slots after the launch
+// slot in which buys count as sniped.
+const DefaultSniperWindowSlots = 3
+
+// Defaults for SolanaRPCHistoryProvider launch lookups.
+const (
+	DefaultLaunchMaxTransactions = 100
+	DefaultLaunchCacheSize       = 10000
+)
+
+// ============================================================================
+// Launch History
+// ============================================================================
+
+// LaunchBuy is a purchase of a token in its earliest trading.
+type LaunchBuy struct {
+	// Buyer is the wallet that received the tokens.
+	Buyer string `json:"buyer"`
+
+	// Slot is the slot the buy landed in.
+	Slot uint64 `json:"slot"`
+
+	// Amount is the amount bought in whole tokens.
+	Amount decimal.Decimal `json:"amount"`
+
+	// At is when the buy happened (zero if unknown).
+	At time.Time `json:"at"`
+}
+
+// LaunchHistoryProvider looks up a token's earliest trades, for the launch
+// sniper check.
+type LaunchHistoryProvider interface {
+	// GetLaunchBuys returns buys from the token's first trades, oldest
+	// first. An empty result means the launch is unknown.
+	GetLaunchBuys(ctx context.Context, tokenMint string) ([]LaunchBuy, error)
+}
+
+// launchCache is implemented by launch history providers that cache
+// launches. The screener does not charge for cached launches.
+type launchCache interface {
+	cachedLaunchBuys(tokenMint string) ([]LaunchBuy, bool)
+}
+
+// launchAnalysis is who bought in a token's launch window.
+type launchAnalysis struct {
+	snipers map[string]decimal.Decimal // Amount bought in the window, by buyer
+	bundle  map[string]struct{}        // Buyers in the launch slot, if two or more
+	bought  decimal.Decimal            // Total bought in the window
+}
+
+// analyzeLaunch groups buys within windowSlots slots of the launch slot
+// (the first buy's slot). Several wallets buying in the launch slot itself
+// are a bundle: buys submitted together with the launch, usually by the
+// deployer.
+func analyzeLaunch(buys []LaunchBuy, windowSlots int) launchAnalysis {
+	analysis := launchAnalysis{
+		snipers: make(map[string]decimal.Decimal),
+		bundle:  make(map[string]struct{}),
+	}
+	if len(buys) == 0 {
+		return analysis
+	}
+
+	launchSlot := buys[0].Slot
+	for _, buy := range buys {
+		launchSlot = min(launchSlot, buy.Slot)
+	}
+
+	for _, buy := range buys {
+		if buy.Slot > launchSlot+uint64(windowSlots) {
+			continue
+		}
+		analysis.snipers[buy.Buyer] = analysis.snipers[buy.Buyer].Add(buy.Amount)
+		analysis.bought = analysis.bought.Add(buy.Amount)
+		if buy.Slot == launchSlot {
+			analysis.bundle[buy.Buyer] = struct{}{}
+		}
+	}
+	if len(analysis.bundle) < 2 {
+		analysis.bundle = map[string]struct{}{}
+	}
+
+	return analysis
+}
+
+// heldPct sums the current shares of the given wallets among holders.
+func heldPct[V any](holders []HolderShare, wallets map[string]V) decimal.Decimal {
+	var pct decimal.Decimal
+	for _, holder := range holders {
+		if _, ok := wallets[holder.Owner]; ok {
+			pct = pct.Add(holder.Pct)
+		}
+	}
+	return pct
+}
+
+// ============================================================================
+// RPC Launch Buys
+// ============================================================================
+
+// GetLaunchBuys returns buys from the mint's oldest transactions, oldest
+// first.
+//
+// A buy is a successful transaction invoking a supported AMM program (see
+// Venue) in which a signer's balance of the token increased. Mints with
+// more history than MaxPages covers have an unknown launch. Launches read
+// from the mint's first transaction are cached, including those of mints
+// with fewer than MaxLaunchTransactions transactions: the sniper window
+// closes a few slots after the first buy.
+func (p *SolanaRPCHistoryProvider) GetLaunchBuys(ctx context.Context, tokenMint string) ([]LaunchBuy, error) {
+	if cached, ok := p.cachedLaunchBuys(tokenMint); ok {
+		return cached, nil
+	}
+
+	oldest, first, err := p.oldestSignatures(ctx, tokenMint, p.maxLaunchTransactions)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, nil
+	}
+
+	var buys []LaunchBuy
+	for i := len(oldest) - 1; i >= 0; i-- {
+		tx, err := p.rpc.getTransaction(ctx, oldest[i].Signature)
+		if err != nil {
+			return nil, err
+		}
+		if tx == nil || tx.failed() || !invokesVenue(tx) {
+			continue
+		}
+		buys = append(buys, tokenBuys(tx, tokenMint)...)
+	}
+
+	p.mu.Lock()
+	storeBounded(p.launches, p.launchCacheSize, tokenMint, buys)
+	p.mu.Unlock()
+	return buys, nil
+}
+
+// cachedLaunchBuys returns a mint's cached launch buys.
+func (p *SolanaRPCHistoryProvider) cachedLaunchBuys(tokenMint string) ([]LaunchBuy, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	buys, ok := p.launches[tokenMint]
+	return buys, ok
+}
+
+// invokesVenue reports whether a transaction calls a supported AMM program,
+// directly or through another program.
+func invokesVenue(tx *rpcTransaction) bool {
+	for _, ix := range tx.instructions() {
+		if _, ok := venuePrograms[ix.ProgramID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenBuys returns the signers of tx whose balance of mint increased.
+func tokenBuys(tx *rpcTransaction, mint string) []LaunchBuy {
+	if tx.Meta == nil {
+		return nil
+	}
+
+	var decimals int32
+	deltas := make(map[string]*big.Int)
+	add := func(balances []rpcTokenBalance, sign int) {
+		for _, balance := range balances {
+			if balance.Mint != mint || balance.Owner == "" {
+				continue
+			}
+			amount, ok := new(big.Int).SetString(balance.UITokenAmount.Amount, 10)
+			if !ok {
+				continue
+			}
+			if deltas[balance.Owner] == nil {
+				deltas[balance.Owner] = new(big.Int)
+			}
+			if sign < 0 {
+				amount.Neg(amount)
+			}
+			deltas[balance.Owner].Add(deltas[balance.Owner], amount)
+			decimals = balance.UITokenAmount.Decimals
+		}
+	}
+	add(tx.Meta.PreTokenBalances, -1)
+	add(tx.Meta.PostTokenBalances, 1)
+
+	var at time.Time
+	if tx.BlockTime != nil {
+		at = time.Unix(*tx.BlockTime, 0)
+	}
+
+	signers := tx.signers()
+	var buys []LaunchBuy
+	for owner, delta := range deltas {
+		if _, signed := signers[owner]; !signed || delta.Sign() <= 0 {
+			continue
+		}
+		buys = append(buys, LaunchBuy{
+			Buyer:  owner,
+			Slot:   tx.Slot,
+			Amount: decimal.NewFromBigInt(delta, -decimals),
+			At:     at,
+		})
+	}
+	sort.Slice(buys, func(i, j int) bool { return buys[i].Buyer < buys[j].Buyer })
+
+	return buys
+}
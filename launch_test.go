@@ -0,0 +1,268 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// launchMintAddr has fixtures for a pump.fun launch: the deployer and a
+// bundled wallet buy in the launch slot, a sniper buys through a router two
+// slots later, and a latecomer buys after the window. A failed buy and a
+// plain token transfer are not buys.
+const launchMintAddr = "ETb5z6aF5kw1y5FhKhYEYhBLQ2oKFBKHMzvPu5aZpnZ9"
+
+const (
+	launchDeployer = "5N7UUqTYosUhQM7mnWEjJQihi95joFVLAF5fW3kB2hJP"
+	launchBundler  = "D2s7a1nrtvTBjefMnpNZUVNmFtFG4GPKEwaaUZYb8qwp"
+	launchSniper   = "7nNnhKwo5Sa9jz4UrTMwD8uWJDEye7EjjkMP33xVk42d"
+	launchLate     = "3nNQSknfxABZdPHUK5MBucTphEN8HtuMXGuwvudxtVNG"
+)
+
+// launchHistory is a LaunchHistoryProvider returning fixed buys.
+type launchHistory struct {
+	buys []LaunchBuy
+	err  error
+}
+
+func (h *launchHistory) GetLaunchBuys(_ context.Context, _ string) ([]LaunchBuy, error) {
+	return h.buys, h.err
+}
+
+func testLaunchBuys() []LaunchBuy {
+	buy := func(buyer string, slot uint64, amount int64) LaunchBuy {
+		return LaunchBuy{Buyer: buyer, Slot: slot, Amount: decimal.NewFromInt(amount)}
+	}
+	return []LaunchBuy{
+		buy("deployer", 100, 60),
+		buy("bundled", 100, 40),
+		buy("sniper", 102, 50),
+		buy("sniper", 103, 20),
+		buy("late", 110, 30),
+	}
+}
+
+func TestAnalyzeLaunch(t *testing.T) {
+	launch := analyzeLaunch(testLaunchBuys(), DefaultSniperWindowSlots)
+
+	want := map[string]decimal.Decimal{
+		"deployer": decimal.NewFromInt(60),
+		"bundled":  decimal.NewFromInt(40),
+		"sniper":   decimal.NewFromInt(70),
+	}
+	if len(launch.snipers) != len(want) {
+		t.Fatalf("expected snipers %v, got %v", want, launch.snipers)
+	}
+	for buyer, amount := range want {
+		if !launch.snipers[buyer].Equal(amount) {
+			t.Errorf("expected %s to have bought %s, got %s", buyer, amount, launch.snipers[buyer])
+		}
+	}
+	if !launch.bought.Equal(decimal.NewFromInt(170)) {
+		t.Errorf("expected 170 bought in window, got %s", launch.bought)
+	}
+	if !reflect.DeepEqual(launch.bundle, map[string]struct{}{"deployer": {}, "bundled": {}}) {
+		t.Errorf("expected launch slot bundle, got %v", launch.bundle)
+	}
+
+	// A single launch slot buyer is not a bundle
+	single := analyzeLaunch(testLaunchBuys()[1:], 0)
+	if len(single.bundle) != 0 || len(single.snipers) != 1 {
+		t.Errorf("expected one sniper and no bundle, got %v and %v", single.snipers, single.bundle)
+	}
+}
+
+func TestScreener_Screen_LaunchSnipers(t *testing.T) {
+	holders := func(shares map[string]int64) *HolderData {
+		data := &HolderData{Supply: decimal.NewFromInt(1000)}
+		for owner, pct := range shares {
+			data.Holders = append(data.Holders, HolderShare{Owner: owner, Pct: decimal.NewFromInt(pct)})
+		}
+		return data
+	}
+
+	tests := []struct {
+		name        string
+		buys        []LaunchBuy
+		holders     *HolderData
+		windowSlots int
+		wantReasons []string
+		wantHeld    int64
+		wantBundle  int64
+	}{
+		{"unknown launch", nil, holders(map[string]int64{"deployer": 15}), 0, nil, 0, 0},
+		{"snipers sold", testLaunchBuys(), holders(map[string]int64{"sniper": 2, "late": 3}), 0, nil, 2, 0},
+		{"snipers holding", testLaunchBuys(), holders(map[string]int64{"sniper": 12, "deployer": 9, "late": 12}), 0,
+			[]string{"sniper_concentration:21.00%"}, 21, 9},
+		{"bundle holding", testLaunchBuys(), holders(map[string]int64{"deployer": 6, "bundled": 6, "sniper": 4}), 0,
+			[]string{"bundle_concentration:12.00%"}, 16, 12},
+		{"wider window", testLaunchBuys(), holders(map[string]int64{"sniper": 7, "late": 12, "deployer": 2}), 10,
+			[]string{"sniper_concentration:21.00%"}, 21, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			screener, err := New(Config{
+				SecurityDataProvider:  &staticSecurityData{data: &SecurityData{}},
+				MarketDataProvider:    &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+				HolderDataProvider:    &staticHolderData{data: tt.holders},
+				LaunchHistoryProvider: &launchHistory{buys: tt.buys},
+				SniperWindowSlots:     tt.windowSlots,
+				Logger:                zap.NewNop(),
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if len(result.FailureReasons)+len(tt.wantReasons) > 0 && !reflect.DeepEqual(result.FailureReasons, tt.wantReasons) {
+				t.Errorf("expected reasons %v, got %v", tt.wantReasons, result.FailureReasons)
+			}
+			details := result.Details
+			if !details.SniperHeldPct.Equal(decimal.NewFromInt(tt.wantHeld)) ||
+				!details.BundleHeldPct.Equal(decimal.NewFromInt(tt.wantBundle)) {
+				t.Errorf("expected snipers holding %d%% and bundle %d%%, got %s%% and %s%%",
+					tt.wantHeld, tt.wantBundle, details.SniperHeldPct, details.BundleHeldPct)
+			}
+			if tt.buys != nil && (details.SniperWallets == 0 || !details.SniperBoughtPct.IsPositive()) {
+				t.Errorf("expected sniper purchases reported, got %+v", details)
+			}
+			if skipped := contains(result.SkippedChecks, "launch_snipers"); skipped != (tt.buys == nil) {
+				t.Errorf("expected unknown launch skipped=%v, got %v", tt.buys == nil, result.SkippedChecks)
+			}
+		})
+	}
+}
+
+func TestScreener_Screen_LaunchSnipersErrors(t *testing.T) {
+	launches := &launchHistory{err: errors.New("history unavailable")}
+	cfg := Config{
+		SecurityDataProvider:  &staticSecurityData{data: &SecurityData{}},
+		MarketDataProvider:    &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+		LaunchHistoryProvider: launches,
+		Logger:                zap.NewNop(),
+	}
+
+	// Skipped without holder lists
+	screener, _ := New(cfg)
+	if _, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal); err != nil {
+		t.Errorf("expected check skipped without holder provider, got %v", err)
+	}
+
+	cfg.HolderDataProvider = &staticHolderData{data: &HolderData{Holders: splitHolders("holder", 2, 10)}}
+	screener, _ = New(cfg)
+	if _, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal); err == nil {
+		t.Error("expected lookup error to fail screening")
+	}
+
+	cfg.SniperWindowSlots = -1
+	if _, err := New(cfg); err == nil {
+		t.Error("expected error for negative sniper window")
+	}
+}
+
+func TestScreener_Screen_CachedLaunchIsFree(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{
+		RPC:                   SolanaRPCConfig{Endpoint: server.URL},
+		MaxLaunchTransactions: 3,
+	})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+	}
+	screener, err := New(Config{
+		SecurityDataProvider:  &staticSecurityData{data: &SecurityData{}},
+		MarketDataProvider:    &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+		HolderDataProvider:    &staticHolderData{data: &HolderData{Holders: splitHolders("holder", 2, 10)}},
+		LaunchHistoryProvider: provider,
+		CallCosts:             CallCosts{Launch: 100},
+		Logger:                zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// One security, market and holder call each, plus the launch lookup
+	for i, want := range []int64{103, 3} {
+		result, err := screener.Screen(context.Background(), launchMintAddr, ScreeningLevelNormal)
+		if err != nil {
+			t.Fatalf("Screen() error = %v", err)
+		}
+		if result.CreditsUsed != want {
+			t.Errorf("screening %d: expected %d credits used, got %d", i+1, want, result.CreditsUsed)
+		}
+	}
+}
+
+func TestSolanaRPCHistoryProvider_GetLaunchBuys(t *testing.T) {
+	const whole = 1_000_000 // Fixture amounts are in millions of tokens
+
+	tests := []struct {
+		name            string
+		maxPages        int
+		maxTransactions int
+		want            []string
+		wantCached      bool
+	}{
+		{"whole history", 0, 0, []string{launchDeployer, launchBundler, launchSniper, launchLate}, true},
+		{"first transactions", 0, 3, []string{launchDeployer, launchBundler, launchSniper}, true},
+		{"history beyond max pages", 1, 3, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRPCStandIn(t)
+			provider, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{
+				RPC:                   SolanaRPCConfig{Endpoint: server.URL},
+				MaxPages:              tt.maxPages,
+				MaxLaunchTransactions: tt.maxTransactions,
+			})
+			if err != nil {
+				t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+			}
+			if tt.maxPages != 0 {
+				provider.pageSize = 2
+			}
+
+			buys, err := provider.GetLaunchBuys(context.Background(), launchMintAddr)
+			if err != nil {
+				t.Fatalf("GetLaunchBuys() error = %v", err)
+			}
+
+			var got []string
+			for _, buy := range buys {
+				got = append(got, buy.Buyer)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected buyers %v, got %v", tt.want, got)
+			}
+			if len(buys) > 0 {
+				first := buys[0]
+				if first.Slot != 300000000 || !first.Amount.Equal(decimal.NewFromInt(100*whole)) ||
+					!first.At.Equal(time.Unix(1745000000, 0)) {
+					t.Errorf("expected deployer buy of 100M in slot 300000000, got %+v", first)
+				}
+				if sniper := buys[2]; sniper.Slot != 300000002 || !sniper.Amount.Equal(decimal.NewFromInt(30*whole)) {
+					t.Errorf("expected sniper buy of 30M through router, got %+v", sniper)
+				}
+			}
+
+			lookups := server.callCount("getSignaturesForAddress")
+			if _, err := provider.GetLaunchBuys(context.Background(), launchMintAddr); err != nil {
+				t.Fatalf("GetLaunchBuys() repeat error = %v", err)
+			}
+			if cached := server.callCount("getSignaturesForAddress") == lookups; cached != tt.wantCached {
+				t.Errorf("expected cached=%v, got %v", tt.wantCached, cached)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package tokenguard
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Solana token program IDs.
+const (
+	TokenProgramID     = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	Token2022ProgramID = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+)
+
+// SPL mint account layout.
+const (
+	mintSize = 82 // Base mint layout shared by both token programs
+
+	// Token-2022 pads mints to the token account size so the two can be told
+	// apart, then stores an account type byte followed by TLV extensions.
+	token2022AccountTypeOffset = 165
+	token2022ExtensionsOffset  = 166
+	token2022AccountTypeMint   = 1
+)
+
+// Token-2022 extension types relevant to screening.
+const (
+	extTransferFeeConfig = 1
+	extNonTransferable   = 9
+	extPermanentDelegate = 12
+	extTransferHook      = 14
+	extTokenMetadata     = 19
+)
+
+// token2022ExtensionNames names Token-2022 extension types.
+var token2022ExtensionNames = map[uint16]string{
+	1:  "transfer_fee_config",
+	3:  "mint_close_authority",
+	4:  "confidential_transfer_mint",
+	6:  "default_account_state",
+	9:  "non_transferable",
+	10: "interest_bearing_config",
+	12: "permanent_delegate",
+	14: "transfer_hook",
+	16: "confidential_transfer_fee_config",
+	18: "metadata_pointer",
+	19: "token_metadata",
+	20: "group_pointer",
+	21: "token_group",
+	22: "group_member_pointer",
+	23: "token_group_member",
+	25: "scaled_ui_amount",
+	26: "pausable",
+}
+
+// ============================================================================
+// Mint Account Decoding
+// ============================================================================
+
+// mintExtension is a raw Token-2022 TLV extension.
+type mintExtension struct {
+	kind uint16
+	data []byte
+}
+
+// mintAccount is a decoded SPL Token or Token-2022 mint.
+type mintAccount struct {
+	mintAuthority   string // Empty if revoked
+	freezeAuthority string // Empty if revoked
+	supply          uint64 // In base units
+	decimals        uint8
+	token2022       bool
+	extensions      []mintExtension
+}
+
+// decodeMintAccount decodes a mint account owned by owner.
+//
+// Errors wrap ErrNotMint when the account is not an initialized mint of
+// one of the token programs, except for malformed extension data.
+func decodeMintAccount(owner string, data []byte) (*mintAccount, error) {
+	if owner != TokenProgramID && owner != Token2022ProgramID {
+		return nil, fmt.Errorf("owned by %s: %w", owner, ErrNotMint)
+	}
+
+	// Mints are exactly the base size, except Token-2022 mints with
+	// extensions, which are longer and carry a mint account type. Other
+	// sizes are token accounts or other program data.
+	token2022 := owner == Token2022ProgramID
+	extended := token2022 && len(data) > token2022AccountTypeOffset &&
+		data[token2022AccountTypeOffset] == token2022AccountTypeMint
+	if len(data) != mintSize && !extended {
+		return nil, fmt.Errorf("unexpected %d byte account: %w", len(data), ErrNotMint)
+	}
+
+	mint := &mintAccount{
+		supply:    binary.LittleEndian.Uint64(data[36:44]),
+		decimals:  data[44],
+		token2022: token2022,
+	}
+
+	var err error
+	if mint.mintAuthority, err = decodeCOptionPubkey(data[0:36]); err != nil {
+		return nil, fmt.Errorf("mint authority: %w", err)
+	}
+	if data[45] != 1 {
+		return nil, fmt.Errorf("uninitialized: %w", ErrNotMint)
+	}
+	if mint.freezeAuthority, err = decodeCOptionPubkey(data[46:82]); err != nil {
+		return nil, fmt.Errorf("freeze authority: %w", err)
+	}
+
+	if extended {
+		if mint.extensions, err = decodeExtensions(data[token2022ExtensionsOffset:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return mint, nil
+}
+
+// decodeCOptionPubkey decodes a COption<Pubkey>: a 4-byte tag then 32 bytes.
+func decodeCOptionPubkey(data []byte) (string, error) {
+	switch binary.LittleEndian.Uint32(data[0:4]) {
+	case 0:
+		return "", nil
+	case 1:
+		return base58Encode(data[4:36]), nil
+	default:
+		return "", fmt.Errorf("invalid option tag: %w", ErrNotMint)
+	}
+}
+
+// decodeOptionalPubkey decodes an OptionalNonZeroPubkey, where all zeros
+// means none.
+func decodeOptionalPubkey(data []byte) string {
+	for _, b := range data[:32] {
+		if b != 0 {
+			return base58Encode(data[:32])
+		}
+	}
+	return ""
+}
+
+// decodeExtensions decodes Token-2022 TLV entries: a 2-byte type, a 2-byte
+// length and the value. An uninitialized (zero) type ends the list.
+func decodeExtensions(data []byte) ([]mintExtension, error) {
+	var extensions []mintExtension
+
+	for offset := 0; offset+4 <= len(data); {
+		kind := binary.LittleEndian.Uint16(data[offset:])
+		length := int(binary.LittleEndian.Uint16(data[offset+2:]))
+		if kind == 0 {
+			break
+		}
+
+		start := offset + 4
+		if start+length > len(data) {
+			return nil, fmt.Errorf("extension %d: truncated", kind)
+		}
+		extensions = append(extensions, mintExtension{kind: kind, data: data[start : start+length]})
+		offset = start + length
+	}
+
+	return extensions, nil
+}
+
+// securityData converts the mint to provider-agnostic security data.
+func (m *mintAccount) securityData(asOf time.Time) *SecurityData {
+	data := &SecurityData{
+		Source:          SolanaRPCSource,
+		AsOf:            asOf,
+		MintAuthority:   m.mintAuthority,
+		FreezeAuthority: m.freezeAuthority,
+		Supply:          decimal.NewFromBigInt(new(big.Int).SetUint64(m.supply), -int32(m.decimals)),
+		Decimals:        int(m.decimals),
+		IsToken2022:     m.token2022,
+
+		// Holder distribution is not part of the mint account
+		HolderSharesUnknown: true,
+	}
+
+	for _, ext := range m.extensions {
+		if name, ok := token2022ExtensionNames[ext.kind]; ok {
+			data.Extensions = append(data.Extensions, name)
+		} else {
+			data.Extensions = append(data.Extensions, fmt.Sprintf("extension_%d", ext.kind))
+		}
+
+		switch ext.kind {
+		case extTransferFeeConfig:
+			data.HasTransferFee = true
+			data.TransferFeeBPS = transferFeeBPS(ext.data)
+		case extNonTransferable:
+			data.NonTransferable = true
+		case extPermanentDelegate:
+			if len(ext.data) >= 32 {
+				data.PermanentDelegate = decodeOptionalPubkey(ext.data)
+			}
+		case extTransferHook:
+			if len(ext.data) >= 64 {
+				data.TransferHookProgram = decodeOptionalPubkey(ext.data[32:])
+			}
+		case extTokenMetadata:
+			if len(ext.data) >= 32 {
+				data.MutableMetadata = decodeOptionalPubkey(ext.data) != ""
+			}
+		}
+	}
+
+	return data
+}
+
+// transferFeeBPS returns the higher of the older and newer transfer fees
+// in a TransferFeeConfig extension.
+//
+// The newer fee takes effect at a future epoch; the higher of the two is
+// reported since either may apply to a trade made now or soon.
+func transferFeeBPS(data []byte) int {
+	// Config authority (32), withdraw authority (32), withheld amount (8),
+	// then two fees of epoch (8), maximum fee (8) and basis points (2).
+	const (
+		olderFeeBPSOffset = 32 + 32 + 8 + 16
+		newerFeeBPSOffset = olderFeeBPSOffset + 18
+	)
+	if len(data) < newerFeeBPSOffset+2 {
+		return 0
+	}
+
+	older := binary.LittleEndian.Uint16(data[olderFeeBPSOffset:])
+	newer := binary.LittleEndian.Uint16(data[newerFeeBPSOffset:])
+	return int(max(older, newer))
+}
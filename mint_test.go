@@ -0,0 +1,90 @@
+package tokenguard
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// testMintData builds a minimal initialized base mint layout.
+func testMintData() []byte {
+	data := make([]byte, mintSize)
+	binary.LittleEndian.PutUint64(data[36:44], 1000)
+	data[44] = 6 // Decimals
+	data[45] = 1 // Initialized
+	return data
+}
+
+func TestBase58_RoundTrip(t *testing.T) {
+	for _, address := range []string{usdcMint, TokenProgramID, Token2022ProgramID, "11111111111111111111111111111111"} {
+		decoded, err := base58Decode(address)
+		if err != nil {
+			t.Fatalf("base58Decode(%q) error = %v", address, err)
+		}
+		if len(decoded) != 32 {
+			t.Errorf("expected 32-byte key for %q, got %d", address, len(decoded))
+		}
+		if got := base58Encode(decoded); got != address {
+			t.Errorf("round trip of %q gave %q", address, got)
+		}
+	}
+
+	if _, err := base58Decode("0OIl"); err == nil {
+		t.Error("expected error for characters outside the alphabet")
+	}
+}
+
+func TestDecodeMintAccount_Invalid(t *testing.T) {
+	uninitialized := testMintData()
+	uninitialized[45] = 0
+
+	badTag := testMintData()
+	badTag[0] = 7
+
+	// A Token-2022 account typed as a token account rather than a mint.
+	tokenAccount := append(testMintData(), make([]byte, 84)...)
+	tokenAccount[token2022AccountTypeOffset] = 2
+
+	tests := []struct {
+		name  string
+		owner string
+		data  []byte
+	}{
+		{"wrong owner", "11111111111111111111111111111111", testMintData()},
+		{"too short", TokenProgramID, make([]byte, 40)},
+		{"uninitialized", TokenProgramID, uninitialized},
+		{"invalid option tag", TokenProgramID, badTag},
+		{"extended classic mint", TokenProgramID, append(testMintData(), 0)},
+		{"token-2022 token account", Token2022ProgramID, tokenAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeMintAccount(tt.owner, tt.data); !errors.Is(err, ErrNotMint) {
+				t.Errorf("expected ErrNotMint, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeExtensions(t *testing.T) {
+	var data bytes.Buffer
+	_ = binary.Write(&data, binary.LittleEndian, []uint16{extNonTransferable, 0})
+	_ = binary.Write(&data, binary.LittleEndian, []uint16{extPermanentDelegate, 32})
+	data.Write(bytes.Repeat([]byte{1}, 32))
+	data.Write(make([]byte, 8)) // Zero padding ends the list
+
+	extensions, err := decodeExtensions(data.Bytes())
+	if err != nil {
+		t.Fatalf("decodeExtensions() error = %v", err)
+	}
+	if len(extensions) != 2 || extensions[0].kind != extNonTransferable || len(extensions[1].data) != 32 {
+		t.Errorf("unexpected extensions: %+v", extensions)
+	}
+
+	truncated := data.Bytes()[:20]
+	if _, err := decodeExtensions(truncated); err == nil {
+		t.Error("expected error for truncated extension")
+	}
+}
@@ -0,0 +1,316 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+)
+
+// DefaultNotFoundTTL is the default time-to-live for cached "not found" failures.
+const DefaultNotFoundTTL = time.Minute
+
+// DefaultPersistentErrorTTL is the default time-to-live for other cached
+// persistent failures. Kept short so fixed upstream problems recover quickly.
+const DefaultPersistentErrorTTL = 15 * time.Second
+
+// ErrCachedFailure is matched (via errors.Is) by errors that were served from
+// the negative cache rather than produced by a fresh provider call.
+var ErrCachedFailure = errors.New("cached provider failure")
+
+// ============================================================================
+// Error Classification
+// ============================================================================
+
+// ErrorClass categorizes provider errors for negative caching.
+type ErrorClass int
+
+// Error class constants.
+const (
+	// ErrorClassTransient errors may succeed on retry and are never cached
+	// (timeouts, rate limits, server errors, network failures).
+	ErrorClassTransient ErrorClass = iota
+
+	// ErrorClassNotFound means the provider does not know the token.
+	ErrorClassNotFound
+
+	// ErrorClassPersistent errors will keep failing for the same input
+	// (e.g., a malformed address rejected by the provider).
+	ErrorClassPersistent
+)
+
+// String returns the class name.
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNotFound:
+		return "not_found"
+	case ErrorClassPersistent:
+		return "persistent"
+	default:
+		return "transient"
+	}
+}
+
+// ErrorClassifier decides how a provider error should be negatively cached.
+type ErrorClassifier func(err error) ErrorClass
+
+// ClassifyError is the default ErrorClassifier.
+//
+// It recognizes ErrTokenNotFound and ErrNotMint, Birdeye API errors and
+// Solana RPC errors. For HTTP failures, 404 is NotFound and other 4xx
+// responses are Persistent, except 408 and 429 and the account-level
+// 401, 402 and 403 (bad or expired API key, plan limits), which fail for
+// every token alike and are Transient; RPC requests rejected for
+// invalid parameters are Persistent. Errors from composite providers take
+// the most retryable class among their sources' errors. Everything else,
+// including context cancellation and network errors, is Transient.
+func ClassifyError(err error) ErrorClass {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTransient
+	}
+
+	var providerErrs *ProviderErrors
+	if errors.As(err, &providerErrs) {
+		return providerErrs.class(ClassifyError)
+	}
+
+	var replayed *ReplayedError
+	if errors.As(err, &replayed) {
+		return replayed.class()
+	}
+
+	switch {
+	case errors.Is(err, ErrTokenNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, ErrNotMint):
+		return ErrorClassPersistent
+	}
+
+	if apiErr, ok := birdeye.IsAPIError(err); ok {
+		return classifyHTTPStatus(apiErr.StatusCode)
+	}
+
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		if rpcErr.StatusCode != 0 {
+			return classifyHTTPStatus(rpcErr.StatusCode)
+		}
+		if rpcErr.Code == rpcInvalidParams {
+			return ErrorClassPersistent
+		}
+	}
+
+	return ErrorClassTransient
+}
+
+// classifyHTTPStatus classifies a failed HTTP response by status code.
+func classifyHTTPStatus(status int) ErrorClass {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrorClassNotFound
+	case status == http.StatusTooManyRequests, status == http.StatusRequestTimeout:
+		return ErrorClassTransient
+	case status == http.StatusUnauthorized, status == http.StatusPaymentRequired, status == http.StatusForbidden:
+		// Account problems, not token problems: caching them per mint
+		// would report every token screened meanwhile as bad
+		return ErrorClassTransient
+	case status >= 400 && status < 500:
+		return ErrorClassPersistent
+	default:
+		return ErrorClassTransient
+	}
+}
+
+// ============================================================================
+// Negative Cache
+// ============================================================================
+
+// CachedError is returned by Screen when a recent provider failure for the
+// token is served from the negative cache instead of calling providers again.
+//
+// It matches both ErrCachedFailure and the original error with errors.Is
+// and errors.As, so callers can tell it apart from a fresh failure while
+// still inspecting the underlying cause.
+type CachedError struct {
+	// TokenMint is the token whose screening failed.
+	TokenMint string
+
+	// Class is the classification of the original error.
+	Class ErrorClass
+
+	// Err is the original provider error.
+	Err error
+
+	// CachedAt is when the original failure occurred.
+	CachedAt time.Time
+
+	// ExpiresAt is when the failure will be forgotten and providers retried.
+	ExpiresAt time.Time
+}
+
+// Error implements the error interface.
+func (e *CachedError) Error() string {
+	return fmt.Sprintf("cached %s failure for %s (retry after %s): %v",
+		e.Class, e.TokenMint, e.ExpiresAt.Format(time.RFC3339), e.Err)
+}
+
+// Unwrap exposes both ErrCachedFailure and the original error.
+func (e *CachedError) Unwrap() []error {
+	return []error{ErrCachedFailure, e.Err}
+}
+
+// NegativeCache remembers classified provider failures for a short time, so
+// repeatedly screening an unknown or rejected mint (e.g., spam tokens seen on
+// every event) does not retry provider calls each time.
+//
+// Features:
+//   - Thread-safe
+//   - Separate TTLs for not-found and other persistent errors
+//   - Pluggable error classification
+//   - Configurable max size
+type NegativeCache struct {
+	entries       map[string]*CachedError
+	classify      ErrorClassifier
+	notFoundTTL   time.Duration
+	persistentTTL time.Duration
+	maxSize       int
+	mu            sync.RWMutex
+}
+
+// NegativeCacheConfig holds configuration for NegativeCache.
+type NegativeCacheConfig struct {
+	// NotFoundTTL is how long "token not found" failures are cached.
+	// Defaults to 1 minute if zero.
+	NotFoundTTL time.Duration
+
+	// PersistentErrorTTL is how long other persistent failures are cached.
+	// Defaults to 15 seconds if zero.
+	PersistentErrorTTL time.Duration
+
+	// Classifier decides which errors are cached.
+	// Defaults to ClassifyError if nil.
+	Classifier ErrorClassifier
+
+	// MaxSize is the maximum number of cached failures.
+	// Defaults to 10,000 if zero.
+	MaxSize int
+}
+
+// NewNegativeCache creates a new negative cache.
+func NewNegativeCache(cfg NegativeCacheConfig) *NegativeCache {
+	if cfg.NotFoundTTL == 0 {
+		cfg.NotFoundTTL = DefaultNotFoundTTL
+	}
+	if cfg.PersistentErrorTTL == 0 {
+		cfg.PersistentErrorTTL = DefaultPersistentErrorTTL
+	}
+	if cfg.Classifier == nil {
+		cfg.Classifier = ClassifyError
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = DefaultMaxCacheSize
+	}
+
+	return &NegativeCache{
+		entries:       make(map[string]*CachedError),
+		classify:      cfg.Classifier,
+		notFoundTTL:   cfg.NotFoundTTL,
+		persistentTTL: cfg.PersistentErrorTTL,
+		maxSize:       cfg.MaxSize,
+	}
+}
+
+// Get returns the cached failure for a token if one is still active.
+func (c *NegativeCache) Get(tokenMint string) (*CachedError, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[tokenMint]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.ExpiresAt) {
+		// Lazy deletion on read, unless the entry was replaced meanwhile
+		c.mu.Lock()
+		if c.entries[tokenMint] == entry {
+			delete(c.entries, tokenMint)
+		}
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// Record classifies err and caches it for the token unless it is transient.
+// Returns true if the failure was cached.
+func (c *NegativeCache) Record(tokenMint string, err error) bool {
+	class := c.classify(err)
+
+	var ttl time.Duration
+	switch class {
+	case ErrorClassNotFound:
+		ttl = c.notFoundTTL
+	case ErrorClassPersistent:
+		ttl = c.persistentTTL
+	default:
+		return false
+	}
+
+	now := time.Now()
+	entry := &CachedError{
+		TokenMint: tokenMint,
+		Class:     class,
+		Err:       err,
+		CachedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, isUpdate := c.entries[tokenMint]; !isUpdate && len(c.entries) >= c.maxSize {
+		c.evictLocked(now)
+	}
+	c.entries[tokenMint] = entry
+
+	return true
+}
+
+// Delete forgets any cached failure for a token.
+func (c *NegativeCache) Delete(tokenMint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, tokenMint)
+}
+
+// Size returns the number of cached failures (including expired).
+func (c *NegativeCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.entries)
+}
+
+// evictLocked removes expired entries, or one arbitrary entry if none expired.
+// Must be called with c.mu held (write lock).
+func (c *NegativeCache) evictLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if now.After(entry.ExpiresAt) {
+			delete(c.entries, key)
+		}
+	}
+
+	if len(c.entries) >= c.maxSize {
+		for key := range c.entries {
+			delete(c.entries, key)
+			break // Only evict one
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	birdeye "github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"not found", &birdeye.APIError{StatusCode: 404}, ErrorClassNotFound},
+		{"wrapped not found", fmt.Errorf("get token security: %w", &birdeye.APIError{StatusCode: 404}), ErrorClassNotFound},
+		{"bad request", &birdeye.APIError{StatusCode: 400}, ErrorClassPersistent},
+		{"rate limited", &birdeye.APIError{StatusCode: 429}, ErrorClassTransient},
+		{"request timeout", &birdeye.APIError{StatusCode: 408}, ErrorClassTransient},
+		{"unauthorized", &birdeye.APIError{StatusCode: 401}, ErrorClassTransient},
+		{"plan limit", &birdeye.APIError{StatusCode: 402}, ErrorClassTransient},
+		{"forbidden", &RPCError{StatusCode: 403}, ErrorClassTransient},
+		{"server error", &birdeye.APIError{StatusCode: 503}, ErrorClassTransient},
+		{"deadline", context.DeadlineExceeded, ErrorClassTransient},
+		{"unknown", errors.New("connection reset"), ErrorClassTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegativeCache_RecordAndExpire(t *testing.T) {
+	cache := NewNegativeCache(NegativeCacheConfig{NotFoundTTL: 10 * time.Millisecond})
+
+	if cache.Record("transient-mint", errors.New("timeout")) {
+		t.Error("expected transient error to not be cached")
+	}
+
+	notFound := &birdeye.APIError{StatusCode: 404, Message: "token not found"}
+	if !cache.Record("spam-mint", notFound) {
+		t.Fatal("expected not-found error to be cached")
+	}
+
+	cachedErr, ok := cache.Get("spam-mint")
+	if !ok {
+		t.Fatal("expected cached failure")
+	}
+	if cachedErr.Class != ErrorClassNotFound {
+		t.Errorf("expected class not_found, got %v", cachedErr.Class)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("spam-mint"); ok {
+		t.Error("expected cached failure to expire")
+	}
+}
+
+func TestNegativeCache_CustomClassifier(t *testing.T) {
+	errBlocked := errors.New("blocked mint")
+	cache := NewNegativeCache(NegativeCacheConfig{
+		Classifier: func(err error) ErrorClass {
+			if errors.Is(err, errBlocked) {
+				return ErrorClassPersistent
+			}
+			return ErrorClassTransient
+		},
+	})
+
+	if !cache.Record("test-mint", fmt.Errorf("wrapped: %w", errBlocked)) {
+		t.Error("expected custom-classified error to be cached")
+	}
+}
+
+func TestCachedError_Is(t *testing.T) {
+	original := &birdeye.APIError{StatusCode: 404}
+	err := error(&CachedError{TokenMint: "test-mint", Err: original})
+
+	if !errors.Is(err, ErrCachedFailure) {
+		t.Error("expected errors.Is(err, ErrCachedFailure)")
+	}
+	if _, ok := birdeye.IsAPIError(err); !ok {
+		t.Error("expected original API error to be reachable")
+	}
+}
+
+func TestScreener_Screen_NegativeCache(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	calls := 0
+	securityProvider := &countingSecurityProvider{
+		inner: &mockSecurityProvider{
+			err: &birdeye.APIError{StatusCode: 404, Message: "token not found"},
+		},
+		callCount: &calls,
+	}
+
+	screener, err := New(Config{
+		SecurityProvider: securityProvider,
+		OverviewProvider: &mockOverviewProvider{
+			overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(100000)},
+		},
+		NegativeCache: NewNegativeCache(NegativeCacheConfig{}),
+		Logger:        logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to create screener: %v", err)
+	}
+
+	// First screening is a fresh failure.
+	_, err = screener.Screen(ctx, "spam-mint", ScreeningLevelNormal)
+	if err == nil {
+		t.Fatal("expected error for unknown token")
+	}
+	if errors.Is(err, ErrCachedFailure) {
+		t.Error("expected first failure to be fresh, not cached")
+	}
+
+	// Second screening is served from the negative cache.
+	_, err = screener.Screen(ctx, "spam-mint", ScreeningLevelNormal)
+	if !errors.Is(err, ErrCachedFailure) {
+		t.Fatalf("expected cached failure, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 provider call, got %d", calls)
+	}
+}
+
+func TestScreener_Screen_NegativeCacheSkipsTransient(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	calls := 0
+	securityProvider := &countingSecurityProvider{
+		inner:     &mockSecurityProvider{err: &birdeye.APIError{StatusCode: 503}},
+		callCount: &calls,
+	}
+
+	screener, err := New(Config{
+		SecurityProvider: securityProvider,
+		OverviewProvider: &mockOverviewProvider{},
+		NegativeCache:    NewNegativeCache(NegativeCacheConfig{}),
+		Logger:           logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to create screener: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal); errors.Is(err, ErrCachedFailure) {
+			t.Error("expected transient failure to not be cached")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+}
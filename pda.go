@@ -0,0 +1,77 @@
+package tokenguard
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// ============================================================================
+// Program Derived Addresses
+// ============================================================================
+
+// pdaMarker is appended to the seeds when deriving program addresses.
+const pdaMarker = "ProgramDerivedAddress"
+
+// Curve25519 constants for the on-curve check.
+var (
+	curveP = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	curveD = func() *big.Int {
+		// d = -121665 / 121666 mod p
+		d := new(big.Int).ModInverse(big.NewInt(121666), curveP)
+		d.Mul(d, big.NewInt(-121665))
+		return d.Mod(d, curveP)
+	}()
+	curveLegendreExp = new(big.Int).Rsh(new(big.Int).Sub(curveP, big.NewInt(1)), 1)
+)
+
+// findProgramAddress derives the canonical program derived address (PDA)
+// for seeds and a program, as Solana's find_program_address does: the
+// first bump from 255 down whose derived address is off the ed25519 curve.
+func findProgramAddress(seeds [][]byte, programID []byte) ([]byte, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(programID)
+		h.Write([]byte(pdaMarker))
+
+		address := h.Sum(nil)
+		if !isOnCurve(address) {
+			return address, uint8(bump), nil
+		}
+	}
+	return nil, 0, errors.New("no viable program address bump")
+}
+
+// isOnCurve reports whether a 32-byte compressed point decompresses to a
+// valid ed25519 point, i.e. whether (y^2 - 1) / (d*y^2 + 1) is a square.
+func isOnCurve(point []byte) bool {
+	// Little-endian y coordinate; the top bit is the sign of x
+	le := make([]byte, 32)
+	for i := range le {
+		le[i] = point[31-i]
+	}
+	le[0] &= 0x7f
+	y := new(big.Int).SetBytes(le)
+	y.Mod(y, curveP)
+
+	y2 := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, curveP)
+	if u.Sign() == 0 {
+		return true
+	}
+
+	v := new(big.Int).Mul(curveD, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, curveP)
+
+	ratio := new(big.Int).ModInverse(v, curveP)
+	ratio.Mul(ratio, u)
+	ratio.Mod(ratio, curveP)
+
+	return new(big.Int).Exp(ratio, curveLegendreExp, curveP).Cmp(big.NewInt(1)) == 0
+}
@@ -0,0 +1,790 @@
+package tokenguard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Program IDs of the supported liquidity venues.
+const (
+	RaydiumAMMv4ProgramID  = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
+	RaydiumCPMMProgramID   = "CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C"
+	OrcaWhirlpoolProgramID = "whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc"
+	MeteoraDLMMProgramID   = "LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo"
+	PumpFunProgramID       = "6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"
+)
+
+// venuePrograms are the supported AMM programs. Their swaps count as launch
+// buys, and accounts they own (pools, bonding curves) are not holders.
+var venuePrograms = map[string]struct{}{
+	RaydiumAMMv4ProgramID:  {},
+	RaydiumCPMMProgramID:   {},
+	OrcaWhirlpoolProgramID: {},
+	MeteoraDLMMProgramID:   {},
+	PumpFunProgramID:       {},
+}
+
+// Pool authorities of the Raydium programs, which own the vaults of every
+// pool of their program.
+const (
+	RaydiumAMMv4Authority = "5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1"
+	RaydiumCPMMAuthority  = "GpMZbSM2GgvTKHJirzeGfMFoaZ8UR2X7F4v8vHTvxFbL"
+)
+
+// Mints of common quote assets.
+const (
+	WrappedSOLMint = "So11111111111111111111111111111111111111112"
+	USDCMint       = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	USDTMint       = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+)
+
+// PoolsSource is the Source name of market data computed from pool reserves.
+const PoolsSource = "amm-pools"
+
+// pricePrecision is the number of decimal places kept in computed prices.
+const pricePrecision = 24
+
+// pumpFunDecimals are the fixed decimals of pump.fun tokens and SOL.
+const (
+	pumpFunTokenDecimals = 6
+	solDecimals          = 9
+)
+
+// ErrNoQuotePrice is returned by QuotePriceSource for assets it cannot price.
+var ErrNoQuotePrice = errors.New("no quote price")
+
+// Venue identifies a liquidity venue (AMM program).
+type Venue string
+
+// Supported venues.
+const (
+	VenueRaydiumAMMv4  Venue = "raydium_amm_v4"
+	VenueRaydiumCPMM   Venue = "raydium_cpmm"
+	VenueOrcaWhirlpool Venue = "orca_whirlpool"
+	VenueMeteoraDLMM   Venue = "meteora_dlmm"
+	VenuePumpFun       Venue = "pumpfun"
+)
+
+// ============================================================================
+// Quote Prices
+// ============================================================================
+
+// QuotePriceSource prices the quote assets pools are paired against
+// (e.g., SOL, USDC).
+type QuotePriceSource interface {
+	// QuotePriceUSD returns the USD price of one whole unit of mint.
+	// It returns an error wrapping ErrNoQuotePrice if mint is not a quote
+	// asset it knows; pools paired against it are then skipped.
+	QuotePriceUSD(ctx context.Context, mint string) (decimal.Decimal, error)
+}
+
+// StaticQuotePrices is a QuotePriceSource with fixed prices by mint.
+type StaticQuotePrices map[string]decimal.Decimal
+
+// NewStaticQuotePrices returns quote prices for SOL at solPriceUSD and for
+// USDC and USDT at $1.
+func NewStaticQuotePrices(solPriceUSD decimal.Decimal) StaticQuotePrices {
+	return StaticQuotePrices{
+		WrappedSOLMint: solPriceUSD,
+		USDCMint:       decimal.NewFromInt(1),
+		USDTMint:       decimal.NewFromInt(1),
+	}
+}
+
+// QuotePriceUSD returns the configured price of mint.
+func (p StaticQuotePrices) QuotePriceUSD(_ context.Context, mint string) (decimal.Decimal, error) {
+	price, ok := p[mint]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%s: %w", mint, ErrNoQuotePrice)
+	}
+	return price, nil
+}
+
+// ============================================================================
+// Pool Layouts
+// ============================================================================
+
+// poolState is a decoded pool before reserves are resolved and priced.
+//
+// Side 0 and side 1 are the pool's two mints in program order.
+type poolState struct {
+	venue   Venue
+	address string
+	mints   [2]string
+	vaults  [2]string // Empty when reserves are stored in the pool account
+
+	// reserves are in base units; filled from vaults when those are set.
+	reserves [2]*big.Int
+
+	// held are amounts held in the vaults that are not tradable reserves
+	// (accrued protocol fees, pending PnL), subtracted from vault balances.
+	held [2]uint64
+
+	// decimals of each mint, or -1 if they must be read from the mint.
+	decimals [2]int
+
+	// quoteOnly marks pools whose token side is not exit liquidity
+	// (bonding curves), so only the quote reserve counts.
+	quoteOnly bool
+
+	// price returns the spot price of side 0 in units of side 1,
+	// in whole tokens.
+	price func(p *poolState) *big.Float
+}
+
+// poolLayout describes how to discover and decode one venue's pools.
+type poolLayout struct {
+	venue       Venue
+	program     string
+	dataSize    int    // Exact account size filter, or 0
+	kind        []byte // Anchor discriminator filter, or nil
+	mintOffsets [2]int
+	decode      func(address string, data []byte) (*poolState, error)
+}
+
+// anchorDiscriminator returns the 8-byte discriminator of an Anchor account.
+func anchorDiscriminator(name string) []byte {
+	sum := sha256.Sum256([]byte("account:" + name))
+	return sum[:8]
+}
+
+// poolLayouts are the discoverable venues. pump.fun bonding curves are
+// addressed by PDA instead (see pumpFunCurve).
+var poolLayouts = []poolLayout{
+	{
+		venue:       VenueRaydiumAMMv4,
+		program:     RaydiumAMMv4ProgramID,
+		dataSize:    752,
+		mintOffsets: [2]int{400, 432},
+		decode:      decodeRaydiumAMMv4,
+	},
+	{
+		venue:       VenueRaydiumCPMM,
+		program:     RaydiumCPMMProgramID,
+		kind:        anchorDiscriminator("PoolState"),
+		mintOffsets: [2]int{168, 200},
+		decode:      decodeRaydiumCPMM,
+	},
+	{
+		venue:       VenueOrcaWhirlpool,
+		program:     OrcaWhirlpoolProgramID,
+		kind:        anchorDiscriminator("Whirlpool"),
+		mintOffsets: [2]int{101, 181},
+		decode:      decodeOrcaWhirlpool,
+	},
+	{
+		venue:       VenueMeteoraDLMM,
+		program:     MeteoraDLMMProgramID,
+		kind:        anchorDiscriminator("LbPair"),
+		mintOffsets: [2]int{88, 120},
+		decode:      decodeMeteoraDLMM,
+	},
+}
+
+// pubkeyAt returns the base58 public key at offset.
+func pubkeyAt(data []byte, offset int) string {
+	return base58Encode(data[offset : offset+32])
+}
+
+// checkSize returns an error if data is shorter than size.
+func checkSize(venue Venue, data []byte, size int) error {
+	if len(data) < size {
+		return fmt.Errorf("%s pool: %d bytes, expected at least %d", venue, len(data), size)
+	}
+	return nil
+}
+
+// decodeRaydiumAMMv4 decodes a Raydium AMM v4 AmmInfo account.
+//
+// Layout: 16 u64 parameters (including decimals at 32 and 40), fees, then
+// OutPutData starting at 192 with pending PnL; vaults at 336/368 and mints
+// at 400/432.
+func decodeRaydiumAMMv4(address string, data []byte) (*poolState, error) {
+	if err := checkSize(VenueRaydiumAMMv4, data, 752); err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	return &poolState{
+		venue:    VenueRaydiumAMMv4,
+		address:  address,
+		mints:    [2]string{pubkeyAt(data, 400), pubkeyAt(data, 432)},
+		vaults:   [2]string{pubkeyAt(data, 336), pubkeyAt(data, 368)},
+		held:     [2]uint64{le.Uint64(data[192:]), le.Uint64(data[200:])},
+		decimals: [2]int{int(le.Uint64(data[32:])), int(le.Uint64(data[40:]))},
+		price:    constantProductPrice,
+	}, nil
+}
+
+// decodeRaydiumCPMM decodes a Raydium CPMM PoolState account.
+//
+// Layout after the discriminator: config, creator, vaults at 72/104,
+// LP mint, mints at 168/200, programs, observation, bumps and decimals at
+// 331/332, LP supply, then protocol and fund fees at 341..373.
+func decodeRaydiumCPMM(address string, data []byte) (*poolState, error) {
+	if err := checkSize(VenueRaydiumCPMM, data, 373); err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	return &poolState{
+		venue:   VenueRaydiumCPMM,
+		address: address,
+		mints:   [2]string{pubkeyAt(data, 168), pubkeyAt(data, 200)},
+		vaults:  [2]string{pubkeyAt(data, 72), pubkeyAt(data, 104)},
+		held: [2]uint64{
+			le.Uint64(data[341:]) + le.Uint64(data[357:]),
+			le.Uint64(data[349:]) + le.Uint64(data[365:]),
+		},
+		decimals: [2]int{int(data[331]), int(data[332])},
+		price:    constantProductPrice,
+	}, nil
+}
+
+// decodeOrcaWhirlpool decodes an Orca Whirlpool account.
+//
+// Layout after the discriminator: config, bump, tick spacing, fee rates,
+// liquidity, sqrt price (Q64.64) at 65, current tick, protocol fees owed
+// at 85/93, then mint A/vault A at 101/133 and mint B/vault B at 181/213.
+func decodeOrcaWhirlpool(address string, data []byte) (*poolState, error) {
+	if err := checkSize(VenueOrcaWhirlpool, data, 245); err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	sqrtPrice := new(big.Int).SetBytes(reverse(data[65:81])) // u128 LE
+
+	return &poolState{
+		venue:    VenueOrcaWhirlpool,
+		address:  address,
+		mints:    [2]string{pubkeyAt(data, 101), pubkeyAt(data, 181)},
+		vaults:   [2]string{pubkeyAt(data, 133), pubkeyAt(data, 213)},
+		held:     [2]uint64{le.Uint64(data[85:]), le.Uint64(data[93:])},
+		decimals: [2]int{-1, -1},
+		price: func(p *poolState) *big.Float {
+			// price = (sqrtPrice / 2^64)^2, scaled from base units
+			ratio := new(big.Float).SetPrec(256).SetInt(sqrtPrice)
+			ratio.SetMantExp(ratio, -64)
+			ratio.Mul(ratio, ratio)
+			return scaleDecimals(ratio, p.decimals[0]-p.decimals[1])
+		},
+	}, nil
+}
+
+// decodeMeteoraDLMM decodes a Meteora DLMM LbPair account.
+//
+// Layout after the discriminator: static and variable parameters, then
+// the active bin id at 76, bin step at 80, mints X/Y at 88/120, reserves
+// X/Y at 152/184 and protocol fees at 216/224.
+func decodeMeteoraDLMM(address string, data []byte) (*poolState, error) {
+	if err := checkSize(VenueMeteoraDLMM, data, 232); err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	activeID := int32(le.Uint32(data[76:]))
+	binStep := le.Uint16(data[80:])
+
+	return &poolState{
+		venue:    VenueMeteoraDLMM,
+		address:  address,
+		mints:    [2]string{pubkeyAt(data, 88), pubkeyAt(data, 120)},
+		vaults:   [2]string{pubkeyAt(data, 152), pubkeyAt(data, 184)},
+		held:     [2]uint64{le.Uint64(data[216:]), le.Uint64(data[224:])},
+		decimals: [2]int{-1, -1},
+		price: func(p *poolState) *big.Float {
+			// price = (1 + binStep/10000)^activeID, scaled from base units
+			base := new(big.Float).SetPrec(256).SetInt64(10000 + int64(binStep))
+			base.Quo(base, new(big.Float).SetPrec(256).SetInt64(10000))
+			return scaleDecimals(powFloat(base, activeID), p.decimals[0]-p.decimals[1])
+		},
+	}, nil
+}
+
+// pumpFunCurve derives the bonding curve address of a pump.fun token.
+func pumpFunCurve(tokenMint string) (string, error) {
+	mint, err := base58Decode(tokenMint)
+	if err != nil {
+		return "", err
+	}
+	program, err := base58Decode(PumpFunProgramID)
+	if err != nil {
+		return "", err
+	}
+
+	address, _, err := findProgramAddress([][]byte{[]byte("bonding-curve"), mint}, program)
+	if err != nil {
+		return "", err
+	}
+	return base58Encode(address), nil
+}
+
+// decodePumpFunCurve decodes a pump.fun BondingCurve account.
+//
+// Layout after the discriminator: virtual token and SOL reserves, real
+// token and SOL reserves, total supply and the completion flag at 48.
+// Completed curves have migrated to an AMM and hold no liquidity.
+func decodePumpFunCurve(address, tokenMint string, data []byte) (*poolState, error) {
+	if err := checkSize(VenuePumpFun, data, 49); err != nil {
+		return nil, err
+	}
+	if data[48] != 0 {
+		return nil, nil
+	}
+
+	le := binary.LittleEndian
+	virtualToken := le.Uint64(data[8:])
+	virtualSOL := le.Uint64(data[16:])
+
+	return &poolState{
+		venue:   VenuePumpFun,
+		address: address,
+		mints:   [2]string{tokenMint, WrappedSOLMint},
+		reserves: [2]*big.Int{
+			new(big.Int).SetUint64(le.Uint64(data[24:])),
+			new(big.Int).SetUint64(le.Uint64(data[32:])),
+		},
+		decimals:  [2]int{pumpFunTokenDecimals, solDecimals},
+		quoteOnly: true,
+		price: func(p *poolState) *big.Float {
+			if virtualToken == 0 {
+				return new(big.Float)
+			}
+			price := new(big.Float).SetPrec(256).SetUint64(virtualSOL)
+			price.Quo(price, new(big.Float).SetPrec(256).SetUint64(virtualToken))
+			return scaleDecimals(price, p.decimals[0]-p.decimals[1])
+		},
+	}, nil
+}
+
+// constantProductPrice prices side 0 by the ratio of reserves.
+func constantProductPrice(p *poolState) *big.Float {
+	if p.reserves[0].Sign() == 0 {
+		return new(big.Float)
+	}
+	price := new(big.Float).SetPrec(256).SetInt(p.reserves[1])
+	price.Quo(price, new(big.Float).SetPrec(256).SetInt(p.reserves[0]))
+	return scaleDecimals(price, p.decimals[0]-p.decimals[1])
+}
+
+// scaleDecimals converts a base-unit price to a whole-token price.
+func scaleDecimals(price *big.Float, exp int) *big.Float {
+	return price.Mul(price, powFloat(new(big.Float).SetPrec(256).SetInt64(10), int32(exp)))
+}
+
+// powFloat raises base to an integer power by repeated squaring.
+func powFloat(base *big.Float, exp int32) *big.Float {
+	result := new(big.Float).SetPrec(256).SetInt64(1)
+	b := new(big.Float).SetPrec(256).Set(base)
+
+	n := int64(exp)
+	if n < 0 {
+		n = -n
+	}
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, b)
+		}
+		b.Mul(b, b)
+	}
+
+	if exp < 0 {
+		return result.Quo(new(big.Float).SetPrec(256).SetInt64(1), result)
+	}
+	return result
+}
+
+// reverse returns a reversed copy of b.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+// floatToDecimal converts a big.Float to a decimal with pricePrecision places.
+func floatToDecimal(f *big.Float) decimal.Decimal {
+	d, err := decimal.NewFromString(f.Text('e', 40))
+	if err != nil {
+		return decimal.Zero
+	}
+	return d.Round(pricePrecision)
+}
+
+// ============================================================================
+// Pool Liquidity Provider
+// ============================================================================
+
+// PoolLiquidityProvider computes liquidity from AMM pool reserves read
+// over Solana RPC, independently of indexer-reported figures.
+//
+// It discovers the token's pools on Raydium AMM v4, Raydium CPMM, Orca
+// Whirlpool and Meteora DLMM (getProgramAccounts filtered by mint) and its
+// pump.fun bonding curve (by PDA), reads vault balances, and values each
+// pool in USD using a quote asset price. Pools paired against assets the
+// QuotePriceSource cannot price are skipped.
+//
+// Pool liquidity is the USD value of both reserves, with the token priced
+// at the pool's own spot price. Bonding curves count only their SOL
+// reserve, since the tokens they hold are not exit liquidity.
+//
+// getProgramAccounts is expensive; use an RPC endpoint that allows it and
+// a DataCache to avoid repeated discovery.
+type PoolLiquidityProvider struct {
+	rpc     *rpcClient
+	prices  QuotePriceSource
+	layouts []poolLayout
+	pumpFun bool
+}
+
+// PoolLiquidityConfig holds configuration for PoolLiquidityProvider.
+type PoolLiquidityConfig struct {
+	// RPC holds connection settings (Endpoint is required).
+	RPC SolanaRPCConfig
+
+	// QuotePrices prices quote assets (required).
+	QuotePrices QuotePriceSource
+
+	// Venues restricts discovery to the listed venues.
+	// Defaults to all supported venues if empty.
+	Venues []Venue
+}
+
+// NewPoolLiquidityProvider creates a new pool reserve liquidity provider.
+func NewPoolLiquidityProvider(cfg PoolLiquidityConfig) (*PoolLiquidityProvider, error) {
+	if cfg.QuotePrices == nil {
+		return nil, fmt.Errorf("quote prices are required")
+	}
+
+	rpc, err := newRPCClient(cfg.RPC)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PoolLiquidityProvider{rpc: rpc, prices: cfg.QuotePrices}
+
+	enabled := make(map[Venue]bool, len(cfg.Venues))
+	for _, venue := range cfg.Venues {
+		enabled[venue] = true
+	}
+	all := len(enabled) == 0
+
+	for _, layout := range poolLayouts {
+		if all || enabled[layout.venue] {
+			p.layouts = append(p.layouts, layout)
+		}
+	}
+	p.pumpFun = all || enabled[VenuePumpFun]
+
+	return p, nil
+}
+
+// GetMarketData returns liquidity aggregated over the token's pools.
+//
+// PriceUSD is the spot price in the deepest pool. A token without priced
+// pools has zero liquidity.
+func (p *PoolLiquidityProvider) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	pools, err := p.GetPools(ctx, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &MarketData{
+		Source:            PoolsSource,
+		AsOf:              time.Now(),
+		LiquidityReported: true,
+		Pools:             pools,
+	}
+	for _, pool := range pools {
+		data.LiquidityUSD = data.LiquidityUSD.Add(pool.LiquidityUSD)
+	}
+	if len(pools) > 0 {
+		data.PriceUSD = pools[0].PriceUSD
+	}
+
+	return data, nil
+}
+
+// GetPools discovers and values the token's pools, deepest first.
+func (p *PoolLiquidityProvider) GetPools(ctx context.Context, tokenMint string) ([]PoolLiquidity, error) {
+	states, err := p.discover(ctx, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+	if states, err = p.resolve(ctx, states); err != nil {
+		return nil, err
+	}
+
+	pools := make([]PoolLiquidity, 0, len(states))
+	for _, state := range states {
+		pool, ok, err := p.value(ctx, tokenMint, state)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			pools = append(pools, pool)
+		}
+	}
+
+	sort.SliceStable(pools, func(i, j int) bool {
+		return pools[i].LiquidityUSD.GreaterThan(pools[j].LiquidityUSD)
+	})
+
+	return pools, nil
+}
+
+// discover finds the token's pools on all enabled venues concurrently.
+func (p *PoolLiquidityProvider) discover(ctx context.Context, tokenMint string) ([]*poolState, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		states   []*poolState
+		firstErr error
+	)
+	collect := func(found []*poolState, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		states = append(states, found...)
+	}
+
+	for _, layout := range p.layouts {
+		for side := range layout.mintOffsets {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				collect(p.findPools(ctx, layout, layout.mintOffsets[side], tokenMint))
+			}()
+		}
+	}
+	if p.pumpFun {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			collect(p.findPumpFunCurve(ctx, tokenMint))
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Keep discovery order stable regardless of goroutine scheduling
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].venue != states[j].venue {
+			return states[i].venue < states[j].venue
+		}
+		return states[i].address < states[j].address
+	})
+
+	return states, nil
+}
+
+// findPools queries one venue for pools with tokenMint at offset.
+func (p *PoolLiquidityProvider) findPools(
+	ctx context.Context,
+	layout poolLayout,
+	offset int,
+	tokenMint string,
+) ([]*poolState, error) {
+	filters := []rpcFilter{memcmpFilter(offset, tokenMint)}
+	if layout.dataSize > 0 {
+		filters = append(filters, rpcFilter{DataSize: layout.dataSize})
+	}
+	if layout.kind != nil {
+		filters = append(filters, memcmpFilter(0, base58Encode(layout.kind)))
+	}
+
+	accounts, err := p.rpc.getProgramAccounts(ctx, layout.program, filters)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s pools: %w", layout.venue, err)
+	}
+
+	states := make([]*poolState, 0, len(accounts))
+	for _, account := range accounts {
+		data, err := account.Account.bytes()
+		if err != nil {
+			return nil, fmt.Errorf("%s pool %s: %w", layout.venue, account.Pubkey, err)
+		}
+		state, err := layout.decode(account.Pubkey, data)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// findPumpFunCurve reads the token's pump.fun bonding curve, if any.
+func (p *PoolLiquidityProvider) findPumpFunCurve(ctx context.Context, tokenMint string) ([]*poolState, error) {
+	address, err := pumpFunCurve(tokenMint)
+	if err != nil {
+		return nil, fmt.Errorf("derive pump.fun curve: %w", err)
+	}
+
+	account, err := p.rpc.getAccountInfo(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("discover pumpfun curve: %w", err)
+	}
+	if account == nil || account.Owner != PumpFunProgramID {
+		return nil, nil
+	}
+
+	data, err := account.bytes()
+	if err != nil {
+		return nil, fmt.Errorf("pumpfun curve %s: %w", address, err)
+	}
+
+	state, err := decodePumpFunCurve(address, tokenMint, data)
+	if err != nil || state == nil {
+		return nil, err
+	}
+	return []*poolState{state}, nil
+}
+
+// resolve reads vault balances and unknown mint decimals in one batch and
+// returns the resolved pools. Pools with a vault or mint account that no
+// longer exists (e.g., a closed pool) are left out, so they do not hide the
+// liquidity of the others.
+func (p *PoolLiquidityProvider) resolve(ctx context.Context, states []*poolState) ([]*poolState, error) {
+	var addresses []string
+	index := make(map[string]int)
+	want := func(address string) {
+		if _, ok := index[address]; !ok {
+			index[address] = len(addresses)
+			addresses = append(addresses, address)
+		}
+	}
+
+	for _, state := range states {
+		for side := range state.mints {
+			if state.vaults[side] != "" {
+				want(state.vaults[side])
+			}
+			if state.decimals[side] < 0 {
+				want(state.mints[side])
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		return states, nil
+	}
+
+	accounts, err := p.rpc.getMultipleAccounts(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("read pool vaults: %w", err)
+	}
+
+	exists := func(address string) bool {
+		return accounts[index[address]] != nil
+	}
+	lookup := func(address string) ([]byte, error) {
+		return accounts[index[address]].bytes()
+	}
+
+	resolved := states[:0]
+	for _, state := range states {
+		if !state.accountsExist(exists) {
+			continue
+		}
+		for side := range state.mints {
+			if state.vaults[side] != "" {
+				data, err := lookup(state.vaults[side])
+				if err != nil || len(data) < tokenAccountAmountEnd {
+					return nil, fmt.Errorf("%s pool %s: invalid vault %s", state.venue, state.address, state.vaults[side])
+				}
+				amount := new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[tokenAccountAmountOffset:tokenAccountAmountEnd]))
+				amount.Sub(amount, new(big.Int).SetUint64(state.held[side]))
+				if amount.Sign() < 0 {
+					amount.SetInt64(0)
+				}
+				state.reserves[side] = amount
+			}
+			if state.decimals[side] < 0 {
+				data, err := lookup(state.mints[side])
+				if err != nil || len(data) < mintSize {
+					return nil, fmt.Errorf("%s pool %s: invalid mint %s", state.venue, state.address, state.mints[side])
+				}
+				state.decimals[side] = int(data[44])
+			}
+		}
+		resolved = append(resolved, state)
+	}
+
+	return resolved, nil
+}
+
+// accountsExist reports whether the vault and unresolved mint accounts the
+// pool needs all exist.
+func (s *poolState) accountsExist(exists func(address string) bool) bool {
+	for side := range s.mints {
+		if s.vaults[side] != "" && !exists(s.vaults[side]) {
+			return false
+		}
+		if s.decimals[side] < 0 && !exists(s.mints[side]) {
+			return false
+		}
+	}
+	return true
+}
+
+// value prices a resolved pool. It reports false for pools not paired
+// against the token or whose quote asset cannot be priced.
+func (p *PoolLiquidityProvider) value(
+	ctx context.Context,
+	tokenMint string,
+	state *poolState,
+) (PoolLiquidity, bool, error) {
+	tokenSide := 0
+	if state.mints[1] == tokenMint {
+		tokenSide = 1
+	} else if state.mints[0] != tokenMint {
+		return PoolLiquidity{}, false, nil
+	}
+	quoteSide := 1 - tokenSide
+
+	quotePrice, err := p.prices.QuotePriceUSD(ctx, state.mints[quoteSide])
+	if errors.Is(err, ErrNoQuotePrice) {
+		return PoolLiquidity{}, false, nil
+	}
+	if err != nil {
+		return PoolLiquidity{}, false, fmt.Errorf("quote price: %w", err)
+	}
+
+	// Spot price of the token in quote units
+	spot := state.price(state)
+	if tokenSide == 1 && spot.Sign() != 0 {
+		spot = new(big.Float).SetPrec(256).Quo(new(big.Float).SetInt64(1), spot)
+	}
+
+	pool := PoolLiquidity{
+		Venue:        state.venue,
+		Address:      state.address,
+		QuoteMint:    state.mints[quoteSide],
+		TokenReserve: decimal.NewFromBigInt(state.reserves[tokenSide], -int32(state.decimals[tokenSide])),
+		QuoteReserve: decimal.NewFromBigInt(state.reserves[quoteSide], -int32(state.decimals[quoteSide])),
+		PriceUSD:     floatToDecimal(spot).Mul(quotePrice),
+	}
+
+	pool.LiquidityUSD = pool.QuoteReserve.Mul(quotePrice)
+	if !state.quoteOnly {
+		pool.LiquidityUSD = pool.LiquidityUSD.Add(pool.TokenReserve.Mul(pool.PriceUSD))
+	}
+	pool.LiquidityUSD = pool.LiquidityUSD.Round(2)
+
+	return pool, true, nil
+}
@@ -0,0 +1,257 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// Pools of the recorded token poolTokenMint in testdata/rpc.
+const (
+	poolTokenMint    = "BHAYupF1LHTKqsjp6anmRPo2ViRwNi5A9FP3qtA1UBJh"
+	ammPoolAddr      = "8FTrHoovWv2BqPYEgHjVpjFobqXaJkKAFt5TUTpAb7mF"
+	cpmmPoolAddr     = "EYNJnQGef9eHRyaK4NSdgDaoe1Dt9H26esyd5rV9NTJF"
+	whirlpoolAddr    = "BXoDvPUcvzpdttfrMRPCunCgfCq2YVYp66vhGbX4mc5B"
+	dlmmPoolAddr     = "8mDbidhY9omC5qbPiX75pFs5eZqv9q2kB1FncpgVDaDD"
+	pumpFunCurveAddr = "GSS2ALpccaMZiQKvo4y8BCPPgkMJfyRGY7gsW4gB3DSC"
+)
+
+func newTestPoolProvider(t *testing.T, server *rpcStandIn, venues ...Venue) *PoolLiquidityProvider {
+	t.Helper()
+
+	provider, err := NewPoolLiquidityProvider(PoolLiquidityConfig{
+		RPC:         SolanaRPCConfig{Endpoint: server.URL},
+		QuotePrices: NewStaticQuotePrices(decimal.NewFromInt(150)),
+		Venues:      venues,
+	})
+	if err != nil {
+		t.Fatalf("NewPoolLiquidityProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestFindProgramAddress(t *testing.T) {
+	program, _ := base58Decode(PumpFunProgramID)
+	seed, _ := base58Decode("zLVmBiiRYkqs2tHYj7A5boNP2iXormWxuxmHbMcoH1W")
+
+	// Bumps 255 to 253 land on the curve for this seed.
+	address, bump, err := findProgramAddress([][]byte{[]byte("bonding-curve"), seed}, program)
+	if err != nil {
+		t.Fatalf("findProgramAddress() error = %v", err)
+	}
+	if got := base58Encode(address); got != "YnjV7HjExB19RcLjsoVx8mE3J12Zj7p1HKsar8AmXn5" || bump != 252 {
+		t.Errorf("unexpected address %s (bump %d)", got, bump)
+	}
+	if isOnCurve(address) {
+		t.Error("expected program address off the curve")
+	}
+
+	curve, err := pumpFunCurve(poolTokenMint)
+	if err != nil || curve != pumpFunCurveAddr {
+		t.Errorf("pumpFunCurve() = %s, %v", curve, err)
+	}
+}
+
+func TestIsOnCurve_BasePoint(t *testing.T) {
+	// The ed25519 base point has y = 4/5 and a positive x.
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	y := new(big.Int).ModInverse(big.NewInt(5), p)
+	y.Mul(y, big.NewInt(4)).Mod(y, p)
+
+	if !isOnCurve(reverse(y.FillBytes(make([]byte, 32)))) {
+		t.Error("expected base point on the curve")
+	}
+}
+
+func TestPoolLiquidityProvider_GetPools(t *testing.T) {
+	provider := newTestPoolProvider(t, newRPCStandIn(t))
+
+	pools, err := provider.GetPools(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("GetPools() error = %v", err)
+	}
+
+	// The CPMM pool quoted in an unpriced mint is skipped.
+	want := []struct {
+		venue     Venue
+		address   string
+		quote     string
+		liquidity string
+	}{
+		{VenueRaydiumAMMv4, ammPoolAddr, WrappedSOLMint, "30000"},
+		{VenueOrcaWhirlpool, whirlpoolAddr, WrappedSOLMint, "10500"},
+		{VenueRaydiumCPMM, cpmmPoolAddr, USDCMint, "10000"},
+		{VenuePumpFun, pumpFunCurveAddr, WrappedSOLMint, "3000"},
+		{VenueMeteoraDLMM, dlmmPoolAddr, USDCMint, "2499.93"},
+	}
+	if len(pools) != len(want) {
+		t.Fatalf("expected %d pools, got %+v", len(want), pools)
+	}
+
+	for i, w := range want {
+		got := pools[i]
+		if got.Venue != w.venue || got.Address != w.address || got.QuoteMint != w.quote {
+			t.Errorf("pool %d: expected %s %s/%s, got %s %s/%s",
+				i, w.venue, w.address, w.quote, got.Venue, got.Address, got.QuoteMint)
+		}
+		// Whirlpool prices carry sqrt-price rounding.
+		diff := got.LiquidityUSD.Sub(decimal.RequireFromString(w.liquidity)).Abs()
+		if diff.GreaterThan(decimal.RequireFromString("0.01")) {
+			t.Errorf("%s: expected liquidity %s, got %s", w.venue, w.liquidity, got.LiquidityUSD)
+		}
+	}
+}
+
+func TestPoolLiquidityProvider_ClosedPool(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider := newTestPoolProvider(t, server)
+
+	states, err := provider.discover(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("discover() error = %v", err)
+	}
+	for _, state := range states {
+		if state.address == whirlpoolAddr {
+			server.closeAccount(state.vaults[1])
+		}
+	}
+
+	data, err := provider.GetMarketData(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	// The Whirlpool is skipped; the other pools are still summed.
+	if len(data.Pools) != 4 {
+		t.Fatalf("expected 4 pools, got %+v", data.Pools)
+	}
+	for _, pool := range data.Pools {
+		if pool.Address == whirlpoolAddr {
+			t.Error("expected the closed Whirlpool to be skipped")
+		}
+	}
+	diff := data.LiquidityUSD.Sub(decimal.RequireFromString("45499.93")).Abs()
+	if diff.GreaterThan(decimal.RequireFromString("0.01")) {
+		t.Errorf("expected liquidity 45499.93, got %s", data.LiquidityUSD)
+	}
+}
+
+func TestPoolLiquidityProvider_Reserves(t *testing.T) {
+	provider := newTestPoolProvider(t, newRPCStandIn(t))
+
+	pools, err := provider.GetPools(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("GetPools() error = %v", err)
+	}
+
+	byVenue := make(map[Venue]PoolLiquidity, len(pools))
+	for _, pool := range pools {
+		byVenue[pool.Venue] = pool
+	}
+
+	tests := []struct {
+		name  string
+		got   decimal.Decimal
+		want  string
+		exact bool
+	}{
+		// Pending PnL and accrued fees are not tradable reserves.
+		{"amm token reserve", byVenue[VenueRaydiumAMMv4].TokenReserve, "1000000", true},
+		{"amm price", byVenue[VenueRaydiumAMMv4].PriceUSD, "0.015", true},
+		{"cpmm quote reserve", byVenue[VenueRaydiumCPMM].QuoteReserve, "5000", true},
+		{"cpmm token reserve", byVenue[VenueRaydiumCPMM].TokenReserve, "333333.333333", true},
+		{"whirlpool quote reserve", byVenue[VenueOrcaWhirlpool].QuoteReserve, "50", true},
+		{"whirlpool price", byVenue[VenueOrcaWhirlpool].PriceUSD, "0.015", false},
+		{"dlmm price", byVenue[VenueMeteoraDLMM].PriceUSD, "0.0149992887747", false},
+		{"pumpfun quote reserve", byVenue[VenuePumpFun].QuoteReserve, "20", true},
+		{"pumpfun price", byVenue[VenuePumpFun].PriceUSD, "0.000009375", true},
+	}
+
+	for _, tt := range tests {
+		want := decimal.RequireFromString(tt.want)
+		if tt.exact && !tt.got.Equal(want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, want, tt.got)
+		}
+		if !tt.exact && tt.got.Sub(want).Abs().GreaterThan(decimal.RequireFromString("0.000000001")) {
+			t.Errorf("%s: expected ~%s, got %s", tt.name, want, tt.got)
+		}
+	}
+}
+
+func TestPoolLiquidityProvider_GetMarketData(t *testing.T) {
+	provider := newTestPoolProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetMarketData(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	if data.Source != PoolsSource || data.AsOf.IsZero() {
+		t.Errorf("unexpected provenance: %q at %v", data.Source, data.AsOf)
+	}
+	if len(data.Pools) != 5 {
+		t.Fatalf("expected 5 pools, got %d", len(data.Pools))
+	}
+
+	var sum decimal.Decimal
+	for _, pool := range data.Pools {
+		sum = sum.Add(pool.LiquidityUSD)
+	}
+	if !data.LiquidityUSD.Equal(sum) {
+		t.Errorf("expected liquidity %s summed over pools, got %s", sum, data.LiquidityUSD)
+	}
+	if !data.PriceUSD.Equal(data.Pools[0].PriceUSD) {
+		t.Errorf("expected price from deepest pool, got %s", data.PriceUSD)
+	}
+
+	byVenue := data.LiquidityByVenue()
+	if len(byVenue) != 5 || !byVenue[VenuePumpFun].Equal(decimal.NewFromInt(3000)) {
+		t.Errorf("unexpected venue breakdown %v", byVenue)
+	}
+}
+
+func TestPoolLiquidityProvider_Venues(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider := newTestPoolProvider(t, server, VenuePumpFun)
+
+	pools, err := provider.GetPools(context.Background(), poolTokenMint)
+	if err != nil {
+		t.Fatalf("GetPools() error = %v", err)
+	}
+
+	if len(pools) != 1 || pools[0].Venue != VenuePumpFun {
+		t.Errorf("expected only the bonding curve, got %+v", pools)
+	}
+	if got := server.callCount("getProgramAccounts"); got != 0 {
+		t.Errorf("expected no pool discovery for disabled venues, got %d calls", got)
+	}
+}
+
+func TestPoolLiquidityProvider_NoPools(t *testing.T) {
+	provider := newTestPoolProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetMarketData(context.Background(), revokedMint)
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+	if len(data.Pools) != 0 || !data.LiquidityUSD.IsZero() {
+		t.Errorf("expected no liquidity, got %s over %d pools", data.LiquidityUSD, len(data.Pools))
+	}
+}
+
+func TestPoolLiquidityProvider_Validation(t *testing.T) {
+	if _, err := NewPoolLiquidityProvider(PoolLiquidityConfig{RPC: SolanaRPCConfig{Endpoint: "http://localhost"}}); err == nil {
+		t.Error("expected error for missing quote prices")
+	}
+	if _, err := NewPoolLiquidityProvider(PoolLiquidityConfig{QuotePrices: StaticQuotePrices{}}); err == nil {
+		t.Error("expected error for missing endpoint")
+	}
+
+	_, err := StaticQuotePrices{}.QuotePriceUSD(context.Background(), WrappedSOLMint)
+	if !errors.Is(err, ErrNoQuotePrice) {
+		t.Errorf("expected ErrNoQuotePrice, got %v", err)
+	}
+}
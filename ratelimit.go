@@ -0,0 +1,425 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBudgetExhausted is returned (wrapped) when low-priority work is refused
+// because the daily credit budget has been spent. It is classified as
+// transient: the budget resets at midnight UTC.
+var ErrBudgetExhausted = errors.New("daily credit budget exhausted")
+
+// ============================================================================
+// Priorities
+// ============================================================================
+
+// Priority orders provider calls waiting for the rate limiter.
+type Priority int
+
+// Priority constants.
+const (
+	// PriorityNormal is used when the context carries no priority.
+	PriorityNormal Priority = iota
+
+	// PriorityHigh is for latency-sensitive work such as pre-trade
+	// screenings. It is served before other waiting calls and is never
+	// refused for budget.
+	PriorityHigh
+
+	// PriorityLow is for background work such as periodic rescreens.
+	// It waits behind other calls and is refused once the daily budget
+	// is spent.
+	PriorityLow
+)
+
+// String returns the priority name.
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// lane returns the queue index of the priority; lower is served first.
+func (p Priority) lane() int {
+	switch p {
+	case PriorityHigh:
+		return 0
+	case PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// numLanes is the number of priority queues.
+const numLanes = 3
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose provider calls are rate limited at
+// the given priority.
+//
+// Example:
+//
+//	ctx = tokenguard.WithPriority(ctx, tokenguard.PriorityHigh)
+//	result, err := screener.Screen(ctx, mint, tokenguard.ScreeningLevelStrict)
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority set by WithPriority, or
+// PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// ============================================================================
+// Rate Limiter
+// ============================================================================
+
+// RateLimiter is a token bucket shared by provider calls, with priority
+// lanes and a daily credit budget.
+//
+// Each call consumes as many tokens as it costs credits, so the bucket
+// rate is expressed in credits per second. Waiting calls are served
+// strictly by priority, first come first served within a lane.
+//
+// Features:
+//   - Thread-safe; share one limiter between screeners using the same API key
+//   - Burst capacity
+//   - Priority lanes (high, normal, low)
+//   - Daily credit budget (UTC days) that refuses low-priority work once spent
+//   - Waiting respects context cancellation
+type RateLimiter struct {
+	rate   float64 // Credits per second
+	burst  float64
+	budget int64 // Zero means unlimited
+
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time
+	waiters [numLanes][]*rateWaiter
+	timer   *time.Timer // Pending dispatch, or nil
+	day     time.Time   // Start of the current budget day (UTC)
+	spent   int64       // Credits granted during the current day
+	refused atomic.Int64
+}
+
+// rateWaiter is a call queued for tokens.
+type rateWaiter struct {
+	cost    float64
+	ready   chan struct{}
+	granted bool
+}
+
+// RateLimiterConfig holds configuration for RateLimiter.
+type RateLimiterConfig struct {
+	// Rate is the sustained number of credits per second (required).
+	Rate float64
+
+	// Burst is the number of credits that may be spent at once after an
+	// idle period.
+	// Defaults to Rate (rounded up) if zero.
+	Burst int
+
+	// DailyBudget is the number of credits per UTC day after which
+	// low-priority calls are refused with ErrBudgetExhausted. Higher
+	// priorities are still served (and counted) past the budget.
+	// Set to 0 for no budget.
+	DailyBudget int64
+}
+
+// NewRateLimiter creates a new rate limiter. The bucket starts full.
+func NewRateLimiter(cfg RateLimiterConfig) (*RateLimiter, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", cfg.Rate)
+	}
+	if cfg.Burst == 0 {
+		cfg.Burst = int(math.Ceil(cfg.Rate))
+	}
+	if cfg.Burst < 0 || cfg.DailyBudget < 0 {
+		return nil, fmt.Errorf("burst and daily budget must not be negative")
+	}
+
+	now := time.Now()
+	return &RateLimiter{
+		rate:   cfg.Rate,
+		burst:  float64(cfg.Burst),
+		budget: cfg.DailyBudget,
+		tokens: float64(cfg.Burst),
+		last:   now,
+		day:    startOfDayUTC(now),
+	}, nil
+}
+
+// RateLimiterStats is a snapshot of a rate limiter for monitoring.
+type RateLimiterStats struct {
+	CreditsToday int64          `json:"creditsToday"` // Credits granted since midnight UTC
+	DailyBudget  int64          `json:"dailyBudget"`  // Zero means unlimited
+	Waiting      map[string]int `json:"waiting"`      // Queued calls by priority name
+	Refused      int64          `json:"refused"`      // Calls refused for budget since creation
+}
+
+// Stats returns a snapshot of the limiter's usage.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rolloverLocked(time.Now())
+	return RateLimiterStats{
+		CreditsToday: l.spent,
+		DailyBudget:  l.budget,
+		Waiting: map[string]int{
+			PriorityHigh.String():   len(l.waiters[PriorityHigh.lane()]),
+			PriorityNormal.String(): len(l.waiters[PriorityNormal.lane()]),
+			PriorityLow.String():    len(l.waiters[PriorityLow.lane()]),
+		},
+		Refused: l.refused.Load(),
+	}
+}
+
+// Wait blocks until cost credits are available to a call at the priority
+// carried by ctx (see WithPriority), then consumes them.
+//
+// It returns an error wrapping ErrBudgetExhausted for low-priority calls
+// once the daily budget is spent, or ctx's error if ctx is done first.
+// Costs above the burst size wait for a full bucket.
+func (l *RateLimiter) Wait(ctx context.Context, cost int) error {
+	if cost <= 0 {
+		return nil
+	}
+	priority := PriorityFromContext(ctx)
+	tokens := math.Min(float64(cost), l.burst)
+
+	l.mu.Lock()
+	now := time.Now()
+	l.rolloverLocked(now)
+
+	if priority == PriorityLow && l.budget > 0 && l.spent >= l.budget {
+		spent := l.spent
+		l.mu.Unlock()
+		l.refused.Add(1)
+		return fmt.Errorf("%d of %d credits spent: %w", spent, l.budget, ErrBudgetExhausted)
+	}
+
+	l.refillLocked(now)
+	if !l.queuedAheadLocked(priority) && l.tokens >= tokens {
+		l.tokens -= tokens
+		l.spent += int64(cost)
+		l.mu.Unlock()
+		return nil
+	}
+
+	w := &rateWaiter{cost: tokens, ready: make(chan struct{})}
+	lane := priority.lane()
+	l.waiters[lane] = append(l.waiters[lane], w)
+	l.scheduleLocked(now)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		l.mu.Lock()
+		l.spent += int64(cost)
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if w.granted {
+			// Granted concurrently; hand the tokens back
+			l.tokens = math.Min(l.burst, l.tokens+w.cost)
+		} else {
+			l.removeLocked(lane, w)
+		}
+		l.dispatchLocked(time.Now())
+		return ctx.Err()
+	}
+}
+
+// queuedAheadLocked reports whether calls of the same or higher priority
+// are waiting. Must be called with l.mu held.
+func (l *RateLimiter) queuedAheadLocked(priority Priority) bool {
+	for lane := 0; lane <= priority.lane(); lane++ {
+		if len(l.waiters[lane]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// refillLocked adds tokens for the time elapsed since the last refill.
+// Must be called with l.mu held.
+func (l *RateLimiter) refillLocked(now time.Time) {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+}
+
+// rolloverLocked resets the budget at the start of a new UTC day.
+// Must be called with l.mu held.
+func (l *RateLimiter) rolloverLocked(now time.Time) {
+	if today := startOfDayUTC(now); today.After(l.day) {
+		l.day = today
+		l.spent = 0
+	}
+}
+
+// headLocked returns the next waiter to serve and its lane, or nil.
+// Must be called with l.mu held.
+func (l *RateLimiter) headLocked() (*rateWaiter, int) {
+	for lane := range l.waiters {
+		if len(l.waiters[lane]) > 0 {
+			return l.waiters[lane][0], lane
+		}
+	}
+	return nil, 0
+}
+
+// dispatchLocked grants tokens to waiters in priority order while enough
+// are available, then schedules the next dispatch.
+// Must be called with l.mu held.
+func (l *RateLimiter) dispatchLocked(now time.Time) {
+	l.refillLocked(now)
+	for {
+		w, lane := l.headLocked()
+		if w == nil || l.tokens < w.cost {
+			break
+		}
+		l.tokens -= w.cost
+		l.waiters[lane] = l.waiters[lane][1:]
+		w.granted = true
+		close(w.ready)
+	}
+	l.scheduleLocked(now)
+}
+
+// scheduleLocked arms a timer for when the head waiter can be served.
+// Must be called with l.mu held.
+func (l *RateLimiter) scheduleLocked(now time.Time) {
+	w, _ := l.headLocked()
+	if w == nil {
+		return
+	}
+
+	l.refillLocked(now)
+	delay := time.Duration((w.cost - l.tokens) / l.rate * float64(time.Second))
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.timer = time.AfterFunc(max(delay, 0), func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.timer = nil
+		l.dispatchLocked(time.Now())
+	})
+}
+
+// removeLocked removes an abandoned waiter from its lane.
+// Must be called with l.mu held.
+func (l *RateLimiter) removeLocked(lane int, w *rateWaiter) {
+	for i, queued := range l.waiters[lane] {
+		if queued == w {
+			l.waiters[lane] = append(l.waiters[lane][:i], l.waiters[lane][i+1:]...)
+			return
+		}
+	}
+}
+
+// startOfDayUTC returns midnight UTC of t's day.
+func startOfDayUTC(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// ============================================================================
+// Credit Accounting
+// ============================================================================
+
+// CallCosts are the API credits charged per provider call.
+//
+// When a provider makes several upstream requests per call (e.g., a
+// composite provider in consensus mode), its cost should include them all.
+type CallCosts struct {
+	// Security is the cost of one security data call.
+	// Defaults to 1 if zero.
+	Security int
+
+	// Market is the cost of one market data call.
+	// Defaults to 1 if zero.
+	Market int
+
+	// Holders is the cost of one holder data call.
+	// Defaults to 1 if zero.
+	Holders int
+
+	// Creation is the cost of one creation time lookup.
+	// Defaults to 1 if zero.
+	Creation int
+
+	// Funding is the cost of one wallet funding lookup.
+	// Defaults to 1 if zero.
+	Funding int
+
+	// Launch is the cost of one launch buys lookup.
+	// Defaults to 1 if zero.
+	Launch int
+}
+
+// withDefaults returns the costs with zero fields set to 1.
+func (c CallCosts) withDefaults() CallCosts {
+	if c.Security == 0 {
+		c.Security = 1
+	}
+	if c.Market == 0 {
+		c.Market = 1
+	}
+	if c.Holders == 0 {
+		c.Holders = 1
+	}
+	if c.Creation == 0 {
+		c.Creation = 1
+	}
+	if c.Funding == 0 {
+		c.Funding = 1
+	}
+	if c.Launch == 0 {
+		c.Launch = 1
+	}
+	return c
+}
+
+type usageKey struct{}
+
+// withUsage returns a context accumulating credits spent into usage.
+func withUsage(ctx context.Context, usage *atomic.Int64) context.Context {
+	return context.WithValue(ctx, usageKey{}, usage)
+}
+
+// spend waits for the rate limiter (if configured) and records cost against
+// the screening in ctx.
+func (s *Screener) spend(ctx context.Context, cost int) error {
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx, cost); err != nil {
+			return err
+		}
+	}
+	if usage, ok := ctx.Value(usageKey{}).(*atomic.Int64); ok {
+		usage.Add(int64(cost))
+	}
+	return nil
+}
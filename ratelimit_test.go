@@ -0,0 +1,173 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func newTestRateLimiter(t *testing.T, cfg RateLimiterConfig) *RateLimiter {
+	t.Helper()
+
+	limiter, err := NewRateLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewRateLimiter() error = %v", err)
+	}
+	return limiter
+}
+
+func TestNewRateLimiter_Validation(t *testing.T) {
+	if _, err := NewRateLimiter(RateLimiterConfig{}); err == nil {
+		t.Error("expected error for missing rate")
+	}
+	if _, err := NewRateLimiter(RateLimiterConfig{Rate: 1, DailyBudget: -1}); err == nil {
+		t.Error("expected error for negative budget")
+	}
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := newTestRateLimiter(t, RateLimiterConfig{Rate: 50, Burst: 2})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, 1); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+
+	// The burst is immediate; the third credit takes 1/50s to refill.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected third call to wait for refill, took %v", elapsed)
+	}
+	if got := limiter.Stats().CreditsToday; got != 3 {
+		t.Errorf("expected 3 credits spent, got %d", got)
+	}
+}
+
+func TestRateLimiter_PriorityLanes(t *testing.T) {
+	limiter := newTestRateLimiter(t, RateLimiterConfig{Rate: 20, Burst: 1})
+	if err := limiter.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	var (
+		mu    sync.Mutex
+		order []Priority
+		wg    sync.WaitGroup
+	)
+	enqueue := func(priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(WithPriority(context.Background(), priority), 1); err != nil {
+				t.Errorf("Wait() error = %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+		}()
+
+		waitFor(t, time.Second, func() bool { return limiter.Stats().Waiting[priority.String()] == 1 })
+	}
+
+	// The background call queued first is overtaken by the pre-trade call.
+	enqueue(PriorityLow)
+	enqueue(PriorityNormal)
+	enqueue(PriorityHigh)
+	wg.Wait()
+
+	want := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected service order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestRateLimiter_DailyBudget(t *testing.T) {
+	limiter := newTestRateLimiter(t, RateLimiterConfig{Rate: 1000, DailyBudget: 3})
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx, 3); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	err := limiter.Wait(WithPriority(ctx, PriorityLow), 1)
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("expected low priority refused, got %v", err)
+	}
+	if err := limiter.Wait(WithPriority(ctx, PriorityHigh), 1); err != nil {
+		t.Errorf("expected high priority served past the budget, got %v", err)
+	}
+
+	stats := limiter.Stats()
+	if stats.CreditsToday != 4 || stats.Refused != 1 || stats.DailyBudget != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiter_CancelWhileWaiting(t *testing.T) {
+	limiter := newTestRateLimiter(t, RateLimiterConfig{Rate: 0.1, Burst: 1})
+	if err := limiter.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	stats := limiter.Stats()
+	if stats.Waiting["normal"] != 0 || stats.CreditsToday != 1 {
+		t.Errorf("expected abandoned call to be dropped uncharged, got %+v", stats)
+	}
+}
+
+func TestScreener_Screen_CreditAccounting(t *testing.T) {
+	limiter := newTestRateLimiter(t, RateLimiterConfig{Rate: 1000, Burst: 1000, DailyBudget: 100})
+
+	screener, err := New(Config{
+		SecurityProvider: &mockSecurityProvider{security: &birdeye.TokenSecurity{}},
+		OverviewProvider: &mockOverviewProvider{overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(100000)}},
+		DataCache:        NewDataCache(DataCacheConfig{}),
+		NegativeCache:    NewNegativeCache(NegativeCacheConfig{}),
+		RateLimiter:      limiter,
+		CallCosts:        CallCosts{Security: 50, Market: 30},
+		Logger:           zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := screener.Screen(ctx, "test-mint", ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if result.CreditsUsed != 80 {
+		t.Errorf("expected 80 credits used, got %d", result.CreditsUsed)
+	}
+
+	// 80 of 100 credits spent: background work can still start, then is
+	// refused mid-screening once the budget runs out.
+	_, err = screener.Screen(WithPriority(ctx, PriorityLow), "other-mint", ScreeningLevelNormal)
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected budget exhaustion, got %v", err)
+	}
+	if _, cached := screener.negative.Get("other-mint"); cached {
+		t.Error("expected budget refusals not to be negatively cached")
+	}
+
+	if _, err := screener.Screen(WithPriority(ctx, PriorityHigh), "other-mint", ScreeningLevelNormal); err != nil {
+		t.Errorf("expected pre-trade screening to proceed, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package tokenguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultRedisKeyPrefix is the default prefix for cache keys in Redis.
+const DefaultRedisKeyPrefix = "tokenguard:"
+
+// DefaultRedisPoolSize is the default maximum number of Redis connections.
+const DefaultRedisPoolSize = 10
+
+// DefaultRedisTimeout is the default per-command timeout for Redis calls
+// whose context has no deadline.
+const DefaultRedisTimeout = 3 * time.Second
+
+// resultCodecVersion is the current serialization version of cached results.
+// Bump it when TokenScreeningResult changes incompatibly; entries written
+// with other versions are treated as cache misses.
+const resultCodecVersion = 1
+
+// ============================================================================
+// Redis Cache Implementation
+// ============================================================================
+
+// cachedResultEnvelope is the versioned wire format of a cached result.
+//
+// Decimals are encoded as JSON strings, so values round-trip exactly.
+type cachedResultEnvelope struct {
+	Version int                   `json:"v"`
+	Result  *TokenScreeningResult `json:"result"`
+}
+
+// encodeResult serializes a result in the versioned wire format.
+func encodeResult(result *TokenScreeningResult) ([]byte, error) {
+	data, err := json.Marshal(cachedResultEnvelope{
+		Version: resultCodecVersion,
+		Result:  result,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode screening result: %w", err)
+	}
+	return data, nil
+}
+
+// decodeResult deserializes a result written by encodeResult.
+func decodeResult(data []byte) (*TokenScreeningResult, error) {
+	var envelope cachedResultEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("decode screening result: %w", err)
+	}
+	if envelope.Version != resultCodecVersion {
+		return nil, fmt.Errorf("unsupported screening result version: %d", envelope.Version)
+	}
+	if envelope.Result == nil {
+		return nil, fmt.Errorf("decode screening result: missing result")
+	}
+	return envelope.Result, nil
+}
+
+// RedisCache is a shared Cache backed by a Redis-compatible server.
+//
+// This implementation is suitable for multi-instance deployments where
+// several replicas should share screening results. It speaks the RESP
+// protocol directly, so it works with Redis, Valkey, KeyDB, Dragonfly and
+// other compatible servers without additional dependencies.
+//
+// Features:
+//   - Thread-safe
+//   - Server-side expiration (SET ... PX)
+//   - Key prefixing for shared servers
+//   - Versioned serialization with exact decimal round-trips
+//   - Bounded connection pool
+type RedisCache struct {
+	pool   *redisPool
+	prefix string
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// RedisCacheConfig holds configuration for RedisCache.
+type RedisCacheConfig struct {
+	// Addr is the server address in host:port form (required).
+	Addr string
+
+	// Password is sent with AUTH when non-empty.
+	Password string
+
+	// DB selects the logical database when non-zero.
+	DB int
+
+	// KeyPrefix is prepended to every key.
+	// Defaults to "tokenguard:" if empty.
+	KeyPrefix string
+
+	// TTL is the time-to-live for cached entries, enforced by the server.
+	// Defaults to 5 minutes if zero.
+	TTL time.Duration
+
+	// PoolSize is the maximum number of open connections.
+	// Defaults to 10 if zero.
+	PoolSize int
+
+	// DialTimeout bounds establishing a new connection.
+	// Defaults to 5 seconds if zero.
+	DialTimeout time.Duration
+
+	// Timeout bounds each command (write and reply) when the context has
+	// no deadline.
+	// Defaults to 3 seconds if zero.
+	Timeout time.Duration
+
+	// Logger reports failed reads, which surface as cache misses (optional).
+	Logger *zap.Logger
+}
+
+// NewRedisCache creates a new Redis-backed cache.
+//
+// Connections are established lazily, so this does not contact the server.
+func NewRedisCache(cfg RedisCacheConfig) (*RedisCache, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("redis address is required")
+	}
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = DefaultRedisKeyPrefix
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultCacheTTL
+	}
+	if cfg.PoolSize == 0 {
+		cfg.PoolSize = DefaultRedisPoolSize
+	}
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultRedisTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	return &RedisCache{
+		pool: newRedisPool(redisPoolConfig{
+			addr:        cfg.Addr,
+			password:    cfg.Password,
+			db:          cfg.DB,
+			size:        cfg.PoolSize,
+			dialTimeout: cfg.DialTimeout,
+			timeout:     cfg.Timeout,
+		}),
+		prefix: cfg.KeyPrefix,
+		ttl:    cfg.TTL,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// Get retrieves a cached screening result.
+//
+// Returns the result and true if found and not expired.
+// Returns nil and false if not found, expired, or on any server error.
+func (c *RedisCache) Get(ctx context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	reply, err := c.do(ctx, "GET", c.key(tokenMint))
+	if err != nil {
+		c.logger.Warn("redis cache get failed",
+			zap.String("token_mint", tokenMint),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+	if reply.null {
+		return nil, false
+	}
+
+	result, err := decodeResult([]byte(reply.str))
+	if err != nil {
+		c.logger.Warn("discarding undecodable cached result",
+			zap.String("token_mint", tokenMint),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+
+	return result, true
+}
+
+// GetWithTTL retrieves a cached screening result and its remaining time-to-live.
+//
+// The value and its TTL are fetched in a single pipelined round trip.
+func (c *RedisCache) GetWithTTL(ctx context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool) {
+	key := c.key(tokenMint)
+
+	conn, err := c.pool.get(ctx)
+	if err != nil {
+		c.logger.Warn("redis cache get failed",
+			zap.String("token_mint", tokenMint),
+			zap.Error(err),
+		)
+		return nil, 0, false
+	}
+
+	replies, err := conn.pipeline([][]string{{"GET", key}, {"PTTL", key}})
+	c.pool.put(conn, err)
+	if err == nil {
+		err = errors.Join(replyError(replies[0]), replyError(replies[1]))
+	}
+	if err != nil {
+		c.logger.Warn("redis cache get failed",
+			zap.String("token_mint", tokenMint),
+			zap.Error(err),
+		)
+		return nil, 0, false
+	}
+
+	// PTTL returns -2 for a missing key and -1 for a key without expiry.
+	value, pttl := replies[0], replies[1].num
+	if value.null || pttl == -2 {
+		return nil, 0, false
+	}
+
+	result, err := decodeResult([]byte(value.str))
+	if err != nil {
+		c.logger.Warn("discarding undecodable cached result",
+			zap.String("token_mint", tokenMint),
+			zap.Error(err),
+		)
+		return nil, 0, false
+	}
+
+	remaining := c.ttl
+	if pttl >= 0 {
+		remaining = time.Duration(pttl) * time.Millisecond
+	}
+
+	return result, remaining, true
+}
+
+// TTL returns the time-to-live applied by Set.
+func (c *RedisCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Set stores a screening result in the cache.
+func (c *RedisCache) Set(ctx context.Context, result *TokenScreeningResult) error {
+	data, err := encodeResult(result)
+	if err != nil {
+		return err
+	}
+
+	ttlMillis := strconv.FormatInt(c.ttl.Milliseconds(), 10)
+	if _, err := c.do(ctx, "SET", c.key(result.TokenMint), string(data), "PX", ttlMillis); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an entry from the cache.
+func (c *RedisCache) Delete(ctx context.Context, tokenMint string) error {
+	if _, err := c.do(ctx, "DEL", c.key(tokenMint)); err != nil {
+		return fmt.Errorf("redis del: %w", err)
+	}
+	return nil
+}
+
+// Ping checks connectivity to the server.
+func (c *RedisCache) Ping(ctx context.Context) error {
+	if _, err := c.do(ctx, "PING"); err != nil {
+		return fmt.Errorf("redis ping: %w", err)
+	}
+	return nil
+}
+
+// Close closes all pooled connections.
+func (c *RedisCache) Close() error {
+	return c.pool.close()
+}
+
+// key returns the prefixed server key for a token.
+func (c *RedisCache) key(tokenMint string) string {
+	return c.prefix + tokenMint
+}
+
+// do runs a single command on a pooled connection.
+func (c *RedisCache) do(ctx context.Context, args ...string) (respValue, error) {
+	conn, err := c.pool.get(ctx)
+	if err != nil {
+		return respValue{}, err
+	}
+
+	reply, err := conn.do(args...)
+	c.pool.put(conn, err)
+	return reply, err
+}
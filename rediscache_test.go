@@ -0,0 +1,244 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestRedisCache(t *testing.T, server *fakeRedis, cfg RedisCacheConfig) *RedisCache {
+	t.Helper()
+
+	cfg.Addr = server.addr()
+	cache, err := NewRedisCache(cfg)
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache
+}
+
+func TestNewRedisCache_RequiresAddr(t *testing.T) {
+	if _, err := NewRedisCache(RedisCacheConfig{}); err == nil {
+		t.Error("expected error for missing address")
+	}
+}
+
+func TestRedisCache_SetGet(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{TTL: time.Minute})
+
+	result := &TokenScreeningResult{
+		TokenMint: "test-mint",
+		Passed:    false,
+		Score:     55,
+		Level:     ScreeningLevelNormal,
+		Details: ScreeningDetails{
+			LiquidityUSD:    decimal.RequireFromString("12345.678901234567890123"),
+			LPLockedPct:     decimal.NewFromFloat(82.5),
+			Top10HoldersPct: decimal.RequireFromString("0.000000000000000001"),
+		},
+		FailureReasons: []string{"low_liquidity:$12345.68"},
+		ScreenedAt:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	if err := cache.Set(ctx, result); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := cache.Get(ctx, "test-mint")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if got.Score != 55 || got.Passed {
+		t.Errorf("result mismatch: got %+v", got)
+	}
+	if !got.Details.LiquidityUSD.Equal(result.Details.LiquidityUSD) {
+		t.Errorf("LiquidityUSD not preserved exactly: got %s", got.Details.LiquidityUSD)
+	}
+	if !got.Details.Top10HoldersPct.Equal(result.Details.Top10HoldersPct) {
+		t.Errorf("Top10HoldersPct not preserved exactly: got %s", got.Details.Top10HoldersPct)
+	}
+	if !got.ScreenedAt.Equal(result.ScreenedAt) {
+		t.Errorf("ScreenedAt mismatch: got %v", got.ScreenedAt)
+	}
+
+	if _, ok := cache.Get(ctx, "nonexistent"); ok {
+		t.Error("expected cache miss")
+	}
+}
+
+func TestRedisCache_KeyPrefix(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{KeyPrefix: "bot-a:"})
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	keys := server.keys()
+	if len(keys) != 1 || keys[0] != "bot-a:test-mint" {
+		t.Errorf("expected prefixed key, got %v", keys)
+	}
+
+	// A cache with another prefix does not see the entry.
+	other := newTestRedisCache(t, server, RedisCacheConfig{KeyPrefix: "bot-b:"})
+	if _, ok := other.Get(ctx, "test-mint"); ok {
+		t.Error("expected miss for a different key prefix")
+	}
+}
+
+func TestRedisCache_ServerSideTTL(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{TTL: 20 * time.Millisecond})
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, ok := cache.Get(ctx, "test-mint"); !ok {
+		t.Fatal("expected cache hit immediately after set")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := cache.Get(ctx, "test-mint"); ok {
+		t.Error("expected cache miss after server-side expiration")
+	}
+}
+
+func TestRedisCache_Delete(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{})
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := cache.Delete(ctx, "test-mint"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := cache.Get(ctx, "test-mint"); ok {
+		t.Error("expected cache miss after delete")
+	}
+}
+
+func TestRedisCache_Auth(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedisWithPassword(t, "secret")
+
+	bad := newTestRedisCache(t, server, RedisCacheConfig{Password: "wrong"})
+	if err := bad.Ping(ctx); err == nil {
+		t.Error("expected error with wrong password")
+	}
+
+	good := newTestRedisCache(t, server, RedisCacheConfig{Password: "secret"})
+	if err := good.Ping(ctx); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+}
+
+func TestRedisCache_VersionMismatchIsMiss(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{})
+
+	if _, err := cache.do(ctx, "SET", cache.key("test-mint"), `{"v":99,"result":{"tokenMint":"test-mint"}}`); err != nil {
+		t.Fatalf("raw SET error = %v", err)
+	}
+
+	if _, ok := cache.Get(ctx, "test-mint"); ok {
+		t.Error("expected unknown serialization version to be a cache miss")
+	}
+}
+
+func TestRedisCache_PoolReusesConnections(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{PoolSize: 3})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				mint := fmt.Sprintf("mint-%d", g)
+				_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: mint, Score: i})
+				cache.Get(ctx, mint)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if n := server.connCount(); n > 3 {
+		t.Errorf("expected at most 3 connections, got %d", n)
+	}
+}
+
+func TestRedisCache_ContextCancelled(t *testing.T) {
+	server := newFakeRedis(t)
+	cache := newTestRedisCache(t, server, RedisCacheConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err == nil {
+		t.Error("expected error with cancelled context")
+	}
+}
+
+func TestRedisCache_HungServerTimesOut(t *testing.T) {
+	// Accepts connections but never replies
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	t.Cleanup(func() {
+		_ = listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+
+	cache, err := NewRedisCache(RedisCacheConfig{Addr: listener.Addr().String(), Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	t.Cleanup(func() { _ = cache.Close() })
+
+	start := time.Now()
+	if err := cache.Set(context.Background(), &TokenScreeningResult{TokenMint: "test-mint"}); err == nil {
+		t.Error("expected timeout error from hung server")
+	}
+	if _, ok := cache.Get(context.Background(), "test-mint"); ok {
+		t.Error("expected miss from hung server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected calls to time out, took %v", elapsed)
+	}
+}
@@ -0,0 +1,438 @@
+package tokenguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+)
+
+// Recorded method names.
+const (
+	MethodGetTokenSecurity = "GetTokenSecurity"
+	MethodGetTokenOverview = "GetTokenOverview"
+	MethodGetSecurityData  = "GetSecurityData"
+	MethodGetMarketData    = "GetMarketData"
+	MethodGetHolderData    = "GetHolderData"
+)
+
+// ErrNotRecorded is returned (wrapped) by ReplayProvider for calls that are
+// not in the recording, or that exceed the recorded calls.
+var ErrNotRecorded = errors.New("call not recorded")
+
+// ============================================================================
+// Recording
+// ============================================================================
+
+// RecordedCall is one provider call captured by a Recorder, stored as one
+// JSON line.
+type RecordedCall struct {
+	// Seq numbers calls in the order they started.
+	Seq int64 `json:"seq"`
+
+	// Time is when the call started.
+	Time time.Time `json:"time"`
+
+	// LatencyMS is how long the call took, in milliseconds.
+	LatencyMS int64 `json:"latencyMs"`
+
+	// Method is the provider method (e.g., MethodGetTokenSecurity).
+	Method string `json:"method"`
+
+	// Address is the token mint requested.
+	Address string `json:"address"`
+
+	// Response is the JSON-encoded response; absent if the call failed.
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Error describes the failure; absent if the call succeeded.
+	Error *RecordedError `json:"error,omitempty"`
+}
+
+// RecordedError is a provider error captured by a Recorder.
+type RecordedError struct {
+	// Message is the error text.
+	Message string `json:"message"`
+
+	// Class is the ClassifyError class at recording time (e.g., "not_found").
+	Class string `json:"class"`
+
+	// StatusCode is the HTTP status of Birdeye API errors (zero otherwise).
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Path is the endpoint of Birdeye API errors.
+	Path string `json:"path,omitempty"`
+}
+
+// Recorder captures provider calls to a JSONL stream.
+//
+// Wrap each provider with the matching Wrap method; the wrappers call
+// through unchanged and append one RecordedCall per call. A failure to
+// write the recording never fails the call; it is reported by Err.
+//
+// Example:
+//
+//	f, _ := os.Create("traffic.jsonl")
+//	rec := tokenguard.NewRecorder(f)
+//	screener, _ := tokenguard.New(tokenguard.Config{
+//	    SecurityProvider: rec.WrapSecurity(client),
+//	    OverviewProvider: rec.WrapOverview(client),
+//	    Logger:           logger,
+//	})
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	seq int64
+	err error
+}
+
+// NewRecorder creates a recorder writing JSON lines to w.
+// Writes are serialized; w need not be safe for concurrent use.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error encountered writing the recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// WrapSecurity records calls to a Birdeye security provider.
+func (r *Recorder) WrapSecurity(provider TokenSecurityProvider) TokenSecurityProvider {
+	return &recordingSecurity{r: r, provider: provider}
+}
+
+// WrapOverview records calls to a Birdeye overview provider.
+func (r *Recorder) WrapOverview(provider TokenOverviewProvider) TokenOverviewProvider {
+	return &recordingOverview{r: r, provider: provider}
+}
+
+// WrapSecurityData records calls to a security data provider.
+func (r *Recorder) WrapSecurityData(provider SecurityDataProvider) SecurityDataProvider {
+	return &recordingSecurityData{r: r, provider: provider}
+}
+
+// WrapMarketData records calls to a market data provider.
+func (r *Recorder) WrapMarketData(provider MarketDataProvider) MarketDataProvider {
+	return &recordingMarketData{r: r, provider: provider}
+}
+
+// WrapHolderData records calls to a holder data provider.
+func (r *Recorder) WrapHolderData(provider HolderDataProvider) HolderDataProvider {
+	return &recordingHolderData{r: r, provider: provider}
+}
+
+// recordCall runs call and appends its outcome to the recording.
+func recordCall[T any](r *Recorder, method, address string, call func() (*T, error)) (*T, error) {
+	r.mu.Lock()
+	r.seq++
+	seq := r.seq
+	r.mu.Unlock()
+
+	start := time.Now()
+	resp, err := call()
+
+	entry := RecordedCall{
+		Seq:       seq,
+		Time:      start,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Method:    method,
+		Address:   address,
+	}
+	if err != nil {
+		entry.Error = newRecordedError(err)
+	} else if data, marshalErr := json.Marshal(resp); marshalErr != nil {
+		r.fail(fmt.Errorf("encode %s response: %w", method, marshalErr))
+		return resp, err
+	} else {
+		entry.Response = data
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if writeErr := r.enc.Encode(entry); writeErr != nil && r.err == nil {
+		r.err = fmt.Errorf("write recording: %w", writeErr)
+	}
+
+	return resp, err
+}
+
+// fail remembers the first recording error.
+func (r *Recorder) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// newRecordedError captures err with its classification.
+func newRecordedError(err error) *RecordedError {
+	recorded := &RecordedError{Message: err.Error(), Class: ClassifyError(err).String()}
+	if apiErr, ok := birdeye.IsAPIError(err); ok {
+		recorded.StatusCode = apiErr.StatusCode
+		recorded.Path = apiErr.Path
+	}
+	return recorded
+}
+
+type recordingSecurity struct {
+	r        *Recorder
+	provider TokenSecurityProvider
+}
+
+func (p *recordingSecurity) GetTokenSecurity(ctx context.Context, address string) (*birdeye.TokenSecurity, error) {
+	return recordCall(p.r, MethodGetTokenSecurity, address, func() (*birdeye.TokenSecurity, error) {
+		return p.provider.GetTokenSecurity(ctx, address)
+	})
+}
+
+type recordingOverview struct {
+	r        *Recorder
+	provider TokenOverviewProvider
+}
+
+func (p *recordingOverview) GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error) {
+	return recordCall(p.r, MethodGetTokenOverview, address, func() (*birdeye.TokenOverview, error) {
+		return p.provider.GetTokenOverview(ctx, address)
+	})
+}
+
+type recordingSecurityData struct {
+	r        *Recorder
+	provider SecurityDataProvider
+}
+
+func (p *recordingSecurityData) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	return recordCall(p.r, MethodGetSecurityData, tokenMint, func() (*SecurityData, error) {
+		return p.provider.GetSecurityData(ctx, tokenMint)
+	})
+}
+
+type recordingMarketData struct {
+	r        *Recorder
+	provider MarketDataProvider
+}
+
+func (p *recordingMarketData) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	return recordCall(p.r, MethodGetMarketData, tokenMint, func() (*MarketData, error) {
+		return p.provider.GetMarketData(ctx, tokenMint)
+	})
+}
+
+type recordingHolderData struct {
+	r        *Recorder
+	provider HolderDataProvider
+}
+
+func (p *recordingHolderData) GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error) {
+	return recordCall(p.r, MethodGetHolderData, tokenMint, func() (*HolderData, error) {
+		return p.provider.GetHolderData(ctx, tokenMint)
+	})
+}
+
+// ============================================================================
+// Replay
+// ============================================================================
+
+// ReplayedError is a recorded provider error served by ReplayProvider.
+//
+// ClassifyError returns its recorded class, so negative caching behaves as
+// it did when recorded. Birdeye API errors unwrap to a reconstructed
+// *birdeye.APIError.
+type ReplayedError struct {
+	Recorded RecordedError
+	err      error
+}
+
+// Error implements the error interface.
+func (e *ReplayedError) Error() string {
+	return e.Recorded.Message
+}
+
+// Unwrap returns the reconstructed API error, if any.
+func (e *ReplayedError) Unwrap() error {
+	return e.err
+}
+
+// class returns the recorded classification.
+func (e *ReplayedError) class() ErrorClass {
+	switch e.Recorded.Class {
+	case ErrorClassNotFound.String():
+		return ErrorClassNotFound
+	case ErrorClassPersistent.String():
+		return ErrorClassPersistent
+	default:
+		return ErrorClassTransient
+	}
+}
+
+// ReplayProvider serves recorded calls back deterministically.
+//
+// It implements every provider interface (TokenSecurityProvider,
+// TokenOverviewProvider, SecurityDataProvider, MarketDataProvider and
+// HolderDataProvider). Calls for a method and address are answered with
+// the recorded responses for that pair, in recorded order.
+//
+// Features:
+//   - Thread-safe
+//   - Errors replay with their recorded classification
+//   - Optional replay of recorded latency
+//   - Lists recorded tokens, to re-run screening rules over captured traffic
+type ReplayProvider struct {
+	mu          sync.Mutex
+	calls       map[replayKey][]RecordedCall
+	served      map[replayKey]int
+	tokens      []string
+	repeatLast  bool
+	withLatency bool
+}
+
+// replayKey identifies recorded calls to replay in order.
+type replayKey struct {
+	method  string
+	address string
+}
+
+// ReplayConfig holds configuration for ReplayProvider.
+type ReplayConfig struct {
+	// RepeatLast answers calls beyond the recording with the last recorded
+	// call for the same method and address. If false, they fail with
+	// ErrNotRecorded, which catches screenings making extra calls.
+	RepeatLast bool
+
+	// SimulateLatency delays each response by its recorded latency.
+	SimulateLatency bool
+}
+
+// NewReplayProvider loads a recording written by Recorder.
+func NewReplayProvider(r io.Reader, cfg ReplayConfig) (*ReplayProvider, error) {
+	p := &ReplayProvider{
+		calls:       make(map[replayKey][]RecordedCall),
+		served:      make(map[replayKey]int),
+		repeatLast:  cfg.RepeatLast,
+		withLatency: cfg.SimulateLatency,
+	}
+
+	seen := make(map[string]bool)
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var call RecordedCall
+		if err := dec.Decode(&call); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read recording entry %d: %w", line, err)
+		}
+
+		key := replayKey{call.Method, call.Address}
+		p.calls[key] = append(p.calls[key], call)
+		if !seen[call.Address] {
+			seen[call.Address] = true
+			p.tokens = append(p.tokens, call.Address)
+		}
+	}
+
+	return p, nil
+}
+
+// Tokens returns the recorded token addresses in order of first appearance.
+func (p *ReplayProvider) Tokens() []string {
+	return append([]string(nil), p.tokens...)
+}
+
+// Reset rewinds replay to the start of the recording.
+func (p *ReplayProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.served = make(map[replayKey]int)
+}
+
+// next returns the next recorded call for method and address.
+func (p *ReplayProvider) next(method, address string) (RecordedCall, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := replayKey{method, address}
+	calls := p.calls[key]
+	n := p.served[key]
+
+	switch {
+	case n < len(calls):
+		p.served[key] = n + 1
+		return calls[n], nil
+	case p.repeatLast && len(calls) > 0:
+		return calls[len(calls)-1], nil
+	default:
+		return RecordedCall{}, fmt.Errorf("%s %s (call %d of %d recorded): %w",
+			method, address, n+1, len(calls), ErrNotRecorded)
+	}
+}
+
+// replayCall serves the next recorded call for method and address.
+func replayCall[T any](ctx context.Context, p *ReplayProvider, method, address string) (*T, error) {
+	call, err := p.next(method, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.withLatency && call.LatencyMS > 0 {
+		timer := time.NewTimer(time.Duration(call.LatencyMS) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if call.Error != nil {
+		replayed := &ReplayedError{Recorded: *call.Error}
+		if call.Error.StatusCode != 0 {
+			replayed.err = &birdeye.APIError{
+				StatusCode: call.Error.StatusCode,
+				Message:    call.Error.Message,
+				Path:       call.Error.Path,
+			}
+		}
+		return nil, replayed
+	}
+
+	// Decode afresh so callers never share responses
+	resp := new(T)
+	if err := json.Unmarshal(call.Response, resp); err != nil {
+		return nil, fmt.Errorf("decode recorded %s response: %w", method, err)
+	}
+	return resp, nil
+}
+
+// GetTokenSecurity replays a recorded Birdeye security response.
+func (p *ReplayProvider) GetTokenSecurity(ctx context.Context, address string) (*birdeye.TokenSecurity, error) {
+	return replayCall[birdeye.TokenSecurity](ctx, p, MethodGetTokenSecurity, address)
+}
+
+// GetTokenOverview replays a recorded Birdeye overview response.
+func (p *ReplayProvider) GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error) {
+	return replayCall[birdeye.TokenOverview](ctx, p, MethodGetTokenOverview, address)
+}
+
+// GetSecurityData replays recorded security data.
+func (p *ReplayProvider) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	return replayCall[SecurityData](ctx, p, MethodGetSecurityData, tokenMint)
+}
+
+// GetMarketData replays recorded market data.
+func (p *ReplayProvider) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	return replayCall[MarketData](ctx, p, MethodGetMarketData, tokenMint)
+}
+
+// GetHolderData replays recorded holder data.
+func (p *ReplayProvider) GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error) {
+	return replayCall[HolderData](ctx, p, MethodGetHolderData, tokenMint)
+}
@@ -0,0 +1,165 @@
+package tokenguard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func newTestReplay(t *testing.T, recording string, cfg ReplayConfig) *ReplayProvider {
+	t.Helper()
+
+	replay, err := NewReplayProvider(strings.NewReader(recording), cfg)
+	if err != nil {
+		t.Fatalf("NewReplayProvider() error = %v", err)
+	}
+	return replay
+}
+
+func TestRecorder_ReplayReproducesScreening(t *testing.T) {
+	freeze := "FreezeAuthority111"
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+
+	screen := func(security TokenSecurityProvider, overview TokenOverviewProvider) *TokenScreeningResult {
+		t.Helper()
+		screener, err := New(Config{
+			SecurityProvider: security,
+			OverviewProvider: overview,
+			DataCache:        NewDataCache(DataCacheConfig{}),
+			Logger:           zap.NewNop(),
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal)
+		if err != nil {
+			t.Fatalf("Screen() error = %v", err)
+		}
+		return result
+	}
+
+	live := screen(
+		rec.WrapSecurity(&mockSecurityProvider{security: &birdeye.TokenSecurity{FreezeAuthority: &freeze}}),
+		rec.WrapOverview(&mockOverviewProvider{overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(2500)}}),
+	)
+	if err := rec.Err(); err != nil {
+		t.Fatalf("recording error = %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("expected 2 recorded calls, got %d:\n%s", lines, buf.String())
+	}
+
+	replay := newTestReplay(t, buf.String(), ReplayConfig{})
+	replayed := screen(replay, replay)
+
+	if replayed.Passed != live.Passed || replayed.Score != live.Score ||
+		!reflect.DeepEqual(replayed.FailureReasons, live.FailureReasons) {
+		t.Errorf("replayed result %+v differs from live result %+v", replayed, live)
+	}
+	if got := replay.Tokens(); len(got) != 1 || got[0] != "test-mint" {
+		t.Errorf("expected recorded tokens [test-mint], got %v", got)
+	}
+}
+
+func TestReplayProvider_ServesCallsInOrder(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	ctx := context.Background()
+
+	first := rec.WrapMarketData(&staticMarketData{data: &MarketData{Source: "first", LiquidityUSD: decimal.NewFromInt(1)}})
+	second := rec.WrapMarketData(&staticMarketData{data: &MarketData{Source: "second", LiquidityUSD: decimal.NewFromInt(2)}})
+	_, _ = first.GetMarketData(ctx, "test-mint")
+	_, _ = second.GetMarketData(ctx, "test-mint")
+
+	tests := []struct {
+		name    string
+		cfg     ReplayConfig
+		third   string
+		wantErr error
+	}{
+		{"strict", ReplayConfig{}, "", ErrNotRecorded},
+		{"repeat last", ReplayConfig{RepeatLast: true}, "second", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay := newTestReplay(t, buf.String(), tt.cfg)
+
+			for _, want := range []string{"first", "second"} {
+				data, err := replay.GetMarketData(ctx, "test-mint")
+				if err != nil {
+					t.Fatalf("GetMarketData() error = %v", err)
+				}
+				if data.Source != want {
+					t.Errorf("expected %s response, got %s", want, data.Source)
+				}
+			}
+
+			data, err := replay.GetMarketData(ctx, "test-mint")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && data.Source != tt.third {
+				t.Errorf("expected %s response, got %s", tt.third, data.Source)
+			}
+
+			replay.Reset()
+			if data, err := replay.GetMarketData(ctx, "test-mint"); err != nil || data.Source != "first" {
+				t.Errorf("expected first response after reset, got %v, %v", data, err)
+			}
+		})
+	}
+
+	replay := newTestReplay(t, buf.String(), ReplayConfig{})
+	if _, err := replay.GetMarketData(ctx, "other-mint"); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded for unrecorded token, got %v", err)
+	}
+}
+
+func TestReplayProvider_ErrorsKeepClassification(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	ctx := context.Background()
+
+	_, _ = rec.WrapSecurity(&mockSecurityProvider{err: errNotFound}).GetTokenSecurity(ctx, "unknown-mint")
+	_, _ = rec.WrapSecurityData(&staticSecurityData{err: ErrNotMint}).GetSecurityData(ctx, "wallet")
+	_, _ = rec.WrapOverview(&mockOverviewProvider{err: errServerError}).GetTokenOverview(ctx, "test-mint")
+
+	replay := newTestReplay(t, buf.String(), ReplayConfig{})
+
+	_, err := replay.GetTokenSecurity(ctx, "unknown-mint")
+	if ClassifyError(err) != ErrorClassNotFound {
+		t.Errorf("expected not found, got %v", ClassifyError(err))
+	}
+	if apiErr, ok := birdeye.IsAPIError(err); !ok || apiErr.StatusCode != 404 {
+		t.Errorf("expected reconstructed API error, got %v", err)
+	}
+
+	_, err = replay.GetSecurityData(ctx, "wallet")
+	if ClassifyError(err) != ErrorClassPersistent {
+		t.Errorf("expected persistent, got %v", ClassifyError(err))
+	}
+	if err.Error() != ErrNotMint.Error() {
+		t.Errorf("expected recorded message, got %q", err.Error())
+	}
+
+	_, err = replay.GetTokenOverview(ctx, "test-mint")
+	if ClassifyError(err) != ErrorClassTransient {
+		t.Errorf("expected transient, got %v", ClassifyError(err))
+	}
+}
+
+func TestNewReplayProvider_InvalidRecording(t *testing.T) {
+	_, err := NewReplayProvider(strings.NewReader("{\"seq\":1}\nnot json\n"), ReplayConfig{})
+	if err == nil || !strings.Contains(err.Error(), "entry 2") {
+		t.Errorf("expected error locating entry 2, got %v", err)
+	}
+}
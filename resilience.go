@@ -0,0 +1,661 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"go.uber.org/zap"
+)
+
+// Retry and circuit breaker defaults.
+const (
+	DefaultRetryAttempts         = 3
+	DefaultRetryBaseDelay        = 100 * time.Millisecond
+	DefaultRetryMaxDelay         = 2 * time.Second
+	DefaultBreakerFailures       = 5
+	DefaultBreakerOpenTimeout    = 30 * time.Second
+	DefaultBreakerHalfOpenProbes = 1
+)
+
+// ErrCircuitOpen is returned (wrapped) when a call is rejected because the
+// provider's circuit breaker is open. It is classified as transient.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// ============================================================================
+// Retry
+// ============================================================================
+
+// RetryPolicy configures retries with jittered exponential backoff.
+//
+// The delay before retry n (1-based) is drawn uniformly from
+// [0, min(MaxDelay, BaseDelay*2^(n-1))] ("full jitter"), which spreads
+// retries of concurrent callers instead of synchronizing them.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first.
+	// Set to 1 to disable retries.
+	// Defaults to 3 if zero.
+	MaxAttempts int
+
+	// BaseDelay is the backoff ceiling before the first retry.
+	// Defaults to 100ms if zero.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff ceiling.
+	// Defaults to 2 seconds if zero.
+	MaxDelay time.Duration
+
+	// Retryable decides which errors are retried.
+	// Defaults to errors classified as transient by ClassifyError.
+	Retryable func(err error) bool
+}
+
+// withDefaults returns the policy with zero fields set to defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultRetryAttempts
+	}
+	if p.BaseDelay == 0 {
+		p.BaseDelay = DefaultRetryBaseDelay
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = DefaultRetryMaxDelay
+	}
+	if p.Retryable == nil {
+		p.Retryable = isTransient
+	}
+	return p
+}
+
+// backoff returns the jittered delay before retry n (1-based).
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceiling := p.MaxDelay
+	if shift := n - 1; shift < 32 && p.BaseDelay<<shift < p.MaxDelay {
+		ceiling = p.BaseDelay << shift
+	}
+	return rand.N(ceiling + 1)
+}
+
+// isTransient reports whether err is classified as transient. Rejections
+// by an open circuit breaker are not retried by the same caller.
+func isTransient(err error) bool {
+	return !errors.Is(err, ErrCircuitOpen) && ClassifyError(err) == ErrorClassTransient
+}
+
+// ============================================================================
+// Circuit Breaker
+// ============================================================================
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+// Circuit breaker states.
+const (
+	// BreakerClosed passes calls through, counting consecutive failures.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects calls with ErrCircuitOpen until the open timeout
+	// has passed.
+	BreakerOpen
+
+	// BreakerHalfOpen lets a limited number of probe calls through; a
+	// success closes the breaker and a failure opens it again.
+	BreakerHalfOpen
+)
+
+// String returns the state name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerStats is a snapshot of a circuit breaker for monitoring.
+type BreakerStats struct {
+	Name                string       `json:"name"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	OpenedAt            time.Time    `json:"openedAt,omitempty"` // Zero unless open or half-open
+	Successes           int64        `json:"successes"`          // Calls that succeeded
+	Failures            int64        `json:"failures"`           // Calls counted as failures
+	Rejected            int64        `json:"rejected"`           // Calls rejected while open
+}
+
+// CircuitBreaker fails fast after repeated provider failures.
+//
+// Features:
+//   - Thread-safe
+//   - Opens after a configurable number of consecutive failures
+//   - Half-opens after a timeout to probe recovery
+//   - Only provider health problems count as failures (by default,
+//     transient errors); not-found and caller cancellation do not
+//   - State and counters exposed for monitoring
+type CircuitBreaker struct {
+	name          string
+	threshold     int
+	openTimeout   time.Duration
+	probes        int
+	isFailure     func(err error) bool
+	onStateChange func(name string, from, to BreakerState)
+	logger        *zap.Logger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int // Consecutive failures while closed
+	inFlight int // Probes in flight while half-open
+	openedAt time.Time
+	stats    BreakerStats
+}
+
+// CircuitBreakerConfig holds configuration for CircuitBreaker.
+type CircuitBreakerConfig struct {
+	// Name identifies the breaker in stats and logs (e.g., "birdeye-security").
+	Name string
+
+	// FailureThreshold is the number of consecutive failures that opens
+	// the breaker.
+	// Defaults to 5 if zero.
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker stays open before half-opening.
+	// Defaults to 30 seconds if zero.
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is the number of concurrent probe calls allowed while
+	// half-open.
+	// Defaults to 1 if zero.
+	HalfOpenProbes int
+
+	// IsFailure decides which errors count as failures.
+	// Defaults to errors classified as transient by ClassifyError.
+	IsFailure func(err error) bool
+
+	// OnStateChange is called (synchronously, without locks held) after
+	// each state transition (optional).
+	OnStateChange func(name string, from, to BreakerState)
+
+	// Logger reports state transitions (optional).
+	Logger *zap.Logger
+}
+
+// NewCircuitBreaker creates a new circuit breaker.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold == 0 {
+		cfg.FailureThreshold = DefaultBreakerFailures
+	}
+	if cfg.OpenTimeout == 0 {
+		cfg.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	if cfg.HalfOpenProbes == 0 {
+		cfg.HalfOpenProbes = DefaultBreakerHalfOpenProbes
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = func(err error) bool { return ClassifyError(err) == ErrorClassTransient }
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	return &CircuitBreaker{
+		name:          cfg.Name,
+		threshold:     cfg.FailureThreshold,
+		openTimeout:   cfg.OpenTimeout,
+		probes:        cfg.HalfOpenProbes,
+		isFailure:     cfg.IsFailure,
+		onStateChange: cfg.OnStateChange,
+		logger:        cfg.Logger,
+	}
+}
+
+// State returns the current state.
+//
+// An open breaker whose timeout has passed reports BreakerHalfOpen, since
+// the next call will be let through as a probe.
+func (b *CircuitBreaker) State() BreakerState {
+	return b.Stats().State
+}
+
+// Stats returns a snapshot of the breaker's state and counters.
+func (b *CircuitBreaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.Name = b.name
+	stats.State = b.state
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.openTimeout {
+		stats.State = BreakerHalfOpen
+	}
+	stats.ConsecutiveFailures = b.failures
+	stats.OpenedAt = b.openedAt
+	return stats
+}
+
+// Do calls fn unless the breaker is open, and records the outcome.
+//
+// Rejected calls return an error wrapping ErrCircuitOpen without calling fn.
+// Errors caused by ctx being done are neither failures nor successes.
+func (b *CircuitBreaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	probe, err := b.acquire()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	b.release(ctx, probe, err)
+	return err
+}
+
+// acquire admits a call, reporting whether it is a half-open probe.
+func (b *CircuitBreaker) acquire() (bool, error) {
+	b.mu.Lock()
+
+	var transition func()
+	defer func() {
+		b.mu.Unlock()
+		if transition != nil {
+			transition()
+		}
+	}()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			b.stats.Rejected++
+			return false, fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		transition = b.setStateLocked(BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.inFlight >= b.probes {
+			b.stats.Rejected++
+			return false, fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.inFlight++
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// release records the outcome of an admitted call.
+func (b *CircuitBreaker) release(ctx context.Context, probe bool, err error) {
+	b.mu.Lock()
+
+	var transition func()
+	defer func() {
+		b.mu.Unlock()
+		if transition != nil {
+			transition()
+		}
+	}()
+
+	if probe {
+		b.inFlight--
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up; says nothing about the provider
+	case err != nil && b.isFailure(err):
+		b.stats.Failures++
+		b.failures++
+		if probe || (b.state == BreakerClosed && b.failures >= b.threshold) {
+			b.openedAt = time.Now()
+			transition = b.setStateLocked(BreakerOpen)
+		}
+	default:
+		// Successes and non-failure errors (e.g., not found) show the
+		// provider is responding
+		if err == nil {
+			b.stats.Successes++
+		}
+		b.failures = 0
+		if probe {
+			b.openedAt = time.Time{}
+			transition = b.setStateLocked(BreakerClosed)
+		}
+	}
+}
+
+// setStateLocked changes state and returns the notification to run after
+// unlocking, or nil if the state is unchanged.
+// Must be called with b.mu held.
+func (b *CircuitBreaker) setStateLocked(to BreakerState) func() {
+	from := b.state
+	if from == to {
+		return nil
+	}
+	b.state = to
+
+	return func() {
+		b.logger.Info("circuit breaker state change",
+			zap.String("breaker", b.name),
+			zap.String("from", from.String()),
+			zap.String("to", to.String()),
+		)
+		if b.onStateChange != nil {
+			b.onStateChange(b.name, from, to)
+		}
+	}
+}
+
+// ============================================================================
+// Resilient Providers
+// ============================================================================
+
+// ResilienceConfig holds configuration for resilient provider decorators.
+type ResilienceConfig struct {
+	// Retry configures retries of retryable errors.
+	Retry RetryPolicy
+
+	// Breaker fails calls fast while the provider is unhealthy (optional;
+	// nil disables circuit breaking). Use one breaker per provider.
+	Breaker *CircuitBreaker
+
+	// Logger reports retries (optional).
+	Logger *zap.Logger
+}
+
+// resilience runs calls with retries and an optional circuit breaker.
+type resilience struct {
+	retry   RetryPolicy
+	breaker *CircuitBreaker
+	logger  *zap.Logger
+}
+
+func newResilience(cfg ResilienceConfig) resilience {
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	return resilience{retry: cfg.Retry.withDefaults(), breaker: cfg.Breaker, logger: cfg.Logger}
+}
+
+// do calls fn until it succeeds, fails with a non-retryable error, runs out
+// of attempts, or the next backoff would outlast the context deadline.
+func (r resilience) do(ctx context.Context, op, tokenMint string, fn func(ctx context.Context) error) error {
+	call := fn
+	if r.breaker != nil {
+		call = func(ctx context.Context) error { return r.breaker.Do(ctx, fn) }
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := call(ctx)
+		if err == nil || attempt >= r.retry.MaxAttempts || ctx.Err() != nil || !r.retry.Retryable(err) {
+			return err
+		}
+
+		delay := r.retry.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		r.logger.Debug("retrying provider call",
+			zap.String("op", op),
+			zap.String("token_mint", tokenMint),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// ResilientSecurityProvider decorates a TokenSecurityProvider with retries
+// and an optional circuit breaker.
+type ResilientSecurityProvider struct {
+	provider TokenSecurityProvider
+	resilience
+}
+
+// NewResilientSecurityProvider wraps provider.
+func NewResilientSecurityProvider(provider TokenSecurityProvider, cfg ResilienceConfig) *ResilientSecurityProvider {
+	return &ResilientSecurityProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetTokenSecurity calls the wrapped provider with retries.
+func (p *ResilientSecurityProvider) GetTokenSecurity(ctx context.Context, address string) (*birdeye.TokenSecurity, error) {
+	var sec *birdeye.TokenSecurity
+	err := p.do(ctx, "get_token_security", address, func(ctx context.Context) error {
+		var err error
+		sec, err = p.provider.GetTokenSecurity(ctx, address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sec, nil
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientSecurityProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientOverviewProvider decorates a TokenOverviewProvider with retries
+// and an optional circuit breaker.
+type ResilientOverviewProvider struct {
+	provider TokenOverviewProvider
+	resilience
+}
+
+// NewResilientOverviewProvider wraps provider.
+func NewResilientOverviewProvider(provider TokenOverviewProvider, cfg ResilienceConfig) *ResilientOverviewProvider {
+	return &ResilientOverviewProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetTokenOverview calls the wrapped provider with retries.
+func (p *ResilientOverviewProvider) GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error) {
+	var ov *birdeye.TokenOverview
+	err := p.do(ctx, "get_token_overview", address, func(ctx context.Context) error {
+		var err error
+		ov, err = p.provider.GetTokenOverview(ctx, address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ov, nil
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientOverviewProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// resilientCall runs fn with r's retries and returns its value.
+func resilientCall[V any](ctx context.Context, r resilience, op, key string, fn func(ctx context.Context) (V, error)) (V, error) {
+	var value V
+	err := r.do(ctx, op, key, func(ctx context.Context) error {
+		var err error
+		value, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return value, nil
+}
+
+// ResilientSecurityDataProvider decorates a SecurityDataProvider with
+// retries and an optional circuit breaker.
+type ResilientSecurityDataProvider struct {
+	provider SecurityDataProvider
+	resilience
+}
+
+// NewResilientSecurityDataProvider wraps provider.
+func NewResilientSecurityDataProvider(provider SecurityDataProvider, cfg ResilienceConfig) *ResilientSecurityDataProvider {
+	return &ResilientSecurityDataProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetSecurityData calls the wrapped provider with retries.
+func (p *ResilientSecurityDataProvider) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	return resilientCall(ctx, p.resilience, "get_security_data", tokenMint, func(ctx context.Context) (*SecurityData, error) {
+		return p.provider.GetSecurityData(ctx, tokenMint)
+	})
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientSecurityDataProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientMarketDataProvider decorates a MarketDataProvider with retries
+// and an optional circuit breaker.
+type ResilientMarketDataProvider struct {
+	provider MarketDataProvider
+	resilience
+}
+
+// NewResilientMarketDataProvider wraps provider.
+func NewResilientMarketDataProvider(provider MarketDataProvider, cfg ResilienceConfig) *ResilientMarketDataProvider {
+	return &ResilientMarketDataProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetMarketData calls the wrapped provider with retries.
+func (p *ResilientMarketDataProvider) GetMarketData(ctx context.Context, tokenMint string) (*MarketData, error) {
+	return resilientCall(ctx, p.resilience, "get_market_data", tokenMint, func(ctx context.Context) (*MarketData, error) {
+		return p.provider.GetMarketData(ctx, tokenMint)
+	})
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientMarketDataProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientHolderDataProvider decorates a HolderDataProvider with retries
+// and an optional circuit breaker.
+type ResilientHolderDataProvider struct {
+	provider HolderDataProvider
+	resilience
+}
+
+// NewResilientHolderDataProvider wraps provider.
+func NewResilientHolderDataProvider(provider HolderDataProvider, cfg ResilienceConfig) *ResilientHolderDataProvider {
+	return &ResilientHolderDataProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetHolderData calls the wrapped provider with retries.
+func (p *ResilientHolderDataProvider) GetHolderData(ctx context.Context, tokenMint string) (*HolderData, error) {
+	return resilientCall(ctx, p.resilience, "get_holder_data", tokenMint, func(ctx context.Context) (*HolderData, error) {
+		return p.provider.GetHolderData(ctx, tokenMint)
+	})
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientHolderDataProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientTransactionHistoryProvider decorates a TransactionHistoryProvider
+// with retries and an optional circuit breaker.
+//
+// Cached lookups of the wrapped provider stay visible to the screener, so
+// they are still not charged.
+type ResilientTransactionHistoryProvider struct {
+	provider TransactionHistoryProvider
+	resilience
+}
+
+// NewResilientTransactionHistoryProvider wraps provider.
+func NewResilientTransactionHistoryProvider(provider TransactionHistoryProvider, cfg ResilienceConfig) *ResilientTransactionHistoryProvider {
+	return &ResilientTransactionHistoryProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetFunders calls the wrapped provider with retries.
+func (p *ResilientTransactionHistoryProvider) GetFunders(ctx context.Context, wallet string) ([]Funder, error) {
+	return resilientCall(ctx, p.resilience, "get_funders", wallet, func(ctx context.Context) ([]Funder, error) {
+		return p.provider.GetFunders(ctx, wallet)
+	})
+}
+
+// cachedFunders implements fundingCache if the wrapped provider does.
+func (p *ResilientTransactionHistoryProvider) cachedFunders(wallet string) ([]Funder, bool) {
+	if cache, ok := p.provider.(fundingCache); ok {
+		return cache.cachedFunders(wallet)
+	}
+	return nil, false
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientTransactionHistoryProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientLaunchHistoryProvider decorates a LaunchHistoryProvider with
+// retries and an optional circuit breaker.
+//
+// Cached launches of the wrapped provider stay visible to the screener, so
+// they are still not charged.
+type ResilientLaunchHistoryProvider struct {
+	provider LaunchHistoryProvider
+	resilience
+}
+
+// NewResilientLaunchHistoryProvider wraps provider.
+func NewResilientLaunchHistoryProvider(provider LaunchHistoryProvider, cfg ResilienceConfig) *ResilientLaunchHistoryProvider {
+	return &ResilientLaunchHistoryProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetLaunchBuys calls the wrapped provider with retries.
+func (p *ResilientLaunchHistoryProvider) GetLaunchBuys(ctx context.Context, tokenMint string) ([]LaunchBuy, error) {
+	return resilientCall(ctx, p.resilience, "get_launch_buys", tokenMint, func(ctx context.Context) ([]LaunchBuy, error) {
+		return p.provider.GetLaunchBuys(ctx, tokenMint)
+	})
+}
+
+// cachedLaunchBuys implements launchCache if the wrapped provider does.
+func (p *ResilientLaunchHistoryProvider) cachedLaunchBuys(tokenMint string) ([]LaunchBuy, bool) {
+	if cache, ok := p.provider.(launchCache); ok {
+		return cache.cachedLaunchBuys(tokenMint)
+	}
+	return nil, false
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientLaunchHistoryProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// ResilientCreationTimeProvider decorates a CreationTimeProvider with
+// retries and an optional circuit breaker.
+type ResilientCreationTimeProvider struct {
+	provider CreationTimeProvider
+	resilience
+}
+
+// NewResilientCreationTimeProvider wraps provider.
+func NewResilientCreationTimeProvider(provider CreationTimeProvider, cfg ResilienceConfig) *ResilientCreationTimeProvider {
+	return &ResilientCreationTimeProvider{provider: provider, resilience: newResilience(cfg)}
+}
+
+// GetCreationTime calls the wrapped provider with retries.
+func (p *ResilientCreationTimeProvider) GetCreationTime(ctx context.Context, tokenMint string) (CreationTime, error) {
+	return resilientCall(ctx, p.resilience, "get_creation_time", tokenMint, func(ctx context.Context) (CreationTime, error) {
+		return p.provider.GetCreationTime(ctx, tokenMint)
+	})
+}
+
+// Breaker returns the circuit breaker, or nil if none is configured.
+func (p *ResilientCreationTimeProvider) Breaker() *CircuitBreaker {
+	return p.breaker
+}
@@ -0,0 +1,360 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// flakySecurityProvider fails with errs in order, then succeeds.
+type flakySecurityProvider struct {
+	mu    sync.Mutex
+	errs  []error
+	calls atomic.Int32
+}
+
+func (p *flakySecurityProvider) GetTokenSecurity(_ context.Context, _ string) (*birdeye.TokenSecurity, error) {
+	p.calls.Add(1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+		return nil, err
+	}
+	return &birdeye.TokenSecurity{}, nil
+}
+
+// fail queues errors for subsequent calls.
+func (p *flakySecurityProvider) fail(errs ...error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errs = append(p.errs, errs...)
+}
+
+var (
+	errServerError = &birdeye.APIError{StatusCode: 503}
+	errNotFound    = &birdeye.APIError{StatusCode: 404}
+)
+
+// fastRetries retries quickly so tests do not sleep.
+var fastRetries = RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestResilientSecurityProvider_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantCalls int32
+	}{
+		{"recovers from transient errors", []error{errServerError, errServerError}, nil, 3},
+		{"gives up after max attempts", []error{errServerError, errServerError, errServerError, errServerError}, errServerError, 3},
+		{"does not retry not found", []error{errNotFound}, errNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &flakySecurityProvider{}
+			inner.fail(tt.errs...)
+			provider := NewResilientSecurityProvider(inner, ResilienceConfig{Retry: fastRetries})
+
+			_, err := provider.GetTokenSecurity(context.Background(), "test-mint")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got := inner.calls.Load(); got != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
+
+// flakyDataProvider implements every data provider interface, failing
+// once with errServerError before succeeding, and caches nothing.
+type flakyDataProvider struct {
+	calls int
+}
+
+func (p *flakyDataProvider) next() error {
+	p.calls++
+	if p.calls == 1 {
+		return errServerError
+	}
+	return nil
+}
+
+func (p *flakyDataProvider) GetSecurityData(_ context.Context, _ string) (*SecurityData, error) {
+	return &SecurityData{}, p.next()
+}
+
+func (p *flakyDataProvider) GetMarketData(_ context.Context, _ string) (*MarketData, error) {
+	return &MarketData{}, p.next()
+}
+
+func (p *flakyDataProvider) GetHolderData(_ context.Context, _ string) (*HolderData, error) {
+	return &HolderData{}, p.next()
+}
+
+func (p *flakyDataProvider) GetFunders(_ context.Context, _ string) ([]Funder, error) {
+	return nil, p.next()
+}
+
+func (p *flakyDataProvider) GetLaunchBuys(_ context.Context, _ string) ([]LaunchBuy, error) {
+	return nil, p.next()
+}
+
+func (p *flakyDataProvider) GetCreationTime(_ context.Context, _ string) (CreationTime, error) {
+	return CreationTime{}, p.next()
+}
+
+func TestResilientDataProviders_Retry(t *testing.T) {
+	cfg := ResilienceConfig{Retry: fastRetries}
+	tests := []struct {
+		name string
+		call func(ctx context.Context, inner *flakyDataProvider) error
+	}{
+		{"security data", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientSecurityDataProvider(inner, cfg).GetSecurityData(ctx, "test-mint")
+			return err
+		}},
+		{"market data", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientMarketDataProvider(inner, cfg).GetMarketData(ctx, "test-mint")
+			return err
+		}},
+		{"holder data", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientHolderDataProvider(inner, cfg).GetHolderData(ctx, "test-mint")
+			return err
+		}},
+		{"funders", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientTransactionHistoryProvider(inner, cfg).GetFunders(ctx, "test-wallet")
+			return err
+		}},
+		{"launch buys", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientLaunchHistoryProvider(inner, cfg).GetLaunchBuys(ctx, "test-mint")
+			return err
+		}},
+		{"creation time", func(ctx context.Context, inner *flakyDataProvider) error {
+			_, err := NewResilientCreationTimeProvider(inner, cfg).GetCreationTime(ctx, "test-mint")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &flakyDataProvider{}
+			if err := tt.call(context.Background(), inner); err != nil {
+				t.Errorf("expected the transient error to be retried, got %v", err)
+			}
+			if inner.calls != 2 {
+				t.Errorf("expected 2 calls, got %d", inner.calls)
+			}
+		})
+	}
+}
+
+func TestResilientDataProviders_Breaker(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{Name: "market", FailureThreshold: 1})
+	provider := NewResilientMarketDataProvider(&staticMarketData{err: errServerError},
+		ResilienceConfig{Retry: RetryPolicy{MaxAttempts: 1}, Breaker: breaker})
+
+	if _, err := provider.GetMarketData(context.Background(), "test-mint"); !errors.Is(err, errServerError) {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+	if _, err := provider.GetMarketData(context.Background(), "test-mint"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected the open breaker to reject the call, got %v", err)
+	}
+	if provider.Breaker() != breaker {
+		t.Error("expected the configured breaker")
+	}
+}
+
+func TestResilientHistoryProviders_ForwardCaches(t *testing.T) {
+	server := newRPCStandIn(t)
+	history, err := NewSolanaRPCHistoryProvider(SolanaRPCHistoryConfig{RPC: SolanaRPCConfig{Endpoint: server.URL}})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCHistoryProvider() error = %v", err)
+	}
+	storeBounded(history.funders, 1, systemWalletAddr, []Funder{{Address: "funder"}})
+	storeBounded(history.launches, 1, launchMintAddr, []LaunchBuy{{Buyer: "buyer"}})
+
+	funders := NewResilientTransactionHistoryProvider(history, ResilienceConfig{})
+	if _, ok := funders.cachedFunders(systemWalletAddr); !ok {
+		t.Error("expected cached funders to be forwarded")
+	}
+	launches := NewResilientLaunchHistoryProvider(history, ResilienceConfig{})
+	if _, ok := launches.cachedLaunchBuys(launchMintAddr); !ok {
+		t.Error("expected cached launch buys to be forwarded")
+	}
+
+	uncached := NewResilientTransactionHistoryProvider(&fundingHistory{}, ResilienceConfig{})
+	if _, ok := uncached.cachedFunders(systemWalletAddr); ok {
+		t.Error("expected no cache without a caching provider")
+	}
+}
+
+func TestResilientSecurityProvider_RespectsDeadline(t *testing.T) {
+	inner := &flakySecurityProvider{}
+	inner.fail(errServerError, errServerError, errServerError)
+	provider := NewResilientSecurityProvider(inner, ResilienceConfig{
+		Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Second, MaxDelay: 10 * time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := provider.GetTokenSecurity(ctx, "test-mint")
+	if !errors.Is(err, errServerError) {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected retries to stop within the deadline, took %v", elapsed)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}.withDefaults()
+
+	for i := 0; i < 100; i++ {
+		if d := policy.backoff(1); d < 0 || d > 100*time.Millisecond {
+			t.Fatalf("first backoff %v outside [0, 100ms]", d)
+		}
+		if d := policy.backoff(40); d < 0 || d > time.Second {
+			t.Fatalf("capped backoff %v outside [0, 1s]", d)
+		}
+	}
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		transitions []string
+	)
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		Name:             "birdeye-security",
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(_ string, from, to BreakerState) {
+			mu.Lock()
+			defer mu.Unlock()
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	inner := &flakySecurityProvider{}
+	inner.fail(errServerError, errServerError)
+	provider := NewResilientSecurityProvider(inner, ResilienceConfig{
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: breaker,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.GetTokenSecurity(ctx, "test-mint"); !errors.Is(err, errServerError) {
+			t.Fatalf("call %d: expected provider error, got %v", i, err)
+		}
+	}
+	if provider.Breaker().State() != BreakerOpen {
+		t.Fatalf("expected breaker open, got %s", provider.Breaker().State())
+	}
+
+	// Open: calls fail fast without reaching the provider.
+	_, err := provider.GetTokenSecurity(ctx, "test-mint")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if ClassifyError(err) != ErrorClassTransient {
+		t.Error("expected open circuit to be transient")
+	}
+	if got := inner.calls.Load(); got != 2 {
+		t.Errorf("expected 2 provider calls, got %d", got)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if breaker.State() != BreakerHalfOpen {
+		t.Fatalf("expected breaker half-open after timeout, got %s", breaker.State())
+	}
+	if _, err := provider.GetTokenSecurity(ctx, "test-mint"); err != nil {
+		t.Fatalf("probe call error = %v", err)
+	}
+
+	stats := breaker.Stats()
+	if stats.State != BreakerClosed || stats.Failures != 2 || stats.Successes != 1 || stats.Rejected != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"closed->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("expected transitions %v, got %v", want, transitions)
+			break
+		}
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+	ctx := context.Background()
+	fail := func(context.Context) error { return errServerError }
+
+	_ = breaker.Do(ctx, fail)
+	time.Sleep(20 * time.Millisecond)
+
+	// The probe fails, so the breaker opens for another timeout.
+	if err := breaker.Do(ctx, fail); !errors.Is(err, errServerError) {
+		t.Fatalf("expected probe to reach the provider, got %v", err)
+	}
+	if err := breaker.Do(ctx, fail); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected breaker open after failed probe, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresNonFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+
+	// Unknown tokens show the provider is healthy.
+	_ = breaker.Do(context.Background(), func(context.Context) error { return errNotFound })
+
+	// A caller giving up is not the provider's fault.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = breaker.Do(ctx, func(ctx context.Context) error { return ctx.Err() })
+
+	if breaker.State() != BreakerClosed {
+		t.Errorf("expected breaker closed, got %s", breaker.State())
+	}
+}
+
+func TestScreener_Screen_WithResilientProviders(t *testing.T) {
+	security := &flakySecurityProvider{}
+	security.fail(errServerError)
+
+	screener, err := New(Config{
+		SecurityProvider: NewResilientSecurityProvider(security, ResilienceConfig{Retry: fastRetries}),
+		OverviewProvider: NewResilientOverviewProvider(&mockOverviewProvider{
+			overview: &birdeye.TokenOverview{Liquidity: decimal.NewFromInt(100000)},
+		}, ResilienceConfig{Retry: fastRetries}),
+		Logger: zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelNormal); err != nil {
+		t.Fatalf("expected transient failure to be retried, got %v", err)
+	}
+}
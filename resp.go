@@ -0,0 +1,334 @@
+package tokenguard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// Minimal RESP (Redis Serialization Protocol) Client
+// ============================================================================
+
+// RedisError is an error reply returned by a Redis server.
+type RedisError string
+
+// Error implements the error interface.
+func (e RedisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// respValue is a decoded RESP reply.
+type respValue struct {
+	kind  byte // One of '+', '-', ':', '$', '*'
+	str   string
+	num   int64
+	array []respValue
+	null  bool // Null bulk string or null array
+}
+
+// respConn is a single connection speaking RESP.
+type respConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// newRESPConn wraps an established network connection.
+func newRESPConn(conn net.Conn) *respConn {
+	return &respConn{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		w:    bufio.NewWriter(conn),
+	}
+}
+
+// writeCommand buffers a command as a RESP array of bulk strings.
+func (c *respConn) writeCommand(args ...string) error {
+	if _, err := fmt.Fprintf(c.w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(c.w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// do sends one command and reads its reply.
+//
+// Error replies are returned as RedisError; the connection stays usable.
+func (c *respConn) do(args ...string) (respValue, error) {
+	replies, err := c.pipeline([][]string{args})
+	if err != nil {
+		return respValue{}, err
+	}
+	return replies[0], replyError(replies[0])
+}
+
+// pipeline sends several commands in one round trip and reads all replies.
+//
+// Error replies are returned in place rather than as an error, so callers
+// can inspect each reply individually.
+func (c *respConn) pipeline(cmds [][]string) ([]respValue, error) {
+	for _, args := range cmds {
+		if err := c.writeCommand(args...); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]respValue, len(cmds))
+	for i := range cmds {
+		reply, err := c.readReply()
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// readReply decodes a single RESP reply from the connection.
+func (c *respConn) readReply() (respValue, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return respValue{}, err
+	}
+	if len(line) == 0 {
+		return respValue{}, fmt.Errorf("redis: empty reply line")
+	}
+
+	v := respValue{kind: line[0]}
+	payload := line[1:]
+
+	switch v.kind {
+	case '+', '-':
+		v.str = payload
+	case ':':
+		if v.num, err = strconv.ParseInt(payload, 10, 64); err != nil {
+			return respValue{}, fmt.Errorf("redis: invalid integer reply %q", payload)
+		}
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return respValue{}, fmt.Errorf("redis: invalid bulk length %q", payload)
+		}
+		if n < 0 {
+			v.null = true
+			return v, nil
+		}
+		buf := make([]byte, n+2) // Payload plus trailing CRLF
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return respValue{}, err
+		}
+		v.str = string(buf[:n])
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return respValue{}, fmt.Errorf("redis: invalid array length %q", payload)
+		}
+		if n < 0 {
+			v.null = true
+			return v, nil
+		}
+		v.array = make([]respValue, n)
+		for i := range v.array {
+			if v.array[i], err = c.readReply(); err != nil {
+				return respValue{}, err
+			}
+		}
+	default:
+		return respValue{}, fmt.Errorf("redis: unknown reply type %q", v.kind)
+	}
+
+	return v, nil
+}
+
+// readLine reads a CRLF-terminated line without the terminator.
+func (c *respConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// close closes the underlying network connection.
+func (c *respConn) close() error {
+	return c.conn.Close()
+}
+
+// replyError converts an error reply into a RedisError.
+func replyError(v respValue) error {
+	if v.kind == '-' {
+		return RedisError(v.str)
+	}
+	return nil
+}
+
+// ============================================================================
+// Connection Pool
+// ============================================================================
+
+// redisPoolConfig holds connection settings shared by pooled connections.
+type redisPoolConfig struct {
+	addr        string
+	password    string
+	db          int
+	size        int
+	dialTimeout time.Duration
+	timeout     time.Duration // Per-command deadline when ctx has none
+}
+
+// redisPool is a bounded pool of RESP connections.
+//
+// At most size connections are open at once; callers block (respecting
+// their context) until one is free. Connections that hit a network error
+// are discarded rather than returned to the pool.
+type redisPool struct {
+	cfg    redisPoolConfig
+	idle   chan *respConn
+	slots  chan struct{}
+	mu     sync.Mutex
+	closed bool
+}
+
+// newRedisPool creates an empty pool; connections are dialed lazily.
+func newRedisPool(cfg redisPoolConfig) *redisPool {
+	return &redisPool{
+		cfg:   cfg,
+		idle:  make(chan *respConn, cfg.size),
+		slots: make(chan struct{}, cfg.size),
+	}
+}
+
+// get returns a connection with its deadline set from ctx, or from the
+// pool's command timeout if ctx has no deadline, so that a hung server
+// cannot block the caller forever.
+func (p *redisPool) get(ctx context.Context) (*respConn, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		<-p.slots
+		return nil, errors.New("redis: pool is closed")
+	}
+
+	var conn *respConn
+	select {
+	case conn = <-p.idle:
+	default:
+		var err error
+		if conn, err = p.dial(ctx); err != nil {
+			<-p.slots
+			return nil, err
+		}
+	}
+
+	if err := conn.conn.SetDeadline(p.deadline(ctx)); err != nil {
+		_ = conn.close()
+		<-p.slots
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// deadline returns the deadline for a command under ctx.
+func (p *redisPool) deadline(ctx context.Context) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(p.cfg.timeout)
+}
+
+// put returns a connection to the pool after use.
+//
+// If err is a network or protocol error the connection is closed, since its
+// stream position is unknown. Redis error replies leave it usable.
+func (p *redisPool) put(conn *respConn, err error) {
+	defer func() { <-p.slots }()
+
+	var redisErr RedisError
+	if err != nil && !errors.As(err, &redisErr) {
+		_ = conn.close()
+		return
+	}
+
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		_ = conn.close()
+		return
+	}
+
+	select {
+	case p.idle <- conn:
+	default:
+		_ = conn.close()
+	}
+}
+
+// dial opens and authenticates a new connection.
+func (p *redisPool) dial(ctx context.Context) (*respConn, error) {
+	dialer := net.Dialer{Timeout: p.cfg.dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", p.cfg.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis: dial %s: %w", p.cfg.addr, err)
+	}
+
+	conn := newRESPConn(netConn)
+	_ = netConn.SetDeadline(p.deadline(ctx))
+
+	if p.cfg.password != "" {
+		if _, err := conn.do("AUTH", p.cfg.password); err != nil {
+			_ = conn.close()
+			return nil, fmt.Errorf("redis: auth: %w", err)
+		}
+	}
+	if p.cfg.db != 0 {
+		if _, err := conn.do("SELECT", strconv.Itoa(p.cfg.db)); err != nil {
+			_ = conn.close()
+			return nil, fmt.Errorf("redis: select db: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// close closes all idle connections and rejects further use.
+// Connections currently checked out are closed when returned.
+func (p *redisPool) close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	for {
+		select {
+		case conn := <-p.idle:
+			_ = conn.close()
+		default:
+			return nil
+		}
+	}
+}
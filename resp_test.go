@@ -0,0 +1,386 @@
+package tokenguard
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// In-Process RESP Server Stand-In
+// ============================================================================
+
+// fakeRedis is a minimal in-process server speaking enough RESP to exercise
+// the Redis-backed implementations: PING, AUTH, SELECT, GET, SET (PX/EX),
+// DEL, PTTL, PUBLISH and SUBSCRIBE.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu          sync.Mutex
+	data        map[string]string
+	expires     map[string]time.Time
+	conns       int
+	open        map[*fakeConn]struct{}
+	subscribers map[string][]*fakeConn
+}
+
+// fakeConn serializes writes to one client connection, which may come from
+// its own command loop or from another connection's PUBLISH.
+type fakeConn struct {
+	conn net.Conn
+	mu   sync.Mutex
+	w    *bufio.Writer
+}
+
+// newFakeRedis starts a server on a random local port.
+// It is shut down automatically when the test completes.
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	return newFakeRedisWithPassword(t, "")
+}
+
+// newFakeRedisWithPassword starts a server that requires AUTH.
+func newFakeRedisWithPassword(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := &fakeRedis{
+		listener:    listener,
+		password:    password,
+		data:        make(map[string]string),
+		expires:     make(map[string]time.Time),
+		open:        make(map[*fakeConn]struct{}),
+		subscribers: make(map[string][]*fakeConn),
+	}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+// addr returns the server's host:port.
+func (s *fakeRedis) addr() string {
+	return s.listener.Addr().String()
+}
+
+// keys returns all unexpired keys.
+func (s *fakeRedis) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+	for key := range s.data {
+		if s.liveLocked(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// connCount returns the number of connections accepted so far.
+func (s *fakeRedis) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conns
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+// dropConnections closes every open client connection, simulating a
+// server restart or network failure.
+func (s *fakeRedis) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for fc := range s.open {
+		_ = fc.conn.Close()
+	}
+}
+
+// subscriberCount returns the number of active subscriptions to channel.
+func (s *fakeRedis) subscriberCount(channel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.subscribers[channel])
+}
+
+func (s *fakeRedis) handle(conn net.Conn) {
+	fc := &fakeConn{conn: conn, w: bufio.NewWriter(conn)}
+	s.mu.Lock()
+	s.open[fc] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		_ = conn.Close()
+		s.removeConn(fc)
+	}()
+
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+
+	for {
+		args, err := readFakeCommand(r)
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(args[0])
+		switch {
+		case !authed && cmd != "AUTH":
+			fc.mu.Lock()
+			writeFakeError(fc.w, "NOAUTH Authentication required.")
+			fc.mu.Unlock()
+		case cmd == "PUBLISH":
+			n := s.publish(args[1], args[2])
+			fc.mu.Lock()
+			writeFakeInt(fc.w, int64(n))
+			fc.mu.Unlock()
+		case cmd == "SUBSCRIBE":
+			s.subscribe(fc, args[1:])
+		default:
+			fc.mu.Lock()
+			authed = s.exec(fc.w, cmd, args[1:], authed) || authed
+			fc.mu.Unlock()
+		}
+
+		fc.mu.Lock()
+		err = fc.w.Flush()
+		fc.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// subscribe registers fc for channels and confirms each subscription.
+func (s *fakeRedis) subscribe(fc *fakeConn, channels []string) {
+	s.mu.Lock()
+	for _, ch := range channels {
+		s.subscribers[ch] = append(s.subscribers[ch], fc)
+	}
+	s.mu.Unlock()
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for i, ch := range channels {
+		_, _ = fc.w.WriteString("*3\r\n")
+		writeFakeBulk(fc.w, "subscribe")
+		writeFakeBulk(fc.w, ch)
+		writeFakeInt(fc.w, int64(i+1))
+	}
+}
+
+// publish delivers a message to every subscriber of channel.
+// Writes happen outside s.mu so subscribers' command loops cannot deadlock.
+func (s *fakeRedis) publish(channel, payload string) int {
+	s.mu.Lock()
+	subs := append([]*fakeConn(nil), s.subscribers[channel]...)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.mu.Lock()
+		_, _ = sub.w.WriteString("*3\r\n")
+		writeFakeBulk(sub.w, "message")
+		writeFakeBulk(sub.w, channel)
+		writeFakeBulk(sub.w, payload)
+		_ = sub.w.Flush()
+		sub.mu.Unlock()
+	}
+	return len(subs)
+}
+
+// removeConn forgets a closed connection and its subscriptions.
+func (s *fakeRedis) removeConn(fc *fakeConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.open, fc)
+	for ch, subs := range s.subscribers {
+		kept := subs[:0]
+		for _, sub := range subs {
+			if sub != fc {
+				kept = append(kept, sub)
+			}
+		}
+		s.subscribers[ch] = kept
+	}
+}
+
+// exec runs one command and reports whether it authenticated the connection.
+func (s *fakeRedis) exec(w *bufio.Writer, cmd string, args []string, authed bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch cmd {
+	case "PING":
+		_, _ = w.WriteString("+PONG\r\n")
+	case "AUTH":
+		if len(args) != 1 || args[0] != s.password {
+			writeFakeError(w, "WRONGPASS invalid password")
+			return false
+		}
+		_, _ = w.WriteString("+OK\r\n")
+		return true
+	case "SELECT":
+		_, _ = w.WriteString("+OK\r\n")
+	case "GET":
+		if !s.liveLocked(args[0]) {
+			_, _ = w.WriteString("$-1\r\n")
+			return authed
+		}
+		writeFakeBulk(w, s.data[args[0]])
+	case "SET":
+		s.data[args[0]] = args[1]
+		delete(s.expires, args[0])
+		if len(args) == 4 {
+			n, _ := strconv.ParseInt(args[3], 10, 64)
+			unit := time.Millisecond
+			if strings.ToUpper(args[2]) == "EX" {
+				unit = time.Second
+			}
+			s.expires[args[0]] = time.Now().Add(time.Duration(n) * unit)
+		}
+		_, _ = w.WriteString("+OK\r\n")
+	case "DEL":
+		deleted := 0
+		for _, key := range args {
+			if s.liveLocked(key) {
+				deleted++
+			}
+			delete(s.data, key)
+			delete(s.expires, key)
+		}
+		writeFakeInt(w, int64(deleted))
+	case "PTTL":
+		switch {
+		case !s.liveLocked(args[0]):
+			writeFakeInt(w, -2)
+		case s.expires[args[0]].IsZero():
+			writeFakeInt(w, -1)
+		default:
+			writeFakeInt(w, time.Until(s.expires[args[0]]).Milliseconds())
+		}
+	default:
+		writeFakeError(w, "ERR unknown command '"+cmd+"'")
+	}
+
+	return authed
+}
+
+// liveLocked reports whether key exists and is unexpired, lazily deleting it
+// otherwise. Must be called with s.mu held.
+func (s *fakeRedis) liveLocked(key string) bool {
+	if _, ok := s.data[key]; !ok {
+		return false
+	}
+	if exp, ok := s.expires[key]; ok && time.Now().After(exp) {
+		delete(s.data, key)
+		delete(s.expires, key)
+		return false
+	}
+	return true
+}
+
+func readFakeCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func writeFakeBulk(w *bufio.Writer, s string) {
+	_, _ = w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func writeFakeInt(w *bufio.Writer, n int64) {
+	_, _ = w.WriteString(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func writeFakeError(w *bufio.Writer, msg string) {
+	_, _ = w.WriteString("-" + msg + "\r\n")
+}
+
+// ============================================================================
+// Protocol Tests
+// ============================================================================
+
+func TestRESPConn_ReadReply(t *testing.T) {
+	server, client := net.Pipe()
+	defer func() { _ = client.Close() }()
+
+	go func() {
+		_, _ = server.Write([]byte("+OK\r\n-ERR boom\r\n:42\r\n$5\r\nhello\r\n$-1\r\n*2\r\n$1\r\na\r\n:7\r\n"))
+		_ = server.Close()
+	}()
+
+	conn := newRESPConn(client)
+
+	tests := []struct {
+		name  string
+		check func(v respValue) bool
+	}{
+		{"simple string", func(v respValue) bool { return v.kind == '+' && v.str == "OK" }},
+		{"error", func(v respValue) bool { return replyError(v) == RedisError("ERR boom") }},
+		{"integer", func(v respValue) bool { return v.kind == ':' && v.num == 42 }},
+		{"bulk string", func(v respValue) bool { return v.kind == '$' && v.str == "hello" }},
+		{"null bulk", func(v respValue) bool { return v.null }},
+		{"array", func(v respValue) bool {
+			return len(v.array) == 2 && v.array[0].str == "a" && v.array[1].num == 7
+		}},
+	}
+
+	for _, tt := range tests {
+		v, err := conn.readReply()
+		if err != nil {
+			t.Fatalf("%s: readReply() error = %v", tt.name, err)
+		}
+		if !tt.check(v) {
+			t.Errorf("%s: unexpected reply %+v", tt.name, v)
+		}
+	}
+}
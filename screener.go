@@ -5,6 +5,11 @@
 //   - Liquidity depth (slippage risk)
 //   - LP lock percentage (rug pull risk)
 //   - Holder concentration (manipulation risk)
+//   - Insider clusters (supply split across related wallets)
+//   - Launch snipers (supply bought in the first slots and still held)
+//   - Token age (launch rug risk)
+//   - Market activity (volume, holders, liquidity to market cap, price moves)
+//   - Creator reputation (serial ruggers)
 //
 // Results are cached to avoid redundant API calls.
 package tokenguard
@@ -12,7 +17,7 @@ package tokenguard
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/Laminar-Bot/birdeye-go"
@@ -24,14 +29,18 @@ import (
 // Interfaces for dependency injection and testing
 // ============================================================================
 
-// TokenSecurityProvider provides token security data (authorities, holder concentration).
-// This interface allows mocking in tests.
+// TokenSecurityProvider provides Birdeye token security data (authorities,
+// holder concentration). It is satisfied by *birdeye.Client.
+//
+// New integrations should implement SecurityDataProvider instead.
 type TokenSecurityProvider interface {
 	GetTokenSecurity(ctx context.Context, address string) (*birdeye.TokenSecurity, error)
 }
 
-// TokenOverviewProvider provides token market data (liquidity, price, volume).
-// This interface allows mocking in tests.
+// TokenOverviewProvider provides Birdeye token market data (liquidity, price,
+// volume). It is satisfied by *birdeye.Client.
+//
+// New integrations should implement MarketDataProvider instead.
 type TokenOverviewProvider interface {
 	GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error)
 }
@@ -46,6 +55,18 @@ type Cache interface {
 	Set(ctx context.Context, result *TokenScreeningResult) error
 }
 
+// ExpiringCache is a Cache that can report how long entries remain valid.
+// Layered caches use it to avoid serving an entry longer than its source.
+type ExpiringCache interface {
+	Cache
+
+	// GetWithTTL is like Get but also returns the entry's remaining time-to-live.
+	GetWithTTL(ctx context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool)
+
+	// TTL returns the time-to-live applied to newly stored entries.
+	TTL() time.Duration
+}
+
 // ============================================================================
 // Screener Implementation
 // ============================================================================
@@ -57,51 +78,191 @@ type Cache interface {
 //   - Liquidity depth (slippage risk)
 //   - LP lock percentage (rug pull risk)
 //   - Holder concentration (manipulation risk)
+//   - Insider clusters (supply split across related wallets)
+//   - Launch snipers (supply bought in the first slots and still held)
+//   - Token age (launch rug risk)
+//   - Market activity (volume, holders, liquidity to market cap, price moves)
+//   - Creator reputation (serial ruggers)
 //
 // Results are cached to avoid redundant API calls.
 type Screener struct {
-	security TokenSecurityProvider
-	overview TokenOverviewProvider
-	cache    Cache // Optional; nil disables caching
+	security SecurityDataProvider
+	market   MarketDataProvider
+	holders  HolderDataProvider         // Optional; nil uses shares from security data
+	creation CreationTimeProvider       // Optional; nil uses CreatedAt from security data
+	creators CreatorStore               // Optional; nil disables the creator reputation check
+	history  TransactionHistoryProvider // Optional; nil disables the insider cluster check
+	clusters insiderClusters
+	launches LaunchHistoryProvider // Optional; nil disables the launch sniper check
+	cache    Cache                 // Optional; nil disables caching
+	data     *DataCache            // Optional; nil disables per-fact data caching
+	negative *NegativeCache        // Optional; nil disables negative caching
+	limiter  *RateLimiter          // Optional; nil disables rate limiting
+	costs    CallCosts
 	logger   *zap.Logger
 
+	// Slots after the launch slot in which buys count as sniped
+	sniperSlots int
+
+	// Freshness limits for liquidity and holder data
+	maxDataAge  time.Duration // Zero disables
+	staleAction StaleDataAction
+
 	// Thresholds for each screening level
 	thresholds map[ScreeningLevel]ScreeningThresholds
 }
 
 // Config holds configuration for creating a new Screener.
 type Config struct {
-	// SecurityProvider is used to fetch token security data (required).
+	// SecurityDataProvider is used to fetch token security data.
+	// Either it or SecurityProvider is required; it takes precedence.
+	SecurityDataProvider SecurityDataProvider
+
+	// MarketDataProvider is used to fetch token market data.
+	// Either it or OverviewProvider is required; it takes precedence.
+	MarketDataProvider MarketDataProvider
+
+	// HolderDataProvider is used to fetch holder distribution for the
+	// concentration check (optional; nil uses the shares in security data).
+	HolderDataProvider HolderDataProvider
+
+	// CreationTimeProvider looks up token creation times for the token age
+	// check when security data does not report CreatedAt (optional; nil
+	// leaves the age of such tokens unknown).
+	CreationTimeProvider CreationTimeProvider
+
+	// TransactionHistoryProvider looks up who funded the largest holders,
+	// for the insider cluster check (optional; nil disables the check).
+	// The check also requires HolderDataProvider.
+	TransactionHistoryProvider TransactionHistoryProvider
+
+	// InsiderClusters configures the insider cluster check.
+	InsiderClusters InsiderClusterConfig
+
+	// LaunchHistoryProvider looks up a token's earliest buys, for the
+	// launch sniper check (optional; nil disables the check). The check
+	// also requires HolderDataProvider.
+	LaunchHistoryProvider LaunchHistoryProvider
+
+	// SniperWindowSlots is the number of slots after the launch slot in
+	// which buys count as sniped.
+	// Defaults to 3 if zero.
+	SniperWindowSlots int
+
+	// CreatorStore keeps creator reputations for the creator reputation
+	// check (optional; nil disables the check). Every screening is
+	// recorded under the token's creator.
+	CreatorStore CreatorStore
+
+	// SecurityProvider is a Birdeye security data source, used through
+	// BirdeyeSecurityProvider when SecurityDataProvider is nil.
 	SecurityProvider TokenSecurityProvider
 
-	// OverviewProvider is used to fetch token market data (required).
+	// OverviewProvider is a Birdeye market data source, used through
+	// BirdeyeMarketProvider when MarketDataProvider is nil.
 	OverviewProvider TokenOverviewProvider
 
 	// Cache stores screening results (optional; nil disables caching).
 	Cache Cache
 
+	// DataCache stores individual provider inputs with per-kind TTLs
+	// (optional; nil disables per-fact caching).
+	DataCache *DataCache
+
+	// NegativeCache remembers classified provider failures so they are not
+	// retried on every screening (optional; nil disables negative caching).
+	NegativeCache *NegativeCache
+
+	// RateLimiter paces provider calls and enforces the daily credit budget
+	// (optional; nil disables rate limiting). Calls wait at the priority
+	// carried by the context (see WithPriority).
+	RateLimiter *RateLimiter
+
+	// CallCosts are the credits charged per provider call, used for rate
+	// limiting and reported in TokenScreeningResult.CreditsUsed.
+	// Defaults to 1 credit per call.
+	CallCosts CallCosts
+
+	// MaxDataAge is the maximum age of the liquidity and holder data a
+	// screening is judged on, measured from the data's AsOf time. Cached
+	// data keeps its original AsOf; entries in the DataCache older than
+	// MaxDataAge are refetched from the provider.
+	// Set to 0 to accept data of any age.
+	MaxDataAge time.Duration
+
+	// StaleDataAction is applied to screenings with data older than
+	// MaxDataAge.
+	// Defaults to StaleDataFail if empty.
+	StaleDataAction StaleDataAction
+
 	// Logger for structured logging (required).
 	Logger *zap.Logger
 }
 
 // New creates a new token screener.
 func New(cfg Config) (*Screener, error) {
-	if cfg.SecurityProvider == nil {
+	security := cfg.SecurityDataProvider
+	if security == nil && cfg.SecurityProvider != nil {
+		security = NewBirdeyeSecurityProvider(cfg.SecurityProvider)
+	}
+	if security == nil {
 		return nil, fmt.Errorf("security provider is required")
 	}
-	if cfg.OverviewProvider == nil {
+
+	market := cfg.MarketDataProvider
+	if market == nil && cfg.OverviewProvider != nil {
+		market = NewBirdeyeMarketProvider(cfg.OverviewProvider)
+	}
+	if market == nil {
 		return nil, fmt.Errorf("overview provider is required")
 	}
+
 	if cfg.Logger == nil {
 		return nil, fmt.Errorf("logger is required")
 	}
 
+	if cfg.MaxDataAge < 0 {
+		return nil, fmt.Errorf("max data age must not be negative")
+	}
+	if cfg.StaleDataAction == "" {
+		cfg.StaleDataAction = StaleDataFail
+	}
+	if !validStaleDataAction(cfg.StaleDataAction) {
+		return nil, fmt.Errorf("invalid stale data action: %s", cfg.StaleDataAction)
+	}
+
+	clusters, err := newInsiderClusters(cfg.InsiderClusters)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.SniperWindowSlots == 0 {
+		cfg.SniperWindowSlots = DefaultSniperWindowSlots
+	}
+	if cfg.SniperWindowSlots < 0 {
+		return nil, fmt.Errorf("sniper window slots must not be negative")
+	}
+
 	return &Screener{
-		security:   cfg.SecurityProvider,
-		overview:   cfg.OverviewProvider,
+		security:   security,
+		market:     market,
+		holders:    cfg.HolderDataProvider,
+		creation:   cfg.CreationTimeProvider,
+		creators:   cfg.CreatorStore,
+		history:    cfg.TransactionHistoryProvider,
+		clusters:   clusters,
+		launches:   cfg.LaunchHistoryProvider,
 		cache:      cfg.Cache,
+		data:       cfg.DataCache,
+		negative:   cfg.NegativeCache,
+		limiter:    cfg.RateLimiter,
+		costs:      cfg.CallCosts.withDefaults(),
 		logger:     cfg.Logger,
 		thresholds: defaultThresholds(),
+
+		sniperSlots: cfg.SniperWindowSlots,
+		maxDataAge:  cfg.MaxDataAge,
+		staleAction: cfg.StaleDataAction,
 	}, nil
 }
 
@@ -120,6 +281,19 @@ func defaultThresholds() map[ScreeningLevel]ScreeningThresholds {
 			MinLPLockedPct:      decimal.NewFromInt(80),    // 80% LP locked
 			MaxTop10HoldersPct:  decimal.NewFromInt(40),    // Top 10 hold max 40%
 			MaxTopHolderPct:     decimal.NewFromInt(15),    // Single holder max 15%
+			MaxClusterPct:       decimal.NewFromInt(25),    // Funding-linked holders max 25%
+			MaxSniperHeldPct:    decimal.NewFromInt(10),    // Launch snipers hold max 10%
+			MaxBundleHeldPct:    decimal.NewFromInt(5),     // Launch bundle holds max 5%
+
+			MinVolume24hUSD:       decimal.NewFromInt(50000), // $50K daily volume
+			MinHolders:            500,
+			MinLiquidityToMcapPct: decimal.RequireFromString("0.5"), // Liquidity at least 0.5% of market cap
+			MaxPriceChangePct:     decimal.NewFromInt(50),           // Moves within ±50%
+			MinTokenAge:           24 * time.Hour,
+			RequireTokenAge:       true,
+
+			MaxCreatorRuggedTokens: 0,
+			MaxCreatorFailedPct:    decimal.NewFromInt(50), // Penalized if over half failed
 		},
 		ScreeningLevelNormal: {
 			RequireNoMintAuth:   true,
@@ -128,6 +302,18 @@ func defaultThresholds() map[ScreeningLevel]ScreeningThresholds {
 			MinLPLockedPct:      decimal.NewFromInt(50),    // 50% LP locked
 			MaxTop10HoldersPct:  decimal.NewFromInt(60),    // Top 10 hold max 60%
 			MaxTopHolderPct:     decimal.NewFromInt(25),    // Single holder max 25%
+			MaxClusterPct:       decimal.NewFromInt(35),    // Funding-linked holders max 35%
+			MaxSniperHeldPct:    decimal.NewFromInt(20),    // Launch snipers hold max 20%
+			MaxBundleHeldPct:    decimal.NewFromInt(10),    // Launch bundle holds max 10%
+
+			MinVolume24hUSD:       decimal.NewFromInt(10000), // $10K daily volume
+			MinHolders:            100,
+			MinLiquidityToMcapPct: decimal.RequireFromString("0.25"), // Liquidity at least 0.25% of market cap
+			MaxPriceChangePct:     decimal.NewFromInt(150),           // Moves within ±150%
+			MinTokenAge:           time.Hour,
+
+			MaxCreatorRuggedTokens: 0,
+			MaxCreatorFailedPct:    decimal.NewFromInt(75), // Penalized if over 75% failed
 		},
 		ScreeningLevelRelaxed: {
 			RequireNoMintAuth:   false, // Allows mint authority
@@ -136,6 +322,17 @@ func defaultThresholds() map[ScreeningLevel]ScreeningThresholds {
 			MinLPLockedPct:      decimal.NewFromInt(25),   // 25% LP locked
 			MaxTop10HoldersPct:  decimal.NewFromInt(75),   // Top 10 hold max 75%
 			MaxTopHolderPct:     decimal.NewFromInt(35),   // Single holder max 35%
+			MaxClusterPct:       decimal.NewFromInt(50),   // Funding-linked holders max 50%
+			MaxSniperHeldPct:    decimal.NewFromInt(35),   // Launch snipers hold max 35%
+			MaxBundleHeldPct:    decimal.NewFromInt(20),   // Launch bundle holds max 20%
+
+			MinVolume24hUSD:       decimal.NewFromInt(1000), // $1K daily volume
+			MinHolders:            25,
+			MinLiquidityToMcapPct: decimal.RequireFromString("0.1"), // Liquidity at least 0.1% of market cap
+			MaxPriceChangePct:     decimal.NewFromInt(500),          // Moves within ±500%
+
+			MaxCreatorRuggedTokens: 1,
+			MaxCreatorFailedPct:    decimal.NewFromInt(90), // Penalized if over 90% failed
 		},
 	}
 }
@@ -146,7 +343,7 @@ func defaultThresholds() map[ScreeningLevel]ScreeningThresholds {
 // a result indicating whether the token passes screening.
 //
 // The screening process:
-//  1. Check cache for recent results
+//  1. Check cache for recent results (and recent failures)
 //  2. Fetch token security data (authorities, holders)
 //  3. Fetch token market data (liquidity)
 //  4. Run checks against thresholds
@@ -180,6 +377,17 @@ func (s *Screener) Screen(ctx context.Context, tokenMint string, level Screening
 		}
 	}
 
+	// Serve recent classified failures without calling providers again
+	if s.negative != nil {
+		if cachedErr, ok := s.negative.Get(tokenMint); ok {
+			s.logger.Debug("using cached screening failure",
+				zap.String("token_mint", tokenMint),
+				zap.String("class", cachedErr.Class.String()),
+			)
+			return nil, cachedErr
+		}
+	}
+
 	// Get thresholds for this level
 	threshold, ok := s.thresholds[level]
 	if !ok {
@@ -196,21 +404,18 @@ func (s *Screener) Screen(ctx context.Context, tokenMint string, level Screening
 		ScreenedAt:     time.Now(),
 	}
 
-	// Run all checks, collecting failures
-	if err := s.checkAuthorities(ctx, tokenMint, threshold, result); err != nil {
-		return nil, fmt.Errorf("authority check failed: %w", err)
-	}
-
-	if err := s.checkLiquidity(ctx, tokenMint, threshold, result); err != nil {
-		return nil, fmt.Errorf("liquidity check failed: %w", err)
-	}
-
-	if err := s.checkHolderConcentration(ctx, tokenMint, threshold, result); err != nil {
-		return nil, fmt.Errorf("holder concentration check failed: %w", err)
-	}
-
-	if err := s.checkLPLock(ctx, tokenMint, threshold, result); err != nil {
-		return nil, fmt.Errorf("LP lock check failed: %w", err)
+	// Run all checks, collecting failures and credits spent
+	var usage atomic.Int64
+	err := s.runChecks(withUsage(ctx, &usage), tokenMint, threshold, result)
+	result.CreditsUsed = usage.Load()
+	if err != nil {
+		if s.negative != nil && s.negative.Record(tokenMint, err) {
+			s.logger.Debug("caching screening failure",
+				zap.String("token_mint", tokenMint),
+				zap.Error(err),
+			)
+		}
+		return nil, err
 	}
 
 	// Ensure score doesn't go negative
@@ -218,8 +423,9 @@ func (s *Screener) Screen(ctx context.Context, tokenMint string, level Screening
 		result.Score = 0
 	}
 
-	// Cache result (if caching enabled)
-	if s.cache != nil {
+	// Cache result (if caching enabled). Results failed for stale data are
+	// not cached, so the next screening can use fresh data.
+	if s.cache != nil && !s.failedStale(result) {
 		if err := s.cache.Set(ctx, result); err != nil {
 			s.logger.Warn("failed to cache screening result",
 				zap.String("token_mint", tokenMint),
@@ -240,20 +446,152 @@ func (s *Screener) Screen(ctx context.Context, tokenMint string, level Screening
 	return result, nil
 }
 
+// runChecks runs every check against the token, filling in result.
+func (s *Screener) runChecks(
+	ctx context.Context,
+	tokenMint string,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) error {
+	// Several checks judge the security and holder data; fetch each once
+	security, err := s.fetchSecurity(ctx, tokenMint)
+	if err != nil {
+		return err
+	}
+
+	s.checkAuthorities(tokenMint, security, threshold, result)
+
+	if err := s.checkTokenAge(ctx, tokenMint, security, threshold, result); err != nil {
+		return fmt.Errorf("token age check failed: %w", err)
+	}
+
+	if err := s.checkLiquidity(ctx, tokenMint, threshold, result); err != nil {
+		return fmt.Errorf("liquidity check failed: %w", err)
+	}
+
+	var holders *HolderData // Nil without a holder provider
+	if s.holders != nil {
+		if holders, err = s.fetchHolders(ctx, tokenMint); err != nil {
+			return err
+		}
+	}
+
+	s.checkHolderConcentration(tokenMint, security, holders, threshold, result)
+
+	if err := s.checkInsiderClusters(ctx, tokenMint, holders, threshold, result); err != nil {
+		return fmt.Errorf("insider cluster check failed: %w", err)
+	}
+
+	if err := s.checkSnipers(ctx, tokenMint, holders, threshold, result); err != nil {
+		return fmt.Errorf("launch sniper check failed: %w", err)
+	}
+
+	s.checkLPLock(tokenMint, security, threshold, result)
+
+	// Last, so the token is recorded with the verdict of the other checks
+	if err := s.checkCreator(ctx, tokenMint, security, threshold, result); err != nil {
+		return fmt.Errorf("creator check failed: %w", err)
+	}
+
+	return nil
+}
+
+// skipCheck records a check that could not be judged for lack of data.
+func (s *Screener) skipCheck(tokenMint, check string, result *TokenScreeningResult) {
+	result.SkippedChecks = append(result.SkippedChecks, check)
+	s.logger.Debug("check skipped: input unknown",
+		zap.String("token_mint", tokenMint),
+		zap.String("check", check),
+	)
+}
+
+// fetchSecurity returns security data for a token, consulting the data cache
+// (if enabled) before calling the provider.
+func (s *Screener) fetchSecurity(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	if s.data != nil {
+		if security, ok := s.data.Security(tokenMint); ok && !s.cachedTooOld(security.AsOf) {
+			return security, nil
+		}
+	}
+
+	if err := s.spend(ctx, s.costs.Security); err != nil {
+		return nil, fmt.Errorf("get token security: %w", err)
+	}
+
+	security, err := s.security.GetSecurityData(ctx, tokenMint)
+	if err != nil {
+		return nil, fmt.Errorf("get token security: %w", err)
+	}
+
+	if s.data != nil {
+		security = s.data.SetSecurity(tokenMint, security)
+	}
+
+	return security, nil
+}
+
+// fetchMarket returns market data for a token, consulting the data cache
+// (if enabled) before calling the provider.
+func (s *Screener) fetchMarket(ctx context.Context, tokenMint string) (*MarketData, error) {
+	if s.data != nil {
+		if market, ok := s.data.Market(tokenMint); ok && !s.cachedTooOld(market.AsOf) {
+			return market, nil
+		}
+	}
+
+	if err := s.spend(ctx, s.costs.Market); err != nil {
+		return nil, fmt.Errorf("get token overview: %w", err)
+	}
+
+	market, err := s.market.GetMarketData(ctx, tokenMint)
+	if err != nil {
+		return nil, fmt.Errorf("get token overview: %w", err)
+	}
+
+	if s.data != nil {
+		s.data.SetMarket(tokenMint, market)
+	}
+
+	return market, nil
+}
+
+// fetchHolders returns holder distribution for a token, consulting the data
+// cache (if enabled) before calling the provider.
+func (s *Screener) fetchHolders(ctx context.Context, tokenMint string) (*HolderData, error) {
+	if s.data != nil {
+		if holders, ok := s.data.Holders(tokenMint); ok && !s.cachedTooOld(holders.AsOf) {
+			return holders, nil
+		}
+	}
+
+	if err := s.spend(ctx, s.costs.Holders); err != nil {
+		return nil, fmt.Errorf("get holder data: %w", err)
+	}
+
+	holders, err := s.holders.GetHolderData(ctx, tokenMint)
+	if err != nil {
+		return nil, fmt.Errorf("get holder data: %w", err)
+	}
+
+	if s.data != nil {
+		s.data.SetHolders(tokenMint, holders)
+	}
+
+	return holders, nil
+}
+
 // checkAuthorities checks mint and freeze authority status.
 //
 // Tokens with active mint authority can have supply inflated (rug pull risk).
 // Tokens with freeze authority can have user accounts frozen (loss of funds risk).
 func (s *Screener) checkAuthorities(
-	ctx context.Context,
 	tokenMint string,
+	security *SecurityData,
 	threshold ScreeningThresholds,
 	result *TokenScreeningResult,
-) error {
-	security, err := s.security.GetTokenSecurity(ctx, tokenMint)
-	if err != nil {
-		return fmt.Errorf("get token security: %w", err)
-	}
+) {
+	result.Disagreements = appendDisagreements(result.Disagreements, security.Disagreements)
+	recordAsOf(result, security.Source, security.AsOf)
 
 	// Check mint authority
 	hasMintAuth := security.HasMintAuthority()
@@ -283,7 +621,7 @@ func (s *Screener) checkAuthorities(
 
 	// Check Token-2022 specific features
 	result.Details.IsToken2022 = security.IsToken2022
-	result.Details.HasTransferFee = security.TransferFeeEnable
+	result.Details.HasTransferFee = security.HasTransferFee
 	result.Details.NonTransferable = security.NonTransferable
 	result.Details.MutableMetadata = security.MutableMetadata
 
@@ -293,6 +631,60 @@ func (s *Screener) checkAuthorities(
 		result.Score -= 50
 		result.FailureReasons = append(result.FailureReasons, "non_transferable")
 	}
+}
+
+// checkTokenAge checks how long ago the token was created.
+//
+// Most rug pulls happen within hours of launch, before a token has any
+// track record.
+func (s *Screener) checkTokenAge(
+	ctx context.Context,
+	tokenMint string,
+	security *SecurityData,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) error {
+	created := CreationTime{At: security.CreatedAt, Exact: true}
+	if created.At.IsZero() && s.creation != nil {
+		if err := s.spend(ctx, s.costs.Creation); err != nil {
+			return fmt.Errorf("get creation time: %w", err)
+		}
+		var err error
+		if created, err = s.creation.GetCreationTime(ctx, tokenMint); err != nil {
+			return fmt.Errorf("get creation time: %w", err)
+		}
+	}
+
+	var age time.Duration
+	if !created.At.IsZero() {
+		age = max(result.ScreenedAt.Sub(created.At), 0)
+		result.Details.CreatedAt = created.At
+		result.Details.CreatedAtExact = created.Exact
+		result.Details.TokenAge = age
+	}
+
+	// An inexact time only bounds the age from below
+	if age < threshold.MinTokenAge && (created.At.IsZero() || !created.Exact) {
+		s.skipCheck(tokenMint, "token_age", result)
+		if threshold.RequireTokenAge {
+			result.Passed = false
+			result.Score -= 20
+			result.FailureReasons = append(result.FailureReasons, "token_age_unknown")
+		}
+		return nil
+	}
+
+	if age < threshold.MinTokenAge {
+		result.Passed = false
+		result.Score -= 20
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("token_too_young:%s", age.Truncate(time.Second)))
+		s.logger.Debug("token is too young",
+			zap.String("token_mint", tokenMint),
+			zap.Duration("age", age),
+			zap.Duration("min_age", threshold.MinTokenAge),
+		)
+	}
 
 	return nil
 }
@@ -306,44 +698,154 @@ func (s *Screener) checkLiquidity(
 	threshold ScreeningThresholds,
 	result *TokenScreeningResult,
 ) error {
-	overview, err := s.overview.GetTokenOverview(ctx, tokenMint)
+	market, err := s.fetchMarket(ctx, tokenMint)
 	if err != nil {
-		return fmt.Errorf("get token overview: %w", err)
+		return err
 	}
+	result.Disagreements = appendDisagreements(result.Disagreements, market.Disagreements)
+	s.checkFreshness(tokenMint, staleLiquidity, market.Source, market.AsOf, result)
 
-	result.Details.LiquidityUSD = overview.Liquidity
+	result.Details.LiquidityUSD = market.LiquidityUSD
+	result.Details.LiquidityReported = market.LiquidityReported
 
-	if overview.Liquidity.LessThan(threshold.MinLiquidityUSD) {
+	if market.LiquidityUSD.LessThan(threshold.MinLiquidityUSD) {
 		result.Passed = false
 		result.Score -= 25
 		result.FailureReasons = append(result.FailureReasons,
-			fmt.Sprintf("low_liquidity:$%s", overview.Liquidity.StringFixed(2)))
+			fmt.Sprintf("low_liquidity:$%s", market.LiquidityUSD.StringFixed(2)))
 		s.logger.Debug("token has low liquidity",
 			zap.String("token_mint", tokenMint),
-			zap.String("liquidity", overview.Liquidity.String()),
+			zap.String("liquidity", market.LiquidityUSD.String()),
 			zap.String("required", threshold.MinLiquidityUSD.String()),
 		)
 	}
 
+	s.checkMarketActivity(tokenMint, market, threshold, result)
+
 	return nil
 }
 
+// checkMarketActivity checks trading volume, holder count, liquidity
+// relative to market cap and price moves, using the market data already
+// fetched for the liquidity check.
+//
+// Thin trading and few holders mean the liquidity may not be usable; a
+// small pool behind a large market cap means the price cannot be realized;
+// sharp price moves indicate a pump or dump in progress. Checks whose
+// inputs the provider does not report are skipped.
+func (s *Screener) checkMarketActivity(
+	tokenMint string,
+	market *MarketData,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) {
+	result.Details.Volume24hUSD = market.Volume24hUSD
+	result.Details.MarketCapUSD = market.MarketCapUSD
+	result.Details.Holders = market.Holders
+	result.Details.PriceChange24hPct = market.PriceChange24hPct
+
+	if market.reportsActivity() && market.Volume24hUSD.LessThan(threshold.MinVolume24hUSD) {
+		result.Passed = false
+		result.Score -= 10
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("low_volume_24h:$%s", market.Volume24hUSD.StringFixed(2)))
+		s.logger.Debug("token has low trading volume",
+			zap.String("token_mint", tokenMint),
+			zap.String("volume_24h", market.Volume24hUSD.String()),
+			zap.String("required", threshold.MinVolume24hUSD.String()),
+		)
+	}
+
+	if market.Holders > 0 && market.Holders < threshold.MinHolders {
+		result.Passed = false
+		result.Score -= 10
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("low_holder_count:%d", market.Holders))
+		s.logger.Debug("token has few holders",
+			zap.String("token_mint", tokenMint),
+			zap.Int("holders", market.Holders),
+			zap.Int("required", threshold.MinHolders),
+		)
+	}
+
+	if market.MarketCapUSD.IsPositive() {
+		ratioPct := market.LiquidityUSD.Div(market.MarketCapUSD).Mul(decimal.NewFromInt(100))
+		result.Details.LiquidityToMcapPct = ratioPct
+
+		if ratioPct.LessThan(threshold.MinLiquidityToMcapPct) {
+			result.Passed = false
+			result.Score -= 10
+			result.FailureReasons = append(result.FailureReasons,
+				fmt.Sprintf("low_liquidity_mcap_ratio:%s%%", ratioPct.StringFixed(2)))
+			s.logger.Debug("token has low liquidity for its market cap",
+				zap.String("token_mint", tokenMint),
+				zap.String("ratio_pct", ratioPct.String()),
+				zap.String("required", threshold.MinLiquidityToMcapPct.String()),
+			)
+		}
+	}
+
+	// Report each abnormal window, but deduct once
+	changes := append([]PriceChange{{Window: 24 * time.Hour, Pct: market.PriceChange24hPct}}, market.PriceChanges...)
+	abnormal := false
+	for _, change := range changes {
+		if change.Pct.Abs().LessThanOrEqual(threshold.MaxPriceChangePct) {
+			continue
+		}
+		abnormal = true
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("abnormal_price_change_%s:%s%%", formatWindow(change.Window), change.Pct.StringFixed(2)))
+		s.logger.Debug("abnormal price change",
+			zap.String("token_mint", tokenMint),
+			zap.Duration("window", change.Window),
+			zap.String("change_pct", change.Pct.String()),
+			zap.String("max_allowed", threshold.MaxPriceChangePct.String()),
+		)
+	}
+	if abnormal {
+		result.Passed = false
+		result.Score -= 10
+	}
+}
+
+// formatWindow formats a price change window compactly (e.g., "24h", "5m").
+func formatWindow(window time.Duration) string {
+	switch {
+	case window >= time.Hour && window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window >= time.Minute && window%time.Minute == 0:
+		return fmt.Sprintf("%dm", window/time.Minute)
+	default:
+		return window.String()
+	}
+}
+
 // checkHolderConcentration analyzes token distribution.
 //
 // High concentration in few wallets indicates manipulation risk.
+// Shares come from the holder provider if configured, otherwise from
+// security data.
 func (s *Screener) checkHolderConcentration(
-	ctx context.Context,
 	tokenMint string,
+	security *SecurityData,
+	holders *HolderData,
 	threshold ScreeningThresholds,
 	result *TokenScreeningResult,
-) error {
-	security, err := s.security.GetTokenSecurity(ctx, tokenMint)
-	if err != nil {
-		return fmt.Errorf("get token security: %w", err)
+) {
+	var top10Pct, topHolderPct decimal.Decimal
+	if holders != nil {
+		result.Disagreements = appendDisagreements(result.Disagreements, holders.Disagreements)
+		s.checkFreshness(tokenMint, staleHolder, holders.Source, holders.AsOf, result)
+		top10Pct, topHolderPct = holders.Top10HoldersPct, holders.TopHolderPct
+	} else {
+		if security.HolderSharesUnknown {
+			s.skipCheck(tokenMint, "holder_concentration", result)
+			return
+		}
+		s.checkFreshness(tokenMint, staleHolder, security.Source, security.AsOf, result)
+		top10Pct, topHolderPct = security.Top10HoldersPct, security.TopHolderPct
 	}
 
-	// Parse top 10 holder percentage
-	top10Pct := parsePercentage(security.Top10HolderPercent)
 	result.Details.Top10HoldersPct = top10Pct
 
 	if top10Pct.GreaterThan(threshold.MaxTop10HoldersPct) {
@@ -358,8 +860,6 @@ func (s *Screener) checkHolderConcentration(
 		)
 	}
 
-	// Parse creator/top holder percentage
-	topHolderPct := parsePercentage(security.CreatorPercentage)
 	result.Details.TopHolderPct = topHolderPct
 
 	if topHolderPct.GreaterThan(threshold.MaxTopHolderPct) {
@@ -373,30 +873,163 @@ func (s *Screener) checkHolderConcentration(
 			zap.String("max_allowed", threshold.MaxTopHolderPct.String()),
 		)
 	}
+}
+
+// checkInsiderClusters checks the share held by holders linked through
+// funding.
+//
+// A deployer splitting supply across fresh wallets funded from one source
+// passes the per-wallet concentration checks; grouping holders by who
+// funded them reveals the combined position. Requires holder lists, so it
+// is skipped without a holder provider.
+func (s *Screener) checkInsiderClusters(
+	ctx context.Context,
+	tokenMint string,
+	holders *HolderData,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) error {
+	if s.history == nil || holders == nil {
+		return nil
+	}
+
+	clusters, err := s.clusterHolders(ctx, holders.Holders)
+	if err != nil {
+		return err
+	}
+
+	result.Details.InsiderClusters = len(clusters)
+	if len(clusters) == 0 {
+		return nil
+	}
+	largest := clusters[0]
+	result.Details.LargestClusterPct = largest.pct
+	result.Details.LargestClusterWallets = len(largest.holders)
+
+	if largest.pct.GreaterThan(threshold.MaxClusterPct) {
+		result.Passed = false
+		result.Score -= 15
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("high_cluster_concentration:%s%%", largest.pct.StringFixed(2)))
+		s.logger.Debug("high insider cluster concentration",
+			zap.String("token_mint", tokenMint),
+			zap.Strings("wallets", largest.holders),
+			zap.String("cluster_pct", largest.pct.String()),
+			zap.String("max_allowed", threshold.MaxClusterPct.String()),
+		)
+	}
 
 	return nil
 }
 
+// checkSnipers checks the share still held by wallets that bought in the
+// token's first slots.
+//
+// Bots and the deployer's own wallets buying in the launch slot or the few
+// after it take supply before anyone else can, and dump it on later buyers.
+// Buys landing in the launch slot itself from several wallets are a bundle
+// submitted with the launch, so the bundle has a tighter limit. Requires
+// holder lists to measure what is still held, so it is skipped without a
+// holder provider. Tokens whose launch is unknown are listed in
+// TokenScreeningResult.SkippedChecks as "launch_snipers".
+func (s *Screener) checkSnipers(
+	ctx context.Context,
+	tokenMint string,
+	holders *HolderData,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) error {
+	if s.launches == nil || holders == nil {
+		return nil
+	}
+
+	buys, err := s.launchBuys(ctx, tokenMint)
+	if err != nil {
+		return err
+	}
+	if len(buys) == 0 {
+		s.skipCheck(tokenMint, "launch_snipers", result)
+		return nil
+	}
+
+	launch := analyzeLaunch(buys, s.sniperSlots)
+	details := &result.Details
+	details.SniperWallets = len(launch.snipers)
+	details.SniperHeldPct = heldPct(holders.Holders, launch.snipers)
+	details.BundleWallets = len(launch.bundle)
+	details.BundleHeldPct = heldPct(holders.Holders, launch.bundle)
+	if holders.Supply.IsPositive() {
+		details.SniperBoughtPct = launch.bought.Div(holders.Supply).Mul(decimal.NewFromInt(100))
+	}
+
+	if details.SniperHeldPct.GreaterThan(threshold.MaxSniperHeldPct) {
+		result.Passed = false
+		result.Score -= 15
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("sniper_concentration:%s%%", details.SniperHeldPct.StringFixed(2)))
+		s.logger.Debug("launch snipers hold large share",
+			zap.String("token_mint", tokenMint),
+			zap.Int("snipers", details.SniperWallets),
+			zap.String("sniper_held_pct", details.SniperHeldPct.String()),
+			zap.String("max_allowed", threshold.MaxSniperHeldPct.String()),
+		)
+	}
+
+	if details.BundleHeldPct.GreaterThan(threshold.MaxBundleHeldPct) {
+		result.Passed = false
+		result.Score -= 15
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("bundle_concentration:%s%%", details.BundleHeldPct.StringFixed(2)))
+		s.logger.Debug("launch bundle holds large share",
+			zap.String("token_mint", tokenMint),
+			zap.Int("bundle_wallets", details.BundleWallets),
+			zap.String("bundle_held_pct", details.BundleHeldPct.String()),
+			zap.String("max_allowed", threshold.MaxBundleHeldPct.String()),
+		)
+	}
+
+	return nil
+}
+
+// launchBuys returns a token's launch buys, charging for the lookup unless
+// the provider has the launch cached.
+func (s *Screener) launchBuys(ctx context.Context, tokenMint string) ([]LaunchBuy, error) {
+	if cache, ok := s.launches.(launchCache); ok {
+		if buys, ok := cache.cachedLaunchBuys(tokenMint); ok {
+			return buys, nil
+		}
+	}
+
+	if err := s.spend(ctx, s.costs.Launch); err != nil {
+		return nil, fmt.Errorf("get launch buys: %w", err)
+	}
+	buys, err := s.launches.GetLaunchBuys(ctx, tokenMint)
+	if err != nil {
+		return nil, fmt.Errorf("get launch buys: %w", err)
+	}
+	return buys, nil
+}
+
 // checkLPLock estimates LP lock percentage.
 //
 // LP lock prevents the creator from pulling liquidity (rug pull).
 // Note: This is an estimation based on creator holdings.
 // A more accurate check would verify actual lock contracts.
 func (s *Screener) checkLPLock(
-	ctx context.Context,
 	tokenMint string,
+	security *SecurityData,
 	threshold ScreeningThresholds,
 	result *TokenScreeningResult,
-) error {
-	security, err := s.security.GetTokenSecurity(ctx, tokenMint)
-	if err != nil {
-		return fmt.Errorf("get token security: %w", err)
+) {
+	if security.HolderSharesUnknown {
+		s.skipCheck(tokenMint, "lp_locked", result)
+		return
 	}
 
 	// Estimate LP lock percentage based on creator holdings.
 	// If creator holds a small percentage, it suggests LP is locked.
 	// This is a simplified heuristic; production should verify lock contracts.
-	creatorPct := parsePercentage(security.CreatorPercentage)
+	creatorPct := security.CreatorPct
 
 	// Rough estimate: 100% - creator% gives an upper bound on locked LP.
 	lpLockedPct := decimal.NewFromInt(100).Sub(creatorPct)
@@ -420,21 +1053,103 @@ func (s *Screener) checkLPLock(
 			zap.String("min_required", threshold.MinLPLockedPct.String()),
 		)
 	}
-
-	return nil
 }
 
-// parsePercentage safely parses a percentage string to decimal.
-// Returns zero if parsing fails.
-func parsePercentage(s string) decimal.Decimal {
-	if s == "" {
-		return decimal.Zero
+// checkCreator judges the token by its creator's history, then records the
+// token in that history.
+//
+// Serial ruggers launch token after token; a creator who was flagged or
+// whose earlier tokens lost their liquidity fails the screening, and one
+// whose earlier tokens mostly failed screening is penalized. The token is
+// recorded with the verdict of the other checks, so a creator's reputation
+// does not feed on itself.
+func (s *Screener) checkCreator(
+	ctx context.Context,
+	tokenMint string,
+	security *SecurityData,
+	threshold ScreeningThresholds,
+	result *TokenScreeningResult,
+) error {
+	if s.creators == nil {
+		return nil
 	}
 
-	val, err := strconv.ParseFloat(s, 64)
+	creator := security.CreatorAddress
+	if creator == "" {
+		return nil
+	}
+
+	reputation, err := s.creators.Reputation(ctx, creator)
 	if err != nil {
-		return decimal.Zero
+		return fmt.Errorf("get creator reputation: %w", err)
+	}
+	launched, failed, rugged := reputation.history(tokenMint)
+	otherChecksFailed := !result.Passed
+
+	result.Details.CreatorAddress = creator
+	result.Details.CreatorTokens = launched
+	result.Details.CreatorFailedTokens = failed
+	result.Details.CreatorRuggedTokens = rugged
+	result.Details.CreatorFlagged = reputation.Flagged
+
+	if reputation.Flagged {
+		result.Passed = false
+		result.Score -= 50
+		result.FailureReasons = append(result.FailureReasons, "creator_flagged")
+		s.logger.Debug("token creator is flagged",
+			zap.String("token_mint", tokenMint),
+			zap.String("creator", creator),
+			zap.String("reason", reputation.FlagReason),
+		)
 	}
 
-	return decimal.NewFromFloat(val)
+	if rugged > threshold.MaxCreatorRuggedTokens {
+		result.Passed = false
+		result.Score -= 40
+		result.FailureReasons = append(result.FailureReasons,
+			fmt.Sprintf("creator_rug_history:%d", rugged))
+		s.logger.Debug("token creator has rugged before",
+			zap.String("token_mint", tokenMint),
+			zap.String("creator", creator),
+			zap.Int("rugged", rugged),
+			zap.Int("max_allowed", threshold.MaxCreatorRuggedTokens),
+		)
+	}
+
+	// Penalize only: failures at a strict level say little on their own
+	if launched >= minCreatorHistory {
+		failedPct := decimal.NewFromInt(int64(failed * 100)).Div(decimal.NewFromInt(int64(launched)))
+		if failedPct.GreaterThan(threshold.MaxCreatorFailedPct) {
+			result.Score -= 15
+			s.logger.Debug("token creator has a high failure rate",
+				zap.String("token_mint", tokenMint),
+				zap.String("creator", creator),
+				zap.String("failed_pct", failedPct.String()),
+				zap.String("max_allowed", threshold.MaxCreatorFailedPct.String()),
+			)
+		}
+	}
+
+	// Screenings failed for stale data say nothing about the token
+	if s.failedStale(result) {
+		return nil
+	}
+	err = s.creators.RecordScreening(ctx, CreatorScreening{
+		Creator:           creator,
+		TokenMint:         tokenMint,
+		Failed:            otherChecksFailed,
+		LiquidityUSD:      result.Details.LiquidityUSD,
+		LiquidityMeasured: result.Details.LiquidityReported,
+		ScreenedAt:        result.ScreenedAt,
+	})
+	if err != nil {
+		s.logger.Warn("failed to record screening for creator",
+			zap.String("token_mint", tokenMint),
+			zap.String("creator", creator),
+			zap.Error(err),
+		)
+		// Don't fail screening because of store error
+	}
+
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	birdeye "github.com/Laminar-Bot/birdeye-go"
 	"github.com/shopspring/decimal"
@@ -558,6 +559,84 @@ func (c *countingSecurityProvider) GetTokenSecurity(ctx context.Context, address
 	return c.inner.GetTokenSecurity(ctx, address)
 }
 
+func TestScreener_Screen_MarketActivity(t *testing.T) {
+	// Active, widely held and liquid relative to its market cap.
+	healthy := func() *MarketData {
+		return &MarketData{
+			LiquidityUSD:      decimal.NewFromInt(100000),
+			MarketCapUSD:      decimal.NewFromInt(5000000),
+			Volume24hUSD:      decimal.NewFromInt(60000),
+			PriceChange24hPct: decimal.NewFromInt(-12),
+			Holders:           800,
+			Trades24h:         900,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(m *MarketData)
+		wantReasons []string
+		wantScore   int
+	}{
+		{"healthy market", func(*MarketData) {}, nil, 100},
+		{"low volume", func(m *MarketData) { m.Volume24hUSD = decimal.NewFromInt(800) }, []string{"low_volume_24h:$800.00"}, 90},
+		{"few holders", func(m *MarketData) { m.Holders = 12 }, []string{"low_holder_count:12"}, 90},
+		{"thin liquidity for market cap", func(m *MarketData) { m.MarketCapUSD = decimal.NewFromInt(200000000) }, []string{"low_liquidity_mcap_ratio:0.05%"}, 90},
+		{
+			"abnormal price moves deduct once",
+			func(m *MarketData) {
+				m.PriceChange24hPct = decimal.NewFromInt(640)
+				m.PriceChanges = []PriceChange{
+					{Window: time.Hour, Pct: decimal.NewFromInt(520)},
+					{Window: 5 * time.Minute, Pct: decimal.NewFromInt(20)},
+				}
+			},
+			[]string{"abnormal_price_change_24h:640.00%", "abnormal_price_change_1h:520.00%"},
+			90,
+		},
+		{
+			"unreported activity is skipped",
+			func(m *MarketData) {
+				m.Volume24hUSD, m.Trades24h, m.Holders, m.MarketCapUSD = decimal.Zero, 0, 0, decimal.Zero
+			},
+			nil,
+			100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := healthy()
+			tt.modify(market)
+
+			screener, err := New(Config{
+				SecurityDataProvider: &staticSecurityData{data: &SecurityData{}},
+				MarketDataProvider:   &staticMarketData{data: market},
+				Logger:               zap.NewNop(),
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := screener.Screen(context.Background(), "test-mint", ScreeningLevelRelaxed)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if strings.Join(result.FailureReasons, ",") != strings.Join(tt.wantReasons, ",") {
+				t.Errorf("expected reasons %v, got %v", tt.wantReasons, result.FailureReasons)
+			}
+			if result.Passed != (len(tt.wantReasons) == 0) || result.Score != tt.wantScore {
+				t.Errorf("expected passed=%v score=%d, got %v %d",
+					len(tt.wantReasons) == 0, tt.wantScore, result.Passed, result.Score)
+			}
+			if result.Details.Holders != market.Holders || !result.Details.Volume24hUSD.Equal(market.Volume24hUSD) {
+				t.Errorf("expected market activity in details, got %+v", result.Details)
+			}
+		})
+	}
+}
+
 // Helper function to check if a slice contains a string
 func contains(slice []string, str string) bool {
 	for _, s := range slice {
@@ -0,0 +1,201 @@
+package tokenguard
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// DefaultCacheShards is the default number of shards in ShardedCache.
+const DefaultCacheShards = 32
+
+// ============================================================================
+// Sharded In-Memory Cache Implementation
+// ============================================================================
+
+// ShardedCache is an in-memory cache split into independently locked shards.
+//
+// InMemoryCache guards all entries with a single lock, so under heavy
+// concurrency every Set and every expired Get contends on it, and a cleanup
+// pass stalls all readers while it walks the whole map. ShardedCache routes
+// each token to one of N InMemoryCache shards by hash, so operations on
+// different tokens rarely contend, and cleanup sweeps one shard at a time.
+//
+// Features:
+//   - Thread-safe with per-shard locking
+//   - Configurable TTL, max size and memory budget (divided across shards)
+//   - Incremental, shard-by-shard background cleanup (optional)
+type ShardedCache struct {
+	shards []*InMemoryCache
+	ttl    time.Duration
+
+	// For cleanup goroutine
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// ShardedCacheConfig holds configuration for ShardedCache.
+type ShardedCacheConfig struct {
+	// Shards is the number of independently locked shards.
+	// Defaults to 32 if zero.
+	Shards int
+
+	// TTL is the time-to-live for cached entries.
+	// Defaults to 5 minutes if zero.
+	TTL time.Duration
+
+	// MaxSize is the maximum number of entries across all shards.
+	// Each shard holds at most MaxSize/Shards entries (rounded up).
+	// Defaults to 10,000 if zero.
+	MaxSize int
+
+	// MaxBytes is the maximum estimated memory across all shards.
+	// Each shard holds at most MaxBytes/Shards bytes (rounded up).
+	// Set to 0 to bound the cache by entry count only.
+	MaxBytes int64
+
+	// CleanupInterval is how long a full sweep of all shards takes.
+	// Shards are cleaned one at a time, evenly spread over the interval,
+	// so only one shard is locked by cleanup at any moment.
+	// Set to 0 to disable background cleanup.
+	// If enabled, you must call Close() to stop the cleanup goroutine.
+	CleanupInterval time.Duration
+}
+
+// NewShardedCache creates a new sharded in-memory cache.
+func NewShardedCache(cfg ShardedCacheConfig) *ShardedCache {
+	if cfg.Shards <= 0 {
+		cfg.Shards = DefaultCacheShards
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultCacheTTL
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = DefaultMaxCacheSize
+	}
+
+	perShard := (cfg.MaxSize + cfg.Shards - 1) / cfg.Shards
+	perShardBytes := (cfg.MaxBytes + int64(cfg.Shards) - 1) / int64(cfg.Shards)
+	shards := make([]*InMemoryCache, cfg.Shards)
+	for i := range shards {
+		// Shards never run their own cleanup goroutine; see cleanupLoop.
+		shards[i] = NewInMemoryCache(InMemoryCacheConfig{
+			TTL:      cfg.TTL,
+			MaxSize:  perShard,
+			MaxBytes: perShardBytes,
+		})
+	}
+
+	c := &ShardedCache{
+		shards: shards,
+		ttl:    cfg.TTL,
+		done:   make(chan struct{}),
+	}
+
+	if cfg.CleanupInterval > 0 {
+		go c.cleanupLoop(cfg.CleanupInterval)
+	}
+
+	return c
+}
+
+// shard returns the shard responsible for a token.
+func (c *ShardedCache) shard(tokenMint string) *InMemoryCache {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tokenMint))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Get retrieves a cached screening result.
+//
+// Returns the result and true if found and not expired.
+// Returns nil and false if not found or expired.
+func (c *ShardedCache) Get(ctx context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	return c.shard(tokenMint).Get(ctx, tokenMint)
+}
+
+// GetWithTTL retrieves a cached screening result and its remaining time-to-live.
+func (c *ShardedCache) GetWithTTL(ctx context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool) {
+	return c.shard(tokenMint).GetWithTTL(ctx, tokenMint)
+}
+
+// Set stores a screening result in the cache.
+//
+// When the token's shard is full, eviction happens within that shard only.
+func (c *ShardedCache) Set(ctx context.Context, result *TokenScreeningResult) error {
+	return c.shard(result.TokenMint).Set(ctx, result)
+}
+
+// SetWithTTL stores a screening result with a custom time-to-live.
+func (c *ShardedCache) SetWithTTL(ctx context.Context, result *TokenScreeningResult, ttl time.Duration) error {
+	return c.shard(result.TokenMint).SetWithTTL(ctx, result, ttl)
+}
+
+// TTL returns the time-to-live applied by Set.
+func (c *ShardedCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Delete removes an entry from the cache.
+func (c *ShardedCache) Delete(ctx context.Context, tokenMint string) {
+	c.shard(tokenMint).Delete(ctx, tokenMint)
+}
+
+// Clear removes all entries from the cache, one shard at a time.
+func (c *ShardedCache) Clear(ctx context.Context) {
+	for _, shard := range c.shards {
+		shard.Clear(ctx)
+	}
+}
+
+// Size returns the number of entries in the cache (including expired).
+//
+// Shards are counted one at a time, so under concurrent writes the total is
+// approximate.
+func (c *ShardedCache) Size() int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.Size()
+	}
+	return total
+}
+
+// Bytes returns the estimated memory used by entries (including expired).
+func (c *ShardedCache) Bytes() int64 {
+	var total int64
+	for _, shard := range c.shards {
+		total += shard.Bytes()
+	}
+	return total
+}
+
+// Close stops the background cleanup goroutine.
+// Must be called if CleanupInterval was set; safe to call more than once.
+func (c *ShardedCache) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
+
+// cleanupLoop cleans one shard per tick, completing a full sweep every
+// interval.
+func (c *ShardedCache) cleanupLoop(interval time.Duration) {
+	tick := interval / time.Duration(len(c.shards))
+	if tick <= 0 {
+		tick = time.Millisecond
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	next := 0
+	for {
+		select {
+		case <-ticker.C:
+			c.shards[next].cleanup()
+			next = (next + 1) % len(c.shards)
+		case <-c.done:
+			return
+		}
+	}
+}
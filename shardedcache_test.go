@@ -0,0 +1,195 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShardedCache_SetGet(t *testing.T) {
+	ctx := context.Background()
+	cache := NewShardedCache(ShardedCacheConfig{Shards: 4, TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	for i := 0; i < 100; i++ {
+		result := &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i), Score: i}
+		if err := cache.Set(ctx, result); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	if cache.Size() != 100 {
+		t.Errorf("expected size 100, got %d", cache.Size())
+	}
+
+	for i := 0; i < 100; i++ {
+		got, ok := cache.Get(ctx, fmt.Sprintf("mint-%d", i))
+		if !ok || got.Score != i {
+			t.Fatalf("expected hit with score %d for mint-%d", i, i)
+		}
+	}
+
+	// Entries are spread across shards.
+	for i, shard := range cache.shards {
+		if shard.Size() == 0 {
+			t.Errorf("shard %d is empty; expected hashing to spread entries", i)
+		}
+	}
+
+	cache.Delete(ctx, "mint-0")
+	if _, ok := cache.Get(ctx, "mint-0"); ok {
+		t.Error("expected cache miss after delete")
+	}
+
+	cache.Clear(ctx)
+	if cache.Size() != 0 {
+		t.Errorf("expected size 0 after clear, got %d", cache.Size())
+	}
+}
+
+func TestShardedCache_MaxSize(t *testing.T) {
+	ctx := context.Background()
+	cache := NewShardedCache(ShardedCacheConfig{Shards: 4, MaxSize: 40})
+	defer func() { _ = cache.Close() }()
+
+	for i := 0; i < 1000; i++ {
+		_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i)})
+	}
+
+	if size := cache.Size(); size > 40 {
+		t.Errorf("expected at most 40 entries, got %d", size)
+	}
+}
+
+func TestShardedCache_IncrementalCleanup(t *testing.T) {
+	ctx := context.Background()
+	cache := NewShardedCache(ShardedCacheConfig{
+		Shards:          4,
+		TTL:             10 * time.Millisecond,
+		CleanupInterval: 20 * time.Millisecond,
+	})
+	defer func() { _ = cache.Close() }()
+
+	for i := 0; i < 20; i++ {
+		_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i)})
+	}
+
+	// Two full sweeps after expiry.
+	time.Sleep(60 * time.Millisecond)
+
+	if cache.Size() != 0 {
+		t.Errorf("expected size 0 after cleanup, got %d", cache.Size())
+	}
+}
+
+func TestShardedCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	cache := NewShardedCache(ShardedCacheConfig{
+		Shards:          8,
+		TTL:             5 * time.Millisecond,
+		MaxSize:         64,
+		CleanupInterval: 8 * time.Millisecond,
+	})
+	defer func() { _ = cache.Close() }()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				mint := fmt.Sprintf("mint-%d", (g*500+i)%200)
+				_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: mint})
+				cache.Get(ctx, mint)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+//
+// Compare lock contention between the single-lock and sharded caches across
+// core counts with:
+//
+//	go test -run '^$' -bench 'Cache_Parallel' -cpu 1,2,4,8,16
+//
+// InMemoryCache throughput flattens (or drops) as GOMAXPROCS grows because
+// every write serializes on one lock; ShardedCache keeps scaling.
+
+// benchmarkCacheParallel runs a read-heavy mixed workload (90% Get, 10% Set)
+// over a fixed set of hot tokens, with a share of entries expiring so that
+// Get also takes the write path for lazy deletion.
+func benchmarkCacheParallel(b *testing.B, cache interface {
+	Cache
+	SetWithTTL(context.Context, *TokenScreeningResult, time.Duration) error
+}) {
+	ctx := context.Background()
+
+	const numTokens = 4096
+	results := make([]*TokenScreeningResult, numTokens)
+	for i := range results {
+		results[i] = &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i)}
+		_ = cache.Set(ctx, results[i])
+	}
+
+	var seed atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		// Per-goroutine xorshift so the benchmark measures the cache,
+		// not a shared random source.
+		x := seed.Add(0x9E3779B97F4A7C15)
+		for pb.Next() {
+			x ^= x << 13
+			x ^= x >> 7
+			x ^= x << 17
+
+			result := results[x%numTokens]
+			switch x % 10 {
+			case 0:
+				ttl := time.Minute
+				if x%40 == 0 {
+					ttl = time.Nanosecond // Expires immediately
+				}
+				_ = cache.SetWithTTL(ctx, result, ttl)
+			default:
+				cache.Get(ctx, result.TokenMint)
+			}
+		}
+	})
+}
+
+func BenchmarkInMemoryCache_Parallel(b *testing.B) {
+	cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	benchmarkCacheParallel(b, cache)
+}
+
+func BenchmarkShardedCache_Parallel(b *testing.B) {
+	cache := NewShardedCache(ShardedCacheConfig{TTL: time.Minute})
+	defer func() { _ = cache.Close() }()
+
+	benchmarkCacheParallel(b, cache)
+}
+
+func TestShardedCache_MaxBytes(t *testing.T) {
+	ctx := context.Background()
+	cache := NewShardedCache(ShardedCacheConfig{Shards: 4, MaxBytes: 16 << 10})
+	defer func() { _ = cache.Close() }()
+
+	for i := 0; i < 1000; i++ {
+		_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i)})
+	}
+
+	if used := cache.Bytes(); used > 16<<10 || used == 0 {
+		t.Errorf("expected usage within (0, 16KiB], got %d", used)
+	}
+}
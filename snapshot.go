@@ -0,0 +1,252 @@
+package tokenguard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// snapshotVersion is the current snapshot file format version.
+const snapshotVersion = 1
+
+// DefaultSnapshotInterval is the default interval between snapshots saved
+// by StartSnapshotLoop.
+const DefaultSnapshotInterval = time.Minute
+
+// ============================================================================
+// Cache Snapshots
+// ============================================================================
+
+// snapshotHeader is the first line of a snapshot.
+type snapshotHeader struct {
+	Version   int       `json:"v"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// snapshotEntry is one cached result in a snapshot.
+//
+// Expiration is stored as an absolute time so that time spent between
+// export and import (e.g., during a deploy) counts against the TTL.
+type snapshotEntry struct {
+	ExpiresAt time.Time             `json:"expiresAt"`
+	Result    *TokenScreeningResult `json:"result"`
+}
+
+// WriteSnapshot exports all unexpired entries, with their expiration times,
+// to w as JSON lines.
+//
+// The cache stays readable and writable while the snapshot is taken; entries
+// are copied under the read lock and encoded after it is released.
+// Returns the number of entries written.
+func (c *InMemoryCache) WriteSnapshot(w io.Writer) (int, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	entries := make([]snapshotEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			continue
+		}
+		entries = append(entries, snapshotEntry{
+			ExpiresAt: entry.expiresAt,
+			Result:    entry.result,
+		})
+	}
+	c.mu.RUnlock()
+
+	buffered := bufio.NewWriter(w)
+	enc := json.NewEncoder(buffered)
+
+	if err := enc.Encode(snapshotHeader{Version: snapshotVersion, CreatedAt: now}); err != nil {
+		return 0, fmt.Errorf("write snapshot header: %w", err)
+	}
+	for i := range entries {
+		if err := enc.Encode(&entries[i]); err != nil {
+			return i, fmt.Errorf("write snapshot entry: %w", err)
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return 0, fmt.Errorf("flush snapshot: %w", err)
+	}
+
+	return len(entries), nil
+}
+
+// ReadSnapshot preloads entries from a snapshot written by WriteSnapshot.
+//
+// Entries that expired since the snapshot was taken are discarded; the rest
+// keep their original expiration time. Entries already in the cache are
+// overwritten. Max size and memory budget apply as for Set.
+// Returns the number of entries loaded.
+func (c *InMemoryCache) ReadSnapshot(r io.Reader) (int, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return 0, fmt.Errorf("read snapshot header: %w", err)
+	}
+	if header.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version: %d", header.Version)
+	}
+
+	loaded := 0
+	for {
+		var entry snapshotEntry
+		err := dec.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			return loaded, nil
+		}
+		if err != nil {
+			return loaded, fmt.Errorf("read snapshot entry: %w", err)
+		}
+
+		ttl := time.Until(entry.ExpiresAt)
+		if entry.Result == nil || ttl <= 0 {
+			continue // Expired while the snapshot was on disk
+		}
+		if err := c.SetWithTTL(context.Background(), entry.Result, ttl); err != nil {
+			continue // Over budget on its own; skip like any other eviction
+		}
+		loaded++
+	}
+}
+
+// SaveSnapshot writes a snapshot to path atomically.
+//
+// The snapshot is written to a temporary file in the same directory and
+// renamed over path, so readers never see a partially written snapshot.
+// Returns the number of entries written.
+func (c *InMemoryCache) SaveSnapshot(path string) (int, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return 0, fmt.Errorf("create snapshot file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // No-op after successful rename
+
+	n, err := c.WriteSnapshot(tmp)
+	if err != nil {
+		_ = tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return 0, fmt.Errorf("sync snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("close snapshot file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, fmt.Errorf("replace snapshot file: %w", err)
+	}
+
+	return n, nil
+}
+
+// LoadSnapshot preloads the cache from a snapshot file.
+//
+// A missing file is not an error (first start); it loads zero entries.
+// Returns the number of entries loaded.
+func (c *InMemoryCache) LoadSnapshot(path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("open snapshot file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return c.ReadSnapshot(file)
+}
+
+// SnapshotLoop periodically saves snapshots of an InMemoryCache to a file.
+//
+// Typical use on a long-running bot:
+//
+//	cache := tokenguard.NewInMemoryCache(tokenguard.InMemoryCacheConfig{})
+//	if _, err := cache.LoadSnapshot(path); err != nil {
+//	    logger.Warn("failed to warm cache", zap.Error(err))
+//	}
+//	loop := tokenguard.StartSnapshotLoop(cache, path, time.Minute, logger)
+//	defer loop.Stop() // Saves a final snapshot on shutdown
+type SnapshotLoop struct {
+	cache    *InMemoryCache
+	path     string
+	interval time.Duration
+	logger   *zap.Logger
+
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// StartSnapshotLoop starts saving a snapshot of cache to path every interval.
+// Call Stop to save a final snapshot and stop the loop.
+//
+// An interval of zero or less uses DefaultSnapshotInterval.
+func StartSnapshotLoop(cache *InMemoryCache, path string, interval time.Duration, logger *zap.Logger) *SnapshotLoop {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if interval <= 0 {
+		interval = DefaultSnapshotInterval
+	}
+
+	l := &SnapshotLoop{
+		cache:    cache,
+		path:     path,
+		interval: interval,
+		logger:   logger,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go l.run()
+
+	return l
+}
+
+// Stop saves a final snapshot and stops the loop.
+// Returns the error from the final save, if any. Must be called only once.
+func (l *SnapshotLoop) Stop() error {
+	close(l.done)
+	<-l.stopped
+
+	_, err := l.cache.SaveSnapshot(l.path)
+	return err
+}
+
+// run saves snapshots until Stop is called.
+func (l *SnapshotLoop) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			n, err := l.cache.SaveSnapshot(l.path)
+			if err != nil {
+				l.logger.Warn("failed to save cache snapshot",
+					zap.String("path", l.path),
+					zap.Error(err),
+				)
+				continue
+			}
+			l.logger.Debug("saved cache snapshot",
+				zap.String("path", l.path),
+				zap.Int("entries", n),
+			)
+		case <-l.done:
+			return
+		}
+	}
+}
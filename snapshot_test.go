@@ -0,0 +1,148 @@
+package tokenguard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func TestInMemoryCache_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	defer func() { _ = source.Close() }()
+
+	_ = source.Set(ctx, &TokenScreeningResult{
+		TokenMint:      "mint-A",
+		Score:          70,
+		Details:        ScreeningDetails{LiquidityUSD: decimal.RequireFromString("12345.6789")},
+		FailureReasons: []string{"has_freeze_authority"},
+	})
+	_ = source.SetWithTTL(ctx, &TokenScreeningResult{TokenMint: "mint-B"}, 10*time.Millisecond)
+	_ = source.SetWithTTL(ctx, &TokenScreeningResult{TokenMint: "mint-C"}, time.Nanosecond)
+
+	var buf bytes.Buffer
+	n, err := source.WriteSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 unexpired entries written, got %d", n)
+	}
+
+	// mint-B expires while the snapshot is "on disk".
+	time.Sleep(20 * time.Millisecond)
+
+	target := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Hour})
+	defer func() { _ = target.Close() }()
+
+	loaded, err := target.ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if loaded != 1 {
+		t.Errorf("expected 1 entry loaded, got %d", loaded)
+	}
+
+	got, ttl, ok := target.GetWithTTL(ctx, "mint-A")
+	if !ok {
+		t.Fatal("expected mint-A to be restored")
+	}
+	if got.Score != 70 || !got.Details.LiquidityUSD.Equal(decimal.RequireFromString("12345.6789")) {
+		t.Errorf("restored result mismatch: %+v", got)
+	}
+	if ttl > time.Minute {
+		t.Errorf("expected original remaining TTL to be kept, got %v", ttl)
+	}
+	if _, ok := target.Get(ctx, "mint-B"); ok {
+		t.Error("expected entry that expired in the meantime to be discarded")
+	}
+}
+
+func TestInMemoryCache_ReadSnapshot_BadVersion(t *testing.T) {
+	cache := NewInMemoryCache(InMemoryCacheConfig{})
+	defer func() { _ = cache.Close() }()
+
+	if _, err := cache.ReadSnapshot(strings.NewReader(`{"v":99}` + "\n")); err == nil {
+		t.Error("expected error for unsupported snapshot version")
+	}
+}
+
+func TestInMemoryCache_SaveLoadSnapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+
+	// Missing file is a cold start, not an error.
+	empty := NewInMemoryCache(InMemoryCacheConfig{})
+	if n, err := empty.LoadSnapshot(path); err != nil || n != 0 {
+		t.Fatalf("LoadSnapshot() on missing file = %d, %v", n, err)
+	}
+
+	source := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	for i := 0; i < 5; i++ {
+		_ = source.Set(ctx, &TokenScreeningResult{TokenMint: fmt.Sprintf("mint-%d", i)})
+	}
+	if n, err := source.SaveSnapshot(path); err != nil || n != 5 {
+		t.Fatalf("SaveSnapshot() = %d, %v", n, err)
+	}
+
+	target := NewInMemoryCache(InMemoryCacheConfig{})
+	if n, err := target.LoadSnapshot(path); err != nil || n != 5 {
+		t.Fatalf("LoadSnapshot() = %d, %v", n, err)
+	}
+	if target.Size() != 5 {
+		t.Errorf("expected 5 entries, got %d", target.Size())
+	}
+
+	// No temporary files are left behind.
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("expected only the snapshot file, got %d files", len(files))
+	}
+}
+
+func TestSnapshotLoop_DefaultInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+	cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+
+	loop := StartSnapshotLoop(cache, path, 0, nil)
+	if loop.interval != DefaultSnapshotInterval {
+		t.Errorf("expected default interval, got %v", loop.interval)
+	}
+	if err := loop.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
+
+func TestSnapshotLoop(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+
+	cache := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	loop := StartSnapshotLoop(cache, path, 5*time.Millisecond, zap.NewNop())
+
+	_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: "periodic"})
+	time.Sleep(20 * time.Millisecond)
+
+	_ = cache.Set(ctx, &TokenScreeningResult{TokenMint: "final"})
+	if err := loop.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	restored := NewInMemoryCache(InMemoryCacheConfig{})
+	if _, err := restored.LoadSnapshot(path); err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	for _, mint := range []string{"periodic", "final"} {
+		if _, ok := restored.Get(ctx, mint); !ok {
+			t.Errorf("expected %s in snapshot", mint)
+		}
+	}
+}
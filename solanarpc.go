@@ -0,0 +1,498 @@
+package tokenguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRPCCommitment is the default commitment level for Solana RPC reads.
+const DefaultRPCCommitment = "confirmed"
+
+// SolanaRPCSource is the Source name of data read directly from Solana RPC.
+const SolanaRPCSource = "solana-rpc"
+
+// maxRPCResponseSize bounds the size of a JSON-RPC response body.
+const maxRPCResponseSize = 16 << 20
+
+// rpcInvalidParams is the JSON-RPC error code for invalid method parameters
+// (e.g., a malformed address).
+const rpcInvalidParams = -32602
+
+// ============================================================================
+// Solana JSON-RPC Client
+// ============================================================================
+
+// RPCError is a failed Solana JSON-RPC call.
+//
+// StatusCode is set when the HTTP request itself failed (e.g., 429 from a
+// rate-limited endpoint); otherwise Code and Message hold the JSON-RPC error.
+type RPCError struct {
+	Method     string `json:"-"`
+	StatusCode int    `json:"-"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *RPCError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("solana rpc %s: http %d: %s", e.Method, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("solana rpc %s: %s (code %d)", e.Method, e.Message, e.Code)
+}
+
+// SolanaRPCConfig holds connection settings for Solana RPC providers.
+type SolanaRPCConfig struct {
+	// Endpoint is the JSON-RPC URL (required).
+	Endpoint string
+
+	// Commitment is the commitment level for reads.
+	// Defaults to "confirmed" if empty.
+	Commitment string
+
+	// HTTPClient performs requests.
+	// Defaults to a client with a 10 second timeout if nil.
+	HTTPClient *http.Client
+}
+
+// rpcClient is a minimal Solana JSON-RPC 2.0 client over HTTP.
+type rpcClient struct {
+	endpoint   string
+	commitment string
+	http       *http.Client
+	nextID     atomic.Uint64
+}
+
+// newRPCClient validates cfg and creates a client.
+func newRPCClient(cfg SolanaRPCConfig) (*rpcClient, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("rpc endpoint is required")
+	}
+	if cfg.Commitment == "" {
+		cfg.Commitment = DefaultRPCCommitment
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &rpcClient{
+		endpoint:   cfg.Endpoint,
+		commitment: cfg.Commitment,
+		http:       cfg.HTTPClient,
+	}, nil
+}
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// call invokes method and decodes its result into result.
+func (c *rpcClient) call(ctx context.Context, method string, params []any, result any) error {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("encode %s request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("solana rpc %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRPCResponseSize))
+	if err != nil {
+		return fmt.Errorf("solana rpc %s: read response: %w", method, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(data))
+		if len(message) > 200 {
+			message = message[:200]
+		}
+		return &RPCError{Method: method, StatusCode: resp.StatusCode, Message: message}
+	}
+
+	var decoded rpcResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("solana rpc %s: decode response: %w", method, err)
+	}
+	if decoded.Error != nil {
+		decoded.Error.Method = method
+		return decoded.Error
+	}
+
+	if err := json.Unmarshal(decoded.Result, result); err != nil {
+		return fmt.Errorf("solana rpc %s: decode result: %w", method, err)
+	}
+	return nil
+}
+
+// rpcAccount is an account as returned with base64 encoding.
+type rpcAccount struct {
+	Data     []string `json:"data"` // [payload, "base64"]
+	Owner    string   `json:"owner"`
+	Lamports uint64   `json:"lamports"`
+}
+
+// bytes decodes the account data.
+func (a *rpcAccount) bytes() ([]byte, error) {
+	if len(a.Data) != 2 || a.Data[1] != "base64" {
+		return nil, fmt.Errorf("unexpected account data encoding")
+	}
+	return base64.StdEncoding.DecodeString(a.Data[0])
+}
+
+// getAccountInfo fetches an account. It returns nil if the account does
+// not exist.
+func (c *rpcClient) getAccountInfo(ctx context.Context, address string) (*rpcAccount, error) {
+	var result struct {
+		Value *rpcAccount `json:"value"`
+	}
+
+	err := c.call(ctx, "getAccountInfo", []any{
+		address,
+		map[string]string{"encoding": "base64", "commitment": c.commitment},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Value, nil
+}
+
+// rpcTokenAmount is a token balance as returned by the token RPC methods.
+type rpcTokenAmount struct {
+	Address  string `json:"address"` // Set by getTokenLargestAccounts only
+	Amount   string `json:"amount"`  // In base units
+	Decimals int    `json:"decimals"`
+}
+
+// getMultipleAccounts fetches several accounts, in batches of up to 100
+// (the RPC limit). Missing accounts are returned as nil entries.
+func (c *rpcClient) getMultipleAccounts(ctx context.Context, addresses []string) ([]*rpcAccount, error) {
+	const batchSize = 100
+
+	accounts := make([]*rpcAccount, 0, len(addresses))
+	for start := 0; start < len(addresses); start += batchSize {
+		batch := addresses[start:min(start+batchSize, len(addresses))]
+
+		var result struct {
+			Value []*rpcAccount `json:"value"`
+		}
+		err := c.call(ctx, "getMultipleAccounts", []any{
+			batch,
+			map[string]string{"encoding": "base64", "commitment": c.commitment},
+		}, &result)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Value) != len(batch) {
+			return nil, fmt.Errorf("solana rpc getMultipleAccounts: expected %d accounts, got %d",
+				len(batch), len(result.Value))
+		}
+
+		accounts = append(accounts, result.Value...)
+	}
+
+	return accounts, nil
+}
+
+// rpcFilter is a getProgramAccounts filter.
+type rpcFilter struct {
+	DataSize int        `json:"dataSize,omitempty"`
+	Memcmp   *rpcMemcmp `json:"memcmp,omitempty"`
+}
+
+// rpcMemcmp matches accounts with base58-encoded bytes at an offset.
+type rpcMemcmp struct {
+	Offset int    `json:"offset"`
+	Bytes  string `json:"bytes"`
+}
+
+// memcmpFilter matches accounts with the base58 value at offset.
+func memcmpFilter(offset int, value string) rpcFilter {
+	return rpcFilter{Memcmp: &rpcMemcmp{Offset: offset, Bytes: value}}
+}
+
+// rpcKeyedAccount is an account returned by getProgramAccounts.
+type rpcKeyedAccount struct {
+	Pubkey  string     `json:"pubkey"`
+	Account rpcAccount `json:"account"`
+}
+
+// getProgramAccounts returns the program's accounts matching all filters.
+func (c *rpcClient) getProgramAccounts(ctx context.Context, program string, filters []rpcFilter) ([]rpcKeyedAccount, error) {
+	var result []rpcKeyedAccount
+
+	err := c.call(ctx, "getProgramAccounts", []any{
+		program,
+		map[string]any{"encoding": "base64", "commitment": c.commitment, "filters": filters},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// rpcSignature is a transaction signature returned by
+// getSignaturesForAddress.
+type rpcSignature struct {
+	Signature string `json:"signature"`
+	Slot      uint64 `json:"slot"`
+	BlockTime *int64 `json:"blockTime"` // Unix seconds; nil if unavailable
+}
+
+// getSignaturesForAddress returns up to limit signatures of transactions
+// involving address, newest first, starting before the given signature
+// (or the latest transaction if before is empty).
+func (c *rpcClient) getSignaturesForAddress(ctx context.Context, address, before string, limit int) ([]rpcSignature, error) {
+	config := map[string]any{"limit": limit, "commitment": c.commitment}
+	if c.commitment == "processed" {
+		// Not supported by this method
+		config["commitment"] = DefaultRPCCommitment
+	}
+	if before != "" {
+		config["before"] = before
+	}
+
+	var result []rpcSignature
+	if err := c.call(ctx, "getSignaturesForAddress", []any{address, config}, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// rpcInstruction is an instruction of a transaction fetched with jsonParsed
+// encoding. Parsed is an object for instructions of programs the node can
+// parse (e.g., the system program), and absent or a string otherwise.
+type rpcInstruction struct {
+	Program   string          `json:"program"`
+	ProgramID string          `json:"programId"`
+	Parsed    json.RawMessage `json:"parsed"`
+}
+
+// rpcTokenBalance is a token account balance before or after a transaction.
+type rpcTokenBalance struct {
+	AccountIndex  int    `json:"accountIndex"`
+	Mint          string `json:"mint"`
+	Owner         string `json:"owner"`
+	UITokenAmount struct {
+		Amount   string `json:"amount"` // In base units
+		Decimals int32  `json:"decimals"`
+	} `json:"uiTokenAmount"`
+}
+
+// rpcTransaction is a confirmed transaction fetched with jsonParsed encoding.
+type rpcTransaction struct {
+	Slot      uint64 `json:"slot"`
+	BlockTime *int64 `json:"blockTime"` // Unix seconds; nil if unavailable
+	Meta      *struct {
+		Err               json.RawMessage `json:"err"`
+		InnerInstructions []struct {
+			Instructions []rpcInstruction `json:"instructions"`
+		} `json:"innerInstructions"`
+		PreTokenBalances  []rpcTokenBalance `json:"preTokenBalances"`
+		PostTokenBalances []rpcTokenBalance `json:"postTokenBalances"`
+	} `json:"meta"`
+	Transaction struct {
+		Message struct {
+			AccountKeys []struct {
+				Pubkey string `json:"pubkey"`
+				Signer bool   `json:"signer"`
+			} `json:"accountKeys"`
+			Instructions []rpcInstruction `json:"instructions"`
+		} `json:"message"`
+	} `json:"transaction"`
+}
+
+// failed reports whether the transaction failed (its effects were reverted).
+func (t *rpcTransaction) failed() bool {
+	return t.Meta != nil && len(t.Meta.Err) > 0 && string(t.Meta.Err) != "null"
+}
+
+// instructions returns the transaction's instructions, top-level first,
+// then inner instructions (e.g., from CPIs).
+func (t *rpcTransaction) instructions() []rpcInstruction {
+	all := append([]rpcInstruction{}, t.Transaction.Message.Instructions...)
+	if t.Meta != nil {
+		for _, inner := range t.Meta.InnerInstructions {
+			all = append(all, inner.Instructions...)
+		}
+	}
+	return all
+}
+
+// signers returns the set of accounts that signed the transaction.
+func (t *rpcTransaction) signers() map[string]struct{} {
+	signers := make(map[string]struct{})
+	for _, key := range t.Transaction.Message.AccountKeys {
+		if key.Signer {
+			signers[key.Pubkey] = struct{}{}
+		}
+	}
+	return signers
+}
+
+// getTransaction fetches a transaction with parsed instructions. It returns
+// nil if the transaction is not available (e.g., pruned by the node).
+func (c *rpcClient) getTransaction(ctx context.Context, signature string) (*rpcTransaction, error) {
+	commitment := c.commitment
+	if commitment == "processed" {
+		// Not supported by this method
+		commitment = DefaultRPCCommitment
+	}
+
+	var result *rpcTransaction
+	err := c.call(ctx, "getTransaction", []any{
+		signature,
+		map[string]any{
+			"encoding":                       "jsonParsed",
+			"commitment":                     commitment,
+			"maxSupportedTransactionVersion": 0,
+		},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getTokenLargestAccounts returns the largest token accounts of a mint
+// (up to 20, per the RPC method).
+func (c *rpcClient) getTokenLargestAccounts(ctx context.Context, mint string) ([]rpcTokenAmount, error) {
+	var result struct {
+		Value []rpcTokenAmount `json:"value"`
+	}
+
+	err := c.call(ctx, "getTokenLargestAccounts", []any{
+		mint,
+		map[string]string{"commitment": c.commitment},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Value, nil
+}
+
+// getTokenSupply returns the total supply of a mint.
+func (c *rpcClient) getTokenSupply(ctx context.Context, mint string) (*rpcTokenAmount, error) {
+	var result struct {
+		Value *rpcTokenAmount `json:"value"`
+	}
+
+	err := c.call(ctx, "getTokenSupply", []any{
+		mint,
+		map[string]string{"commitment": c.commitment},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Value == nil {
+		return nil, fmt.Errorf("solana rpc getTokenSupply: missing value")
+	}
+
+	return result.Value, nil
+}
+
+// classifyMintParamError maps the RPC's rejection of a mint address by the
+// token methods (getTokenSupply, getTokenLargestAccounts) to ErrTokenNotFound
+// or ErrNotMint. Other errors are returned unchanged.
+func classifyMintParamError(tokenMint string, err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != rpcInvalidParams {
+		return err
+	}
+
+	switch {
+	case strings.Contains(rpcErr.Message, "could not find account"):
+		return fmt.Errorf("mint %s: %w: %w", tokenMint, ErrTokenNotFound, err)
+	case strings.Contains(rpcErr.Message, "not a Token mint"):
+		return fmt.Errorf("mint %s: %w: %w", tokenMint, ErrNotMint, err)
+	default:
+		return err
+	}
+}
+
+// ============================================================================
+// RPC Security Provider
+// ============================================================================
+
+// SolanaRPCSecurityProvider reads token security data directly from the
+// mint account over Solana JSON-RPC, without a third-party indexer.
+//
+// It reports authorities, supply, decimals and Token-2022 extensions
+// (transfer fees, non-transferability, permanent delegate, transfer hook,
+// metadata update authority). Holder distribution is not part of the mint
+// account, so SecurityData.HolderSharesUnknown is set.
+type SolanaRPCSecurityProvider struct {
+	rpc *rpcClient
+}
+
+// NewSolanaRPCSecurityProvider creates a new RPC-backed security provider.
+func NewSolanaRPCSecurityProvider(cfg SolanaRPCConfig) (*SolanaRPCSecurityProvider, error) {
+	rpc, err := newRPCClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &SolanaRPCSecurityProvider{rpc: rpc}, nil
+}
+
+// GetSecurityData fetches and decodes the token's mint account.
+//
+// Returns an error wrapping ErrTokenNotFound if the account does not exist,
+// or ErrNotMint if it is not a mint of the SPL Token or Token-2022 program.
+func (p *SolanaRPCSecurityProvider) GetSecurityData(ctx context.Context, tokenMint string) (*SecurityData, error) {
+	account, err := p.rpc.getAccountInfo(ctx, tokenMint)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("mint %s: %w", tokenMint, ErrTokenNotFound)
+	}
+
+	data, err := account.bytes()
+	if err != nil {
+		return nil, fmt.Errorf("mint %s: %w", tokenMint, err)
+	}
+
+	mint, err := decodeMintAccount(account.Owner, data)
+	if err != nil {
+		return nil, fmt.Errorf("mint %s: %w", tokenMint, err)
+	}
+
+	return mint.securityData(time.Now()), nil
+}
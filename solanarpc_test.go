@@ -0,0 +1,463 @@
+package tokenguard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Addresses of the recorded accounts in testdata/rpc.
+const (
+	usdcMint           = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	revokedMint        = "4xZbQ9AgAtvsRmus4f6FH2yrSkCCU4affGWBcVaRHTZj"
+	transferFeeMint    = "Fim8v51LScgmVLnV2W7N43rL8HosZqDxHsvU2pkxxSfb"
+	nonTransferMint    = "7YL8Z4zihzcNtEYEV9wLH9nGFbRg6LebZ9xrstghwAZ2"
+	tokenAccountAddr   = "7k9EYKaDTzBcZ8VSGBkSDd1JRH7aadYswCmbPLFtDNVm"
+	systemWalletAddr   = "GfsJWjmGXMfct8JMR9Lm9ySUnniZbnGUTQDbT8ipWf9U"
+	missingAccountAddr = "11111111111111111111111111111112"
+)
+
+// rpcStandIn is an httptest Solana RPC node serving recorded responses
+// from testdata/rpc/<method>_<first param>.json.
+type rpcStandIn struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	calls  map[string]int
+	status int             // When non-zero, every request fails with this HTTP status
+	closed map[string]bool // Accounts served as missing
+}
+
+func newRPCStandIn(t *testing.T) *rpcStandIn {
+	t.Helper()
+
+	s := &rpcStandIn{calls: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// callCount returns how many times method was called.
+func (s *rpcStandIn) callCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// closeAccount makes getMultipleAccounts report an account as missing.
+func (s *rpcStandIn) closeAccount(address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed == nil {
+		s.closed = make(map[string]bool)
+	}
+	s.closed[address] = true
+}
+
+// failWith makes every subsequent request fail with an HTTP status.
+func (s *rpcStandIn) failWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *rpcStandIn) handle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.calls[req.Method]++
+	status := s.status
+	s.mu.Unlock()
+
+	if status != 0 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	switch req.Method {
+	case "getMultipleAccounts":
+		s.serveMultipleAccounts(w, req.ID, req.Params[0])
+		return
+	case "getProgramAccounts":
+		s.serveProgramAccounts(w, req.ID, req.Params)
+		return
+	case "getSignaturesForAddress":
+		s.serveSignatures(w, req.ID, req.Params)
+		return
+	}
+
+	var key string
+	if len(req.Params) > 0 {
+		_ = json.Unmarshal(req.Params[0], &key)
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "rpc", req.Method+"_"+key+".json"))
+	switch {
+	case err == nil:
+		_, _ = w.Write(data)
+	case req.Method == "getAccountInfo":
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"context":{"slot":1},"value":null}}`, req.ID)
+	default:
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":%d,"message":"Invalid param: %s"}}`,
+			req.ID, rpcInvalidParams, key)
+	}
+}
+
+// serveMultipleAccounts answers getMultipleAccounts from the recorded
+// getAccountInfo responses of each address.
+func (s *rpcStandIn) serveMultipleAccounts(w http.ResponseWriter, id uint64, params json.RawMessage) {
+	var addresses []string
+	if err := json.Unmarshal(params, &addresses); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+
+	values := make([]json.RawMessage, len(addresses))
+	for i, address := range addresses {
+		values[i] = json.RawMessage("null")
+		if closed[address] {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join("testdata", "rpc", "getAccountInfo_"+address+".json"))
+		if err != nil {
+			continue
+		}
+		var recorded struct {
+			Result struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(data, &recorded); err == nil {
+			values[i] = recorded.Result.Value
+		}
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  map[string]any{"context": map[string]any{"slot": 1}, "value": values},
+	})
+}
+
+// serveProgramAccounts answers getProgramAccounts from the recorded
+// accounts of the program, applying dataSize and memcmp filters.
+func (s *rpcStandIn) serveProgramAccounts(w http.ResponseWriter, id uint64, params []json.RawMessage) {
+	var (
+		program string
+		config  struct {
+			Filters []rpcFilter `json:"filters"`
+		}
+	)
+	if len(params) != 2 || json.Unmarshal(params[0], &program) != nil || json.Unmarshal(params[1], &config) != nil {
+		http.Error(w, "invalid params", http.StatusBadRequest)
+		return
+	}
+
+	var recorded struct {
+		Result []rpcKeyedAccount `json:"result"`
+	}
+	if data, err := os.ReadFile(filepath.Join("testdata", "rpc", "getProgramAccounts_"+program+".json")); err == nil {
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	matched := []rpcKeyedAccount{}
+	for _, account := range recorded.Result {
+		data, err := account.Account.bytes()
+		if err == nil && matchesFilters(data, config.Filters) {
+			matched = append(matched, account)
+		}
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": matched})
+}
+
+// serveSignatures answers getSignaturesForAddress from the recorded
+// signatures of the address (newest first), applying before and limit.
+func (s *rpcStandIn) serveSignatures(w http.ResponseWriter, id uint64, params []json.RawMessage) {
+	var (
+		address string
+		config  struct {
+			Before string `json:"before"`
+			Limit  int    `json:"limit"`
+		}
+	)
+	if len(params) != 2 || json.Unmarshal(params[0], &address) != nil || json.Unmarshal(params[1], &config) != nil {
+		http.Error(w, "invalid params", http.StatusBadRequest)
+		return
+	}
+
+	var recorded struct {
+		Result []rpcSignature `json:"result"`
+	}
+	if data, err := os.ReadFile(filepath.Join("testdata", "rpc", "getSignaturesForAddress_"+address+".json")); err == nil {
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	signatures := recorded.Result
+	if config.Before != "" {
+		for i, sig := range signatures {
+			if sig.Signature == config.Before {
+				signatures = signatures[i+1:]
+				break
+			}
+		}
+	}
+	if len(signatures) > config.Limit {
+		signatures = signatures[:config.Limit]
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": id, "result": append([]rpcSignature{}, signatures...)})
+}
+
+// matchesFilters reports whether account data passes every filter.
+func matchesFilters(data []byte, filters []rpcFilter) bool {
+	for _, filter := range filters {
+		if filter.DataSize != 0 && len(data) != filter.DataSize {
+			return false
+		}
+		if filter.Memcmp != nil {
+			want, err := base58Decode(filter.Memcmp.Bytes)
+			end := filter.Memcmp.Offset + len(want)
+			if err != nil || end > len(data) || !bytes.Equal(data[filter.Memcmp.Offset:end], want) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func newTestRPCSecurityProvider(t *testing.T, server *rpcStandIn) *SolanaRPCSecurityProvider {
+	t.Helper()
+
+	provider, err := NewSolanaRPCSecurityProvider(SolanaRPCConfig{Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("NewSolanaRPCSecurityProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestNewSolanaRPCSecurityProvider_RequiresEndpoint(t *testing.T) {
+	if _, err := NewSolanaRPCSecurityProvider(SolanaRPCConfig{}); err == nil {
+		t.Error("expected error for missing endpoint")
+	}
+}
+
+func TestSolanaRPCSecurityProvider_SPLToken(t *testing.T) {
+	provider := newTestRPCSecurityProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetSecurityData(context.Background(), usdcMint)
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+
+	if data.Source != SolanaRPCSource || data.AsOf.IsZero() {
+		t.Errorf("unexpected provenance: %q at %v", data.Source, data.AsOf)
+	}
+	if data.MintAuthority != "BJE5MMbqXjVwjAF7oxwPYXnTXDyspzZyt4vwenNw5ruG" {
+		t.Errorf("unexpected mint authority %q", data.MintAuthority)
+	}
+	if data.FreezeAuthority != "7dGbd2QZcCKcTndnHcTL8q7SMVXAkp688NTQYwrRCrar" {
+		t.Errorf("unexpected freeze authority %q", data.FreezeAuthority)
+	}
+	if data.Decimals != 6 {
+		t.Errorf("expected 6 decimals, got %d", data.Decimals)
+	}
+	if want := decimal.RequireFromString("8932194432.190355"); !data.Supply.Equal(want) {
+		t.Errorf("expected supply %s, got %s", want, data.Supply)
+	}
+	if data.IsToken2022 || len(data.Extensions) != 0 {
+		t.Errorf("expected classic SPL token, got %+v", data)
+	}
+	if !data.HolderSharesUnknown {
+		t.Error("expected holder shares to be reported unknown")
+	}
+}
+
+func TestSolanaRPCSecurityProvider_RevokedAuthorities(t *testing.T) {
+	provider := newTestRPCSecurityProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetSecurityData(context.Background(), revokedMint)
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+
+	if data.HasMintAuthority() || data.HasFreezeAuthority() {
+		t.Errorf("expected revoked authorities, got %q/%q", data.MintAuthority, data.FreezeAuthority)
+	}
+	if want := decimal.NewFromInt(1000000000); !data.Supply.Equal(want) {
+		t.Errorf("expected supply %s, got %s", want, data.Supply)
+	}
+}
+
+func TestSolanaRPCSecurityProvider_Token2022Extensions(t *testing.T) {
+	provider := newTestRPCSecurityProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetSecurityData(context.Background(), transferFeeMint)
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+
+	if !data.IsToken2022 {
+		t.Error("expected Token-2022 mint")
+	}
+	if !data.HasTransferFee || data.TransferFeeBPS != 250 {
+		t.Errorf("expected higher scheduled transfer fee of 250 bps, got %v/%d", data.HasTransferFee, data.TransferFeeBPS)
+	}
+	if data.PermanentDelegate != "3mFHCEN4X5caiqVJfBP6K5KY9Tp1WK7gKqgP9GhrLn2F" {
+		t.Errorf("unexpected permanent delegate %q", data.PermanentDelegate)
+	}
+	if data.TransferHookProgram != "DTj1n78xLgdSo3rT4tktx9Q8oJpmVn1Yt9qTj9s3QbRg" {
+		t.Errorf("unexpected transfer hook program %q", data.TransferHookProgram)
+	}
+	if !data.MutableMetadata {
+		t.Error("expected metadata with an update authority to be mutable")
+	}
+
+	want := []string{"transfer_fee_config", "permanent_delegate", "transfer_hook", "metadata_pointer", "token_metadata"}
+	if fmt.Sprint(data.Extensions) != fmt.Sprint(want) {
+		t.Errorf("expected extensions %v, got %v", want, data.Extensions)
+	}
+}
+
+func TestSolanaRPCSecurityProvider_NonTransferable(t *testing.T) {
+	provider := newTestRPCSecurityProvider(t, newRPCStandIn(t))
+
+	data, err := provider.GetSecurityData(context.Background(), nonTransferMint)
+	if err != nil {
+		t.Fatalf("GetSecurityData() error = %v", err)
+	}
+
+	if !data.NonTransferable {
+		t.Error("expected non-transferable mint")
+	}
+	if data.MutableMetadata {
+		t.Error("expected metadata without update authority to be immutable")
+	}
+	if data.MintAuthority != "GwpviyXau8U7mMy231TVdDGsG72kSohVDJwUDRPJsTMh" || data.Decimals != 0 {
+		t.Errorf("unexpected mint fields: %+v", data)
+	}
+}
+
+func TestSolanaRPCSecurityProvider_Errors(t *testing.T) {
+	ctx := context.Background()
+	server := newRPCStandIn(t)
+	provider := newTestRPCSecurityProvider(t, server)
+
+	tests := []struct {
+		name    string
+		address string
+		target  error
+		class   ErrorClass
+	}{
+		{"missing account", missingAccountAddr, ErrTokenNotFound, ErrorClassNotFound},
+		{"token account", tokenAccountAddr, ErrNotMint, ErrorClassPersistent},
+		{"system account", systemWalletAddr, ErrNotMint, ErrorClassPersistent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.GetSecurityData(ctx, tt.address)
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("expected %v, got %v", tt.target, err)
+			}
+			if got := ClassifyError(err); got != tt.class {
+				t.Errorf("expected class %v, got %v", tt.class, got)
+			}
+		})
+	}
+
+	server.failWith(http.StatusTooManyRequests)
+	_, err := provider.GetSecurityData(ctx, usdcMint)
+
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected HTTP 429 RPCError, got %v", err)
+	}
+	if ClassifyError(err) != ErrorClassTransient {
+		t.Error("expected rate limit to be transient")
+	}
+}
+
+func TestScreener_Screen_WithRPCSecurity(t *testing.T) {
+	provider := newTestRPCSecurityProvider(t, newRPCStandIn(t))
+
+	screener, err := New(Config{
+		SecurityDataProvider: provider,
+		MarketDataProvider: &staticMarketData{data: &MarketData{
+			LiquidityUSD: decimal.NewFromInt(1000000),
+		}},
+		Logger: zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	result, err := screener.Screen(context.Background(), usdcMint, ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+
+	if !contains(result.FailureReasons, "has_mint_authority") || !contains(result.FailureReasons, "has_freeze_authority") {
+		t.Errorf("expected authority failures, got %v", result.FailureReasons)
+	}
+
+	// The mint account has no creation time or holder shares to judge
+	if !reflect.DeepEqual(result.SkippedChecks, []string{"token_age", "holder_concentration", "lp_locked"}) {
+		t.Errorf("expected holder checks skipped, got %v", result.SkippedChecks)
+	}
+	if !result.Details.LPLockedPct.IsZero() {
+		t.Errorf("expected unknown LP lock, got %s%%", result.Details.LPLockedPct)
+	}
+
+	// A holder provider supplies the concentration
+	screener, err = New(Config{
+		SecurityDataProvider: provider,
+		MarketDataProvider:   &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(1000000)}},
+		HolderDataProvider: &staticHolderData{data: &HolderData{
+			TopHolderPct:    decimal.NewFromInt(40),
+			Top10HoldersPct: decimal.NewFromInt(70),
+		}},
+		Logger: zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	result, err = screener.Screen(context.Background(), usdcMint, ScreeningLevelNormal)
+	if err != nil {
+		t.Fatalf("Screen() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.SkippedChecks, []string{"token_age", "lp_locked"}) || !contains(result.FailureReasons, "high_single_holder:40.00%") {
+		t.Errorf("expected only token age and LP lock skipped, got %v (reasons %v)", result.SkippedChecks, result.FailureReasons)
+	}
+}
@@ -0,0 +1,122 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ============================================================================
+// Two-Tier Cache Implementation
+// ============================================================================
+
+// TieredCache layers a small in-process cache (L1) in front of a slower
+// shared cache (L2), such as RedisCache.
+//
+// Reads check L1 first, then L2; L2 hits are promoted into L1. Writes go
+// through to both tiers. An entry never lives in L1 longer than it would in
+// L2: when L2 can report remaining TTLs (ExpiringCache), the L1 TTL is capped
+// by them, so replicas never serve a result the shared store has expired.
+//
+// This gives replicas shared results without paying a network round trip
+// on every screening of a hot token.
+type TieredCache struct {
+	l1 *InMemoryCache
+	l2 Cache
+}
+
+// TieredCacheConfig holds configuration for TieredCache.
+type TieredCacheConfig struct {
+	// L1 is the fast in-process tier (required).
+	// Its TTL is the maximum time an entry is served without consulting L2.
+	L1 *InMemoryCache
+
+	// L2 is the slower shared tier (required).
+	L2 Cache
+}
+
+// NewTieredCache creates a new two-tier cache.
+func NewTieredCache(cfg TieredCacheConfig) (*TieredCache, error) {
+	if cfg.L1 == nil {
+		return nil, fmt.Errorf("L1 cache is required")
+	}
+	if cfg.L2 == nil {
+		return nil, fmt.Errorf("L2 cache is required")
+	}
+
+	return &TieredCache{
+		l1: cfg.L1,
+		l2: cfg.L2,
+	}, nil
+}
+
+// Get retrieves a cached screening result from L1, falling back to L2.
+//
+// L2 hits are promoted into L1 with a TTL capped by the remaining L2 TTL.
+func (c *TieredCache) Get(ctx context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	result, _, ok := c.GetWithTTL(ctx, tokenMint)
+	return result, ok
+}
+
+// GetWithTTL is like Get but also returns the entry's remaining time-to-live.
+func (c *TieredCache) GetWithTTL(ctx context.Context, tokenMint string) (*TokenScreeningResult, time.Duration, bool) {
+	if result, ttl, ok := c.l1.GetWithTTL(ctx, tokenMint); ok {
+		return result, ttl, true
+	}
+
+	var (
+		result *TokenScreeningResult
+		ttl    = c.l1.TTL()
+		ok     bool
+	)
+	if expiring, isExpiring := c.l2.(ExpiringCache); isExpiring {
+		var remaining time.Duration
+		result, remaining, ok = expiring.GetWithTTL(ctx, tokenMint)
+		ttl = min(ttl, remaining)
+	} else {
+		result, ok = c.l2.Get(ctx, tokenMint)
+	}
+	if !ok {
+		return nil, 0, false
+	}
+
+	if ttl > 0 {
+		// L1 is in-process and cannot fail in practice; ignore the error
+		// like Screener ignores cache write failures.
+		_ = c.l1.SetWithTTL(ctx, result, ttl)
+	}
+
+	return result, ttl, true
+}
+
+// Set stores a screening result in both tiers.
+//
+// L1 is written even if L2 fails, so that during an outage of the shared
+// store this replica still serves its own results; the L2 error is
+// returned. The L1 TTL is capped by the L2 TTL when L2 reports one.
+func (c *TieredCache) Set(ctx context.Context, result *TokenScreeningResult) error {
+	l1Err := c.l1.SetWithTTL(ctx, result, c.TTL())
+
+	if err := c.l2.Set(ctx, result); err != nil {
+		return fmt.Errorf("set L2: %w", err)
+	}
+	return l1Err
+}
+
+// TTL returns the time-to-live applied to newly stored L1 entries.
+func (c *TieredCache) TTL() time.Duration {
+	ttl := c.l1.TTL()
+	if expiring, ok := c.l2.(ExpiringCache); ok {
+		ttl = min(ttl, expiring.TTL())
+	}
+	return ttl
+}
+
+// Delete removes an entry from both tiers.
+//
+// L2 is only affected if it supports deletion, as all caches in this
+// package do.
+func (c *TieredCache) Delete(ctx context.Context, tokenMint string) error {
+	c.l1.Delete(ctx, tokenMint)
+	return deleteFromCache(ctx, c.l2, tokenMint)
+}
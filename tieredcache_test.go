@@ -0,0 +1,179 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingCache wraps a cache to count reads that reach it.
+type countingCache struct {
+	Cache
+	gets int
+}
+
+func (c *countingCache) Get(ctx context.Context, tokenMint string) (*TokenScreeningResult, bool) {
+	c.gets++
+	return c.Cache.Get(ctx, tokenMint)
+}
+
+func TestNewTieredCache_Validation(t *testing.T) {
+	l1 := NewInMemoryCache(InMemoryCacheConfig{})
+	l2 := NewInMemoryCache(InMemoryCacheConfig{})
+
+	if _, err := NewTieredCache(TieredCacheConfig{L2: l2}); err == nil {
+		t.Error("expected error for missing L1")
+	}
+	if _, err := NewTieredCache(TieredCacheConfig{L1: l1}); err == nil {
+		t.Error("expected error for missing L2")
+	}
+}
+
+func TestTieredCache_PromotesL2Hits(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	l2 := &countingCache{Cache: NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})}
+
+	cache, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	// Populated by another replica: present only in L2.
+	if err := l2.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint", Score: 80}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := cache.Get(ctx, "test-mint")
+	if !ok || got.Score != 80 {
+		t.Fatal("expected L2 hit")
+	}
+	if _, ok := l1.Get(ctx, "test-mint"); !ok {
+		t.Error("expected L2 hit to be promoted into L1")
+	}
+
+	// Subsequent reads are served by L1.
+	cache.Get(ctx, "test-mint")
+	if l2.gets != 1 {
+		t.Errorf("expected 1 L2 read, got %d", l2.gets)
+	}
+}
+
+func TestTieredCache_WritesThrough(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	l2 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+
+	cache, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, ok := l1.Get(ctx, "test-mint"); !ok {
+		t.Error("expected entry in L1")
+	}
+	if _, ok := l2.Get(ctx, "test-mint"); !ok {
+		t.Error("expected entry in L2")
+	}
+
+	if err := cache.Delete(ctx, "test-mint"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := l1.Get(ctx, "test-mint"); ok {
+		t.Error("expected entry removed from L1")
+	}
+	if _, ok := l2.Get(ctx, "test-mint"); ok {
+		t.Error("expected entry removed from L2")
+	}
+}
+
+// failingCache is a cache whose writes fail, like a shared store during an
+// outage.
+type failingCache struct {
+	Cache
+}
+
+func (c *failingCache) Set(_ context.Context, _ *TokenScreeningResult) error {
+	return errors.New("connection refused")
+}
+
+func TestTieredCache_L2Outage(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+	l2 := &failingCache{Cache: NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})}
+
+	cache, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint", Score: 70}); err == nil {
+		t.Error("expected L2 error to be returned")
+	}
+	if got, ok := cache.Get(ctx, "test-mint"); !ok || got.Score != 70 {
+		t.Error("expected L1 to serve the result during the L2 outage")
+	}
+}
+
+func TestTieredCache_L1TTLCappedByL2(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Hour})
+	l2 := NewInMemoryCache(InMemoryCacheConfig{TTL: time.Minute})
+
+	cache, err := NewTieredCache(TieredCacheConfig{L1: l1, L2: l2})
+	if err != nil {
+		t.Fatalf("NewTieredCache() error = %v", err)
+	}
+
+	// On write, L1 uses at most the L2 TTL.
+	if err := cache.Set(ctx, &TokenScreeningResult{TokenMint: "written"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, ttl, _ := l1.GetWithTTL(ctx, "written"); ttl > time.Minute {
+		t.Errorf("expected L1 TTL capped at 1m on write, got %v", ttl)
+	}
+
+	// On promotion, L1 uses at most the remaining L2 TTL.
+	if err := l2.SetWithTTL(ctx, &TokenScreeningResult{TokenMint: "promoted"}, 20*time.Millisecond); err != nil {
+		t.Fatalf("SetWithTTL() error = %v", err)
+	}
+	if _, ok := cache.Get(ctx, "promoted"); !ok {
+		t.Fatal("expected L2 hit")
+	}
+	if _, ttl, _ := l1.GetWithTTL(ctx, "promoted"); ttl > 20*time.Millisecond {
+		t.Errorf("expected L1 TTL capped by remaining L2 TTL, got %v", ttl)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := cache.Get(ctx, "promoted"); ok {
+		t.Error("expected L1 entry to expire together with L2")
+	}
+}
+
+func TestTieredCache_WithRedisL2(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+
+	replicaA := newTestRedisCache(t, server, RedisCacheConfig{TTL: time.Minute})
+	replicaB := newTestRedisCache(t, server, RedisCacheConfig{TTL: time.Minute})
+
+	cacheA, _ := NewTieredCache(TieredCacheConfig{L1: NewInMemoryCache(InMemoryCacheConfig{}), L2: replicaA})
+	cacheB, _ := NewTieredCache(TieredCacheConfig{L1: NewInMemoryCache(InMemoryCacheConfig{}), L2: replicaB})
+
+	if err := cacheA.Set(ctx, &TokenScreeningResult{TokenMint: "test-mint", Score: 77}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ttl, ok := cacheB.GetWithTTL(ctx, "test-mint")
+	if !ok || got.Score != 77 {
+		t.Fatal("expected replica B to see replica A's result")
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Errorf("expected remaining TTL within (0, 1m], got %v", ttl)
+	}
+}
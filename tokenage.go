@@ -0,0 +1,174 @@
+package tokenguard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Defaults for SolanaRPCCreationProvider.
+const (
+	DefaultCreationMaxPages  = 5
+	DefaultCreationCacheSize = 10000
+)
+
+// signaturesPageSize is the most signatures getSignaturesForAddress
+// returns per call.
+const signaturesPageSize = 1000
+
+// ============================================================================
+// Creation Time
+// ============================================================================
+
+// CreationTime is when a token was created.
+type CreationTime struct {
+	// At is when the token was created. If Exact is false, the token is
+	// only known to have existed at At, so its age is at least now - At.
+	At time.Time
+
+	// Exact reports whether At is the creation time itself.
+	Exact bool
+}
+
+// CreationTimeProvider looks up when tokens were created, for the token age
+// check. It is consulted when security data does not report CreatedAt.
+// A zero At means the creation time is unknown.
+type CreationTimeProvider interface {
+	GetCreationTime(ctx context.Context, tokenMint string) (CreationTime, error)
+}
+
+// ============================================================================
+// RPC Creation Provider
+// ============================================================================
+
+// SolanaRPCCreationProvider finds a mint's creation time from its oldest
+// transaction over Solana JSON-RPC (getSignaturesForAddress).
+//
+// Signatures are paged from newest to oldest. Busy mints can have more
+// history than MaxPages covers; the lookup then stops early and reports
+// the oldest transaction seen as an inexact time, which bounds the age from
+// below. Exact creation times never change and are cached.
+//
+// Features:
+//   - Thread-safe
+//   - Bounded paging per lookup
+//   - Cache of exact creation times
+type SolanaRPCCreationProvider struct {
+	rpc       *rpcClient
+	maxPages  int
+	pageSize  int
+	cacheSize int
+
+	mu    sync.Mutex
+	cache map[string]time.Time
+}
+
+// SolanaRPCCreationConfig holds configuration for SolanaRPCCreationProvider.
+type SolanaRPCCreationConfig struct {
+	// RPC holds connection settings (Endpoint is required).
+	RPC SolanaRPCConfig
+
+	// MaxPages is the maximum number of signature pages (1000 each) read
+	// per lookup.
+	// Defaults to 5 if zero.
+	MaxPages int
+
+	// CacheSize is the maximum number of cached creation times.
+	// Defaults to 10000 if zero.
+	CacheSize int
+}
+
+// NewSolanaRPCCreationProvider creates a new RPC-backed creation time provider.
+func NewSolanaRPCCreationProvider(cfg SolanaRPCCreationConfig) (*SolanaRPCCreationProvider, error) {
+	rpc, err := newRPCClient(cfg.RPC)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxPages == 0 {
+		cfg.MaxPages = DefaultCreationMaxPages
+	}
+	if cfg.CacheSize == 0 {
+		cfg.CacheSize = DefaultCreationCacheSize
+	}
+	if cfg.MaxPages < 0 || cfg.CacheSize < 0 {
+		return nil, fmt.Errorf("max pages and cache size must not be negative")
+	}
+
+	return &SolanaRPCCreationProvider{
+		rpc:       rpc,
+		maxPages:  cfg.MaxPages,
+		pageSize:  signaturesPageSize,
+		cacheSize: cfg.CacheSize,
+		cache:     make(map[string]time.Time),
+	}, nil
+}
+
+// GetCreationTime returns the block time of the mint's oldest transaction.
+//
+// Returns an error wrapping ErrTokenNotFound if the mint has no
+// transactions.
+func (p *SolanaRPCCreationProvider) GetCreationTime(ctx context.Context, tokenMint string) (CreationTime, error) {
+	p.mu.Lock()
+	at, ok := p.cache[tokenMint]
+	p.mu.Unlock()
+	if ok {
+		return CreationTime{At: at, Exact: true}, nil
+	}
+
+	var (
+		before string
+		oldest time.Time
+		seen   bool
+		timed  bool // The last signature seen has a block time
+		first  bool // The last signature seen is the mint's first
+	)
+	for page := 0; page < p.maxPages; page++ {
+		signatures, err := p.rpc.getSignaturesForAddress(ctx, tokenMint, before, p.pageSize)
+		if err != nil {
+			return CreationTime{}, err
+		}
+
+		for _, sig := range signatures {
+			seen = true
+			timed = sig.BlockTime != nil
+			if timed {
+				oldest = time.Unix(*sig.BlockTime, 0)
+			}
+		}
+
+		if len(signatures) < p.pageSize {
+			// Reached the mint's first transaction
+			if !seen {
+				return CreationTime{}, fmt.Errorf("mint %s: no transactions: %w", tokenMint, ErrTokenNotFound)
+			}
+			first = true
+			break
+		}
+		before = signatures[len(signatures)-1].Signature
+	}
+
+	if oldest.IsZero() {
+		return CreationTime{}, fmt.Errorf("mint %s: transactions have no block time", tokenMint)
+	}
+	exact := first && timed
+	if exact {
+		p.store(tokenMint, oldest)
+	}
+	return CreationTime{At: oldest, Exact: exact}, nil
+}
+
+// store caches an exact creation time, evicting an arbitrary entry when
+// the cache is full.
+func (p *SolanaRPCCreationProvider) store(tokenMint string, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.cache) >= p.cacheSize {
+		for mint := range p.cache {
+			delete(p.cache, mint)
+			break
+		}
+	}
+	p.cache[tokenMint] = at
+}
@@ -0,0 +1,189 @@
+package tokenguard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// revokedMintCreatedAt is the block time of revokedMint's first recorded
+// transaction.
+var revokedMintCreatedAt = time.Date(2024, 5, 31, 16, 0, 0, 0, time.UTC)
+
+func newTestCreationProvider(t *testing.T, server *rpcStandIn, cfg SolanaRPCCreationConfig) *SolanaRPCCreationProvider {
+	t.Helper()
+
+	cfg.RPC.Endpoint = server.URL
+	provider, err := NewSolanaRPCCreationProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewSolanaRPCCreationProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestNewSolanaRPCCreationProvider_Validation(t *testing.T) {
+	if _, err := NewSolanaRPCCreationProvider(SolanaRPCCreationConfig{}); err == nil {
+		t.Error("expected error for missing endpoint")
+	}
+	if _, err := NewSolanaRPCCreationProvider(SolanaRPCCreationConfig{
+		RPC:      SolanaRPCConfig{Endpoint: "http://localhost"},
+		MaxPages: -1,
+	}); err == nil {
+		t.Error("expected error for negative max pages")
+	}
+}
+
+func TestSolanaRPCCreationProvider_GetCreationTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		mint      string
+		pageSize  int
+		maxPages  int
+		wantAt    time.Time
+		wantExact bool
+		wantPages int
+	}{
+		{"single page", revokedMint, signaturesPageSize, 5, revokedMintCreatedAt, true, 1},
+		{"pages to the first transaction", revokedMint, 2, 5, revokedMintCreatedAt, true, 3},
+		{"exact page boundary", revokedMint, 5, 5, revokedMintCreatedAt, true, 2},
+		{"history beyond max pages", revokedMint, 2, 1, time.Unix(1745000000, 0), false, 1},
+		{"first transaction without block time", transferFeeMint, signaturesPageSize, 5, time.Unix(1750000000, 0), false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRPCStandIn(t)
+			provider := newTestCreationProvider(t, server, SolanaRPCCreationConfig{MaxPages: tt.maxPages})
+			provider.pageSize = tt.pageSize
+
+			created, err := provider.GetCreationTime(context.Background(), tt.mint)
+			if err != nil {
+				t.Fatalf("GetCreationTime() error = %v", err)
+			}
+			if !created.At.Equal(tt.wantAt) || created.Exact != tt.wantExact {
+				t.Errorf("expected %v (exact %v), got %v (exact %v)", tt.wantAt, tt.wantExact, created.At, created.Exact)
+			}
+			if got := server.callCount("getSignaturesForAddress"); got != tt.wantPages {
+				t.Errorf("expected %d pages, got %d", tt.wantPages, got)
+			}
+		})
+	}
+}
+
+func TestSolanaRPCCreationProvider_CachesExactTimes(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider := newTestCreationProvider(t, server, SolanaRPCCreationConfig{CacheSize: 1})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := provider.GetCreationTime(ctx, revokedMint); err != nil {
+			t.Fatalf("GetCreationTime() error = %v", err)
+		}
+		if _, err := provider.GetCreationTime(ctx, transferFeeMint); err != nil {
+			t.Fatalf("GetCreationTime() error = %v", err)
+		}
+	}
+
+	// Only the exact time is cached; the inexact one is looked up again.
+	if got := server.callCount("getSignaturesForAddress"); got != 3 {
+		t.Errorf("expected 3 lookups, got %d", got)
+	}
+}
+
+func TestSolanaRPCCreationProvider_NoTransactions(t *testing.T) {
+	server := newRPCStandIn(t)
+	provider := newTestCreationProvider(t, server, SolanaRPCCreationConfig{})
+
+	_, err := provider.GetCreationTime(context.Background(), missingAccountAddr)
+	if !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+}
+
+// staticCreationTime is a CreationTimeProvider returning a fixed time.
+type staticCreationTime struct {
+	created CreationTime
+	calls   int
+}
+
+func (p *staticCreationTime) GetCreationTime(_ context.Context, _ string) (CreationTime, error) {
+	p.calls++
+	return p.created, nil
+}
+
+func TestScreener_Screen_TokenAge(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		createdAt   time.Time // Reported by security data
+		provider    *staticCreationTime
+		level       ScreeningLevel
+		wantPassed  bool
+		wantReason  string
+		wantExact   bool
+		wantSkipped bool
+	}{
+		{"old enough for strict", now.Add(-48 * time.Hour), nil, ScreeningLevelStrict, true, "", true, false},
+		{"too young for strict", now.Add(-3 * time.Hour), nil, ScreeningLevelStrict, false, "token_too_young:", true, false},
+		{"young token allowed by relaxed", now.Add(-time.Minute), nil, ScreeningLevelRelaxed, true, "", true, false},
+		{"security data preferred over provider", now.Add(-48 * time.Hour), &staticCreationTime{created: CreationTime{At: now.Add(-time.Minute), Exact: true}}, ScreeningLevelStrict, true, "", true, false},
+		{"creation time from provider", time.Time{}, &staticCreationTime{created: CreationTime{At: now.Add(-10 * time.Minute), Exact: true}}, ScreeningLevelNormal, false, "token_too_young:", true, false},
+		{"lower bound is skipped", time.Time{}, &staticCreationTime{created: CreationTime{At: now.Add(-10 * time.Minute)}}, ScreeningLevelNormal, true, "", false, true},
+		{"old enough lower bound", time.Time{}, &staticCreationTime{created: CreationTime{At: now.Add(-48 * time.Hour)}}, ScreeningLevelStrict, true, "", false, false},
+		{"lower bound fails strict", time.Time{}, &staticCreationTime{created: CreationTime{At: now.Add(-10 * time.Minute)}}, ScreeningLevelStrict, false, "token_age_unknown", false, true},
+		{"unknown age is skipped", time.Time{}, &staticCreationTime{}, ScreeningLevelNormal, true, "", false, true},
+		{"unknown age fails strict", time.Time{}, &staticCreationTime{}, ScreeningLevelStrict, false, "token_age_unknown", false, true},
+		{"without a creation source", time.Time{}, nil, ScreeningLevelStrict, false, "token_age_unknown", false, true},
+		{"unknown age allowed by relaxed", time.Time{}, nil, ScreeningLevelRelaxed, true, "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				SecurityDataProvider: &staticSecurityData{data: &SecurityData{CreatedAt: tt.createdAt}},
+				MarketDataProvider:   &staticMarketData{data: &MarketData{LiquidityUSD: decimal.NewFromInt(100_000)}},
+				Logger:               zap.NewNop(),
+			}
+			if tt.provider != nil {
+				cfg.CreationTimeProvider = tt.provider
+			}
+			screener, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := screener.Screen(context.Background(), "test-mint", tt.level)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+
+			if result.Passed != tt.wantPassed {
+				t.Errorf("expected passed=%v, got %v (reasons %v)", tt.wantPassed, result.Passed, result.FailureReasons)
+			}
+			if !tt.wantPassed && !strings.HasPrefix(result.FailureReasons[0], tt.wantReason) {
+				t.Errorf("expected %s reason, got %v", tt.wantReason, result.FailureReasons)
+			}
+			if got := contains(result.SkippedChecks, "token_age"); got != tt.wantSkipped {
+				t.Errorf("expected token_age skipped=%v, got %v", tt.wantSkipped, result.SkippedChecks)
+			}
+			if result.Details.CreatedAtExact != tt.wantExact {
+				t.Errorf("expected exact=%v, got %v", tt.wantExact, result.Details.CreatedAtExact)
+			}
+			if !result.Details.CreatedAt.IsZero() && result.Details.TokenAge <= 0 {
+				t.Errorf("expected token age reported, got %v", result.Details.TokenAge)
+			}
+			if !tt.createdAt.IsZero() && !result.Details.CreatedAt.Equal(tt.createdAt) {
+				t.Errorf("expected creation time %v from security data, got %v", tt.createdAt, result.Details.CreatedAt)
+			}
+			if tt.provider != nil && !tt.createdAt.IsZero() && tt.provider.calls != 0 {
+				t.Error("expected provider not consulted when security data reports CreatedAt")
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Package tokenguardtest provides fake providers and prebuilt token
+// scenarios for testing code that depends on tokenguard.Screener.
+//
+// Example:
+//
+//	provider := tokenguardtest.NewProvider(tokenguardtest.Config{})
+//	provider.Set(mint, tokenguardtest.FreshRug(mint))
+//	screener, err := tokenguard.New(provider.ScreenerConfig())
+//	...
+//	result, err := screener.Screen(ctx, mint, tokenguard.ScreeningLevelNormal)
+package tokenguardtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"go.uber.org/zap"
+
+	tokenguard "github.com/Laminar-Bot/solana-token-guard"
+)
+
+// Errors matching what Birdeye returns, for error injection.
+var (
+	// ErrNotFound is returned for tokens Birdeye does not know.
+	// Classified as not found.
+	ErrNotFound = &birdeye.APIError{StatusCode: 404, Message: "token not found"}
+
+	// ErrRateLimited is returned when the API key's rate limit is exceeded.
+	// Classified as transient.
+	ErrRateLimited = &birdeye.APIError{StatusCode: 429, Message: "too many requests"}
+
+	// ErrUnavailable is returned during provider outages.
+	// Classified as transient.
+	ErrUnavailable = &birdeye.APIError{StatusCode: 503, Message: "service unavailable"}
+)
+
+// MethodGetCreationTime names creation time lookups for FailNext and Calls.
+const MethodGetCreationTime = "GetCreationTime"
+
+// Token is the data served for one mint.
+type Token struct {
+	Security *birdeye.TokenSecurity
+	Overview *birdeye.TokenOverview
+
+	// CreatedAt is served as the mint's creation time (zero if unknown).
+	CreatedAt time.Time
+}
+
+// ============================================================================
+// Fake Provider
+// ============================================================================
+
+// Provider is a fake Birdeye client implementing
+// tokenguard.TokenSecurityProvider and tokenguard.TokenOverviewProvider,
+// and a tokenguard.CreationTimeProvider.
+//
+// Unknown mints fail with ErrNotFound, as Birdeye does. Each call returns
+// a copy of the stored data, so callers cannot corrupt the fixture.
+//
+// Features:
+//   - Thread-safe
+//   - Per-mint data, usually from a scenario (see CleanBlueChip etc.)
+//   - Latency injection (respects context cancellation)
+//   - Error injection: persistent per mint, or queued for the next calls
+//   - Call counting per method
+type Provider struct {
+	mu      sync.Mutex
+	tokens  map[string]Token
+	fail    map[string]error
+	queued  map[string][]error // Next errors by method
+	calls   map[string]int     // Calls by method
+	latency time.Duration
+}
+
+// Config holds configuration for Provider.
+type Config struct {
+	// Latency delays every call.
+	// Set to 0 for immediate responses.
+	Latency time.Duration
+}
+
+// NewProvider creates a fake provider with no tokens.
+func NewProvider(cfg Config) *Provider {
+	return &Provider{
+		tokens:  make(map[string]Token),
+		fail:    make(map[string]error),
+		queued:  make(map[string][]error),
+		calls:   make(map[string]int),
+		latency: cfg.Latency,
+	}
+}
+
+// ScreenerConfig returns a screener configuration using the provider for
+// security, overview and creation time data, with a no-op logger. Set
+// further fields on the result as needed.
+func (p *Provider) ScreenerConfig() tokenguard.Config {
+	return tokenguard.Config{
+		SecurityProvider:     p,
+		OverviewProvider:     p,
+		CreationTimeProvider: p,
+		Logger:               zap.NewNop(),
+	}
+}
+
+// Set serves token for mint, replacing any previous data.
+// A nil Security or Overview fails that method with ErrNotFound.
+func (p *Provider) Set(mint string, token Token) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokens[mint] = token
+}
+
+// Fail makes every call for mint return err until cleared with a nil err.
+func (p *Provider) Fail(mint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		delete(p.fail, mint)
+		return
+	}
+	p.fail[mint] = err
+}
+
+// FailNext queues errors for the next calls to method
+// (tokenguard.MethodGetTokenSecurity, tokenguard.MethodGetTokenOverview or
+// MethodGetCreationTime), whatever the mint. Calls succeed again once the queue is drained.
+func (p *Provider) FailNext(method string, errs ...error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queued[method] = append(p.queued[method], errs...)
+}
+
+// SetLatency changes the delay applied to every call.
+func (p *Provider) SetLatency(latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latency = latency
+}
+
+// Calls returns the number of calls made to method.
+func (p *Provider) Calls(method string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls[method]
+}
+
+// GetTokenSecurity returns a copy of the mint's security data.
+func (p *Provider) GetTokenSecurity(ctx context.Context, address string) (*birdeye.TokenSecurity, error) {
+	token, err := p.serve(ctx, tokenguard.MethodGetTokenSecurity, address)
+	if err != nil {
+		return nil, err
+	}
+	if token.Security == nil {
+		return nil, notFound("/defi/token_security", address)
+	}
+	return copySecurity(token.Security), nil
+}
+
+// GetTokenOverview returns a copy of the mint's overview.
+func (p *Provider) GetTokenOverview(ctx context.Context, address string) (*birdeye.TokenOverview, error) {
+	token, err := p.serve(ctx, tokenguard.MethodGetTokenOverview, address)
+	if err != nil {
+		return nil, err
+	}
+	if token.Overview == nil {
+		return nil, notFound("/defi/token_overview", address)
+	}
+	overview := *token.Overview
+	if overview.Extensions != nil {
+		extensions := *overview.Extensions
+		overview.Extensions = &extensions
+	}
+	return &overview, nil
+}
+
+// GetCreationTime returns the mint's creation time, exactly.
+// Unknown mints and tokens without CreatedAt report an unknown time.
+func (p *Provider) GetCreationTime(ctx context.Context, tokenMint string) (tokenguard.CreationTime, error) {
+	token, err := p.serve(ctx, MethodGetCreationTime, tokenMint)
+	if err != nil || token.CreatedAt.IsZero() {
+		return tokenguard.CreationTime{}, err
+	}
+	return tokenguard.CreationTime{At: token.CreatedAt, Exact: true}, nil
+}
+
+// serve counts the call, applies latency and injected errors, and looks up
+// the mint. Unknown mints yield an empty Token.
+func (p *Provider) serve(ctx context.Context, method, address string) (Token, error) {
+	p.mu.Lock()
+	p.calls[method]++
+	latency := p.latency
+	var err error
+	if queued := p.queued[method]; len(queued) > 0 {
+		err, p.queued[method] = queued[0], queued[1:]
+	} else if failure, ok := p.fail[address]; ok {
+		err = failure
+	}
+	token := p.tokens[address]
+	p.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Token{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// notFound returns a Birdeye 404 for address at the endpoint path.
+func notFound(path, address string) error {
+	return &birdeye.APIError{
+		StatusCode: ErrNotFound.StatusCode,
+		Message:    ErrNotFound.Message,
+		Path:       fmt.Sprintf("%s?address=%s", path, address),
+	}
+}
+
+// copySecurity deep-copies security data.
+func copySecurity(security *birdeye.TokenSecurity) *birdeye.TokenSecurity {
+	dup := *security
+	if security.MintAuthority != nil {
+		dup.MintAuthority = ptr(*security.MintAuthority)
+	}
+	if security.FreezeAuthority != nil {
+		dup.FreezeAuthority = ptr(*security.FreezeAuthority)
+	}
+	if security.TransferFeeData != nil {
+		fees := *security.TransferFeeData
+		dup.TransferFeeData = &fees
+	}
+	return &dup
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
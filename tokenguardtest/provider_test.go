@@ -0,0 +1,96 @@
+package tokenguardtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tokenguard "github.com/Laminar-Bot/solana-token-guard"
+)
+
+func TestProvider_UnknownMintNotFound(t *testing.T) {
+	provider := NewProvider(Config{})
+
+	_, err := provider.GetTokenSecurity(context.Background(), "unknown")
+	if tokenguard.ClassifyError(err) != tokenguard.ErrorClassNotFound {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	provider.Set("overview-only", Token{Overview: CleanBlueChip("overview-only").Overview})
+	if _, err := provider.GetTokenSecurity(context.Background(), "overview-only"); tokenguard.ClassifyError(err) != tokenguard.ErrorClassNotFound {
+		t.Errorf("expected not found for missing security data, got %v", err)
+	}
+}
+
+func TestProvider_ReturnsCopies(t *testing.T) {
+	provider := NewProvider(Config{})
+	provider.Set("rug", FreshRug("rug"))
+	ctx := context.Background()
+
+	security, err := provider.GetTokenSecurity(ctx, "rug")
+	if err != nil {
+		t.Fatalf("GetTokenSecurity() error = %v", err)
+	}
+	*security.MintAuthority = ""
+
+	again, err := provider.GetTokenSecurity(ctx, "rug")
+	if err != nil {
+		t.Fatalf("GetTokenSecurity() error = %v", err)
+	}
+	if !again.HasMintAuthority() {
+		t.Error("expected caller mutation not to reach the fixture")
+	}
+}
+
+func TestProvider_ErrorInjection(t *testing.T) {
+	provider := NewProvider(Config{})
+	provider.Set("chip", CleanBlueChip("chip"))
+	ctx := context.Background()
+
+	provider.FailNext(tokenguard.MethodGetTokenOverview, ErrRateLimited, ErrUnavailable)
+	for _, want := range []error{ErrRateLimited, ErrUnavailable, nil} {
+		if _, err := provider.GetTokenOverview(ctx, "chip"); !errors.Is(err, want) {
+			t.Errorf("expected %v, got %v", want, err)
+		}
+	}
+	if _, err := provider.GetTokenSecurity(ctx, "chip"); err != nil {
+		t.Errorf("expected queued errors to affect only overview calls, got %v", err)
+	}
+
+	provider.Fail("chip", ErrUnavailable)
+	if _, err := provider.GetTokenSecurity(ctx, "chip"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected injected failure, got %v", err)
+	}
+	provider.Fail("chip", nil)
+	if _, err := provider.GetTokenSecurity(ctx, "chip"); err != nil {
+		t.Errorf("expected failure cleared, got %v", err)
+	}
+
+	if got := provider.Calls(tokenguard.MethodGetTokenSecurity); got != 3 {
+		t.Errorf("expected 3 security calls, got %d", got)
+	}
+	if got := provider.Calls(tokenguard.MethodGetTokenOverview); got != 3 {
+		t.Errorf("expected 3 overview calls, got %d", got)
+	}
+}
+
+func TestProvider_Latency(t *testing.T) {
+	provider := NewProvider(Config{Latency: 20 * time.Millisecond})
+	provider.Set("chip", CleanBlueChip("chip"))
+
+	start := time.Now()
+	if _, err := provider.GetTokenOverview(context.Background(), "chip"); err != nil {
+		t.Fatalf("GetTokenOverview() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected injected latency, took %v", elapsed)
+	}
+
+	provider.SetLatency(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := provider.GetTokenOverview(ctx, "chip"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package tokenguardtest
+
+import (
+	"time"
+
+	"github.com/Laminar-Bot/birdeye-go"
+	"github.com/shopspring/decimal"
+
+	tokenguard "github.com/Laminar-Bot/solana-token-guard"
+)
+
+// ============================================================================
+// Scenarios
+// ============================================================================
+
+// Scenario is a prebuilt token profile.
+type Scenario struct {
+	// Name identifies the scenario in test output.
+	Name string
+
+	// Build returns the scenario's data for mint.
+	Build func(mint string) Token
+
+	// PassesAt lists the screening levels at which the scenario passes
+	// the default thresholds.
+	PassesAt []tokenguard.ScreeningLevel
+}
+
+// Scenarios returns every prebuilt scenario, for table-driven tests.
+func Scenarios() []Scenario {
+	return []Scenario{
+		{
+			Name:  "clean_blue_chip",
+			Build: CleanBlueChip,
+			PassesAt: []tokenguard.ScreeningLevel{
+				tokenguard.ScreeningLevelStrict,
+				tokenguard.ScreeningLevelNormal,
+				tokenguard.ScreeningLevelRelaxed,
+			},
+		},
+		{Name: "fresh_rug", Build: FreshRug},
+		{Name: "honeypot", Build: Honeypot},
+		{Name: "whale_concentrated", Build: WhaleConcentrated},
+		{
+			Name:  "token2022_with_fees",
+			Build: Token2022WithFees,
+			PassesAt: []tokenguard.ScreeningLevel{
+				tokenguard.ScreeningLevelNormal,
+				tokenguard.ScreeningLevelRelaxed,
+			},
+		},
+	}
+}
+
+// CleanBlueChip is an established token: authorities revoked, deep
+// liquidity, broad distribution and steady two-way trading.
+func CleanBlueChip(mint string) Token {
+	return Token{
+		Security: &birdeye.TokenSecurity{
+			CreatorAddress:     "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM",
+			CreatorBalance:     "4500000",
+			CreatorPercentage:  "0.45",
+			Top10HolderBalance: "184200000",
+			Top10HolderPercent: "18.42",
+			Top10UserBalance:   "97300000",
+			Top10UserPercent:   "9.73",
+			TotalSupply:        "1000000000",
+		},
+		Overview: &birdeye.TokenOverview{
+			Address:                mint,
+			Symbol:                 "BLUE",
+			Name:                   "Blue Chip",
+			Decimals:               6,
+			Liquidity:              decimal.NewFromInt(12_450_000),
+			Price:                  decimal.RequireFromString("1.8342"),
+			PriceChange24hPercent:  decimal.RequireFromString("-1.27"),
+			Volume24h:              decimal.NewFromInt(4_860_000),
+			Volume24hUSD:           decimal.NewFromInt(8_914_000),
+			Volume24hChangePercent: decimal.RequireFromString("3.4"),
+			MarketCap:              decimal.NewFromInt(1_834_200_000),
+			Supply:                 decimal.NewFromInt(1_000_000_000),
+			CirculatingSupply:      decimal.NewFromInt(1_000_000_000),
+			Holder:                 846_213,
+			Trade24h:               61_870,
+			Buy24h:                 31_254,
+			Sell24h:                30_616,
+			UniqueWallet24h:        18_402,
+			LastTradeUnixTime:      time.Now().Add(-3 * time.Second).Unix(),
+			Extensions: &birdeye.TokenExtensions{
+				Coingecko: "blue-chip",
+				Twitter:   "https://twitter.com/bluechip",
+				Website:   "https://bluechip.example",
+			},
+		},
+		CreatedAt: time.Now().Add(-2 * 365 * 24 * time.Hour),
+	}
+}
+
+// FreshRug is a token launched minutes ago whose creator kept mint and
+// freeze authority and most of the supply, with thin liquidity.
+func FreshRug(mint string) Token {
+	creator := "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+	return Token{
+		Security: &birdeye.TokenSecurity{
+			MintAuthority:      ptr(creator),
+			FreezeAuthority:    ptr(creator),
+			CreatorAddress:     creator,
+			CreatorBalance:     "612000000",
+			CreatorPercentage:  "61.2",
+			OwnerAddress:       creator,
+			OwnerBalance:       "612000000",
+			OwnerPercentage:    "61.2",
+			Top10HolderBalance: "948000000",
+			Top10HolderPercent: "94.8",
+			Top10UserBalance:   "336000000",
+			Top10UserPercent:   "33.6",
+			TotalSupply:        "1000000000",
+			MutableMetadata:    true,
+		},
+		Overview: &birdeye.TokenOverview{
+			Address:               mint,
+			Symbol:                "MOON",
+			Name:                  "MoonShot Inu",
+			Decimals:              6,
+			Liquidity:             decimal.RequireFromString("3184.22"),
+			Price:                 decimal.RequireFromString("0.00000412"),
+			PriceChange24hPercent: decimal.RequireFromString("812.5"),
+			Volume24hUSD:          decimal.RequireFromString("14210.8"),
+			MarketCap:             decimal.NewFromInt(4120),
+			Supply:                decimal.NewFromInt(1_000_000_000),
+			CirculatingSupply:     decimal.NewFromInt(1_000_000_000),
+			Holder:                37,
+			Trade24h:              214,
+			Buy24h:                181,
+			Sell24h:               33,
+			UniqueWallet24h:       41,
+			LastTradeUnixTime:     time.Now().Add(-20 * time.Second).Unix(),
+		},
+		CreatedAt: time.Now().Add(-20 * time.Minute),
+	}
+}
+
+// Honeypot is a token anyone can buy but only the deployer can sell: the
+// freeze authority freezes buyers' accounts, so trading is one-way.
+func Honeypot(mint string) Token {
+	deployer := "FRzN4ow1AqN2fJmGZwtTJ8dYZxXVBeyptNacfz2RxAr3"
+	return Token{
+		Security: &birdeye.TokenSecurity{
+			FreezeAuthority:    ptr(deployer),
+			CreatorAddress:     deployer,
+			CreatorBalance:     "21500000",
+			CreatorPercentage:  "2.15",
+			Top10HolderBalance: "338000000",
+			Top10HolderPercent: "33.8",
+			Top10UserBalance:   "188000000",
+			Top10UserPercent:   "18.8",
+			TotalSupply:        "1000000000",
+		},
+		Overview: &birdeye.TokenOverview{
+			Address:               mint,
+			Symbol:                "SAFE",
+			Name:                  "SafeYield",
+			Decimals:              9,
+			Liquidity:             decimal.RequireFromString("86420.55"),
+			Price:                 decimal.RequireFromString("0.0431"),
+			PriceChange24hPercent: decimal.RequireFromString("146.3"),
+			Volume24hUSD:          decimal.RequireFromString("211940.1"),
+			MarketCap:             decimal.NewFromInt(43_100_000),
+			Supply:                decimal.NewFromInt(1_000_000_000),
+			CirculatingSupply:     decimal.NewFromInt(1_000_000_000),
+			Holder:                1_912,
+			Trade24h:              1_844,
+			Buy24h:                1_843,
+			Sell24h:               1,
+			UniqueWallet24h:       1_790,
+			LastTradeUnixTime:     time.Now().Add(-5 * time.Second).Unix(),
+		},
+		CreatedAt: time.Now().Add(-6 * time.Hour),
+	}
+}
+
+// WhaleConcentrated is a liquid token with authorities revoked whose
+// supply is held by a few wallets, one of them holding nearly a third.
+func WhaleConcentrated(mint string) Token {
+	return Token{
+		Security: &birdeye.TokenSecurity{
+			CreatorAddress:     "HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH",
+			CreatorBalance:     "312000000",
+			CreatorPercentage:  "31.2",
+			Top10HolderBalance: "782000000",
+			Top10HolderPercent: "78.2",
+			Top10UserBalance:   "431000000",
+			Top10UserPercent:   "43.1",
+			TotalSupply:        "1000000000",
+		},
+		Overview: &birdeye.TokenOverview{
+			Address:               mint,
+			Symbol:                "WHL",
+			Name:                  "Whale Coin",
+			Decimals:              6,
+			Liquidity:             decimal.NewFromInt(412_300),
+			Price:                 decimal.RequireFromString("0.0872"),
+			PriceChange24hPercent: decimal.RequireFromString("-6.8"),
+			Volume24hUSD:          decimal.NewFromInt(96_400),
+			MarketCap:             decimal.NewFromInt(87_200_000),
+			Supply:                decimal.NewFromInt(1_000_000_000),
+			CirculatingSupply:     decimal.NewFromInt(1_000_000_000),
+			Holder:                2_304,
+			Trade24h:              702,
+			Buy24h:                344,
+			Sell24h:               358,
+			UniqueWallet24h:       211,
+			LastTradeUnixTime:     time.Now().Add(-2 * time.Minute).Unix(),
+		},
+		CreatedAt: time.Now().Add(-40 * 24 * time.Hour),
+	}
+}
+
+// Token2022WithFees is a Token-2022 mint with a 5% transfer fee that its
+// fee authority can raise, and mutable metadata. Authorities are revoked,
+// but liquidity is below the strict minimum.
+func Token2022WithFees(mint string) Token {
+	authority := "5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1"
+	return Token{
+		Security: &birdeye.TokenSecurity{
+			CreatorAddress:     authority,
+			CreatorBalance:     "18000000",
+			CreatorPercentage:  "1.8",
+			Top10HolderBalance: "296000000",
+			Top10HolderPercent: "29.6",
+			Top10UserBalance:   "151000000",
+			Top10UserPercent:   "15.1",
+			TotalSupply:        "1000000000",
+			IsToken2022:        true,
+			TransferFeeEnable:  true,
+			TransferFeeData: &birdeye.TransferFeeData{
+				TransferFeeBPS:    500,
+				MaxFee:            "18446744073709551615",
+				FeeAuthority:      authority,
+				WithdrawAuthority: authority,
+			},
+			MutableMetadata: true,
+		},
+		Overview: &birdeye.TokenOverview{
+			Address:               mint,
+			Symbol:                "TAX",
+			Name:                  "Tax Token",
+			Decimals:              9,
+			Liquidity:             decimal.NewFromInt(38_750),
+			Price:                 decimal.RequireFromString("0.00218"),
+			PriceChange24hPercent: decimal.RequireFromString("12.4"),
+			Volume24hUSD:          decimal.NewFromInt(21_300),
+			MarketCap:             decimal.NewFromInt(2_180_000),
+			Supply:                decimal.NewFromInt(1_000_000_000),
+			CirculatingSupply:     decimal.NewFromInt(1_000_000_000),
+			Holder:                688,
+			Trade24h:              312,
+			Buy24h:                170,
+			Sell24h:               142,
+			UniqueWallet24h:       96,
+			LastTradeUnixTime:     time.Now().Add(-40 * time.Second).Unix(),
+		},
+		CreatedAt: time.Now().Add(-3 * 24 * time.Hour),
+	}
+}
@@ -0,0 +1,73 @@
+package tokenguardtest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	tokenguard "github.com/Laminar-Bot/solana-token-guard"
+)
+
+func TestScenarios_ScreenAsDocumented(t *testing.T) {
+	levels := []tokenguard.ScreeningLevel{
+		tokenguard.ScreeningLevelStrict,
+		tokenguard.ScreeningLevelNormal,
+		tokenguard.ScreeningLevelRelaxed,
+	}
+
+	for _, scenario := range Scenarios() {
+		t.Run(scenario.Name, func(t *testing.T) {
+			const mint = "So11111111111111111111111111111111111111112"
+			provider := NewProvider(Config{})
+			provider.Set(mint, scenario.Build(mint))
+
+			screener, err := tokenguard.New(provider.ScreenerConfig())
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			for _, level := range levels {
+				result, err := screener.Screen(context.Background(), mint, level)
+				if err != nil {
+					t.Fatalf("Screen(%s) error = %v", level, err)
+				}
+				if want := slices.Contains(scenario.PassesAt, level); result.Passed != want {
+					t.Errorf("%s: expected passed=%v, got %v (reasons %v)",
+						level, want, result.Passed, result.FailureReasons)
+				}
+			}
+		})
+	}
+}
+
+func TestScenarios_Details(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(string) Token
+		check func(d tokenguard.ScreeningDetails) bool
+	}{
+		{"fresh rug keeps mint authority", FreshRug, func(d tokenguard.ScreeningDetails) bool { return d.HasMintAuthority && d.TokenAge < time.Hour }},
+		{"honeypot keeps freeze authority", Honeypot, func(d tokenguard.ScreeningDetails) bool { return d.HasFreezeAuthority }},
+		{"token-2022 charges transfer fees", Token2022WithFees, func(d tokenguard.ScreeningDetails) bool { return d.IsToken2022 && d.HasTransferFee }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewProvider(Config{})
+			provider.Set("mint", tt.build("mint"))
+			screener, err := tokenguard.New(provider.ScreenerConfig())
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			result, err := screener.Screen(context.Background(), "mint", tokenguard.ScreeningLevelRelaxed)
+			if err != nil {
+				t.Fatalf("Screen() error = %v", err)
+			}
+			if !tt.check(result.Details) {
+				t.Errorf("unexpected details %+v", result.Details)
+			}
+		})
+	}
+}
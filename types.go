@@ -4,7 +4,12 @@
 //   - Authority validation (mint/freeze authority status)
 //   - Liquidity analysis (minimum LP thresholds)
 //   - Holder concentration analysis (top holder distribution)
+//   - Insider cluster detection (holders linked by funding)
+//   - Launch sniper detection (supply bought in the first slots and bundles)
 //   - LP lock verification (estimated based on creator holdings)
+//   - Market activity (volume, holder count, liquidity to market cap, price moves)
+//   - Token age (launch recency)
+//   - Creator reputation (history of the token's creator wallet)
 //
 // The library supports three screening levels (Strict, Normal, Relaxed) with
 // configurable thresholds for each check.
@@ -43,6 +48,14 @@ const (
 	//   - Min LP locked: 80%
 	//   - Max top 10 holders: 40%
 	//   - Max single holder: 15%
+	//   - Max funding-linked holders: 25%
+	//   - Max held by launch snipers: 10%, by launch bundle: 5%
+	//   - Min 24h volume: $50,000
+	//   - Min holders: 500
+	//   - Min liquidity to market cap: 0.5%
+	//   - Max price change: 50%
+	//   - Min token age: 24 hours
+	//   - Creator: not flagged, no rugged tokens
 	ScreeningLevelStrict ScreeningLevel = "strict"
 
 	// ScreeningLevelNormal requires:
@@ -52,6 +65,14 @@ const (
 	//   - Min LP locked: 50%
 	//   - Max top 10 holders: 60%
 	//   - Max single holder: 25%
+	//   - Max funding-linked holders: 35%
+	//   - Max held by launch snipers: 20%, by launch bundle: 10%
+	//   - Min 24h volume: $10,000
+	//   - Min holders: 100
+	//   - Min liquidity to market cap: 0.25%
+	//   - Max price change: 150%
+	//   - Min token age: 1 hour
+	//   - Creator: not flagged, no rugged tokens
 	ScreeningLevelNormal ScreeningLevel = "normal"
 
 	// ScreeningLevelRelaxed requires:
@@ -61,6 +82,14 @@ const (
 	//   - Min LP locked: 25%
 	//   - Max top 10 holders: 75%
 	//   - Max single holder: 35%
+	//   - Max funding-linked holders: 50%
+	//   - Max held by launch snipers: 35%, by launch bundle: 20%
+	//   - Min 24h volume: $1,000
+	//   - Min holders: 25
+	//   - Min liquidity to market cap: 0.1%
+	//   - Max price change: 500%
+	//   - Token age: not required
+	//   - Creator: not flagged, at most 1 rugged token
 	ScreeningLevelRelaxed ScreeningLevel = "relaxed"
 )
 
@@ -98,6 +127,68 @@ type TokenScreeningResult struct {
 
 	// ScreenedAt is when the screening was performed.
 	ScreenedAt time.Time `json:"screenedAt"`
+
+	// CreditsUsed is the number of API credits spent on provider calls
+	// for this screening (see Config.CallCosts). Data served from the data
+	// cache costs nothing; a cached result reports its original cost.
+	CreditsUsed int64 `json:"creditsUsed"`
+
+	// Disagreements lists fields on which data sources disagreed, when
+	// composite providers in consensus mode were used.
+	Disagreements []Disagreement `json:"disagreements,omitempty"`
+
+	// DataAsOf maps each data source used (e.g., "birdeye") to when its
+	// data was observed; the oldest observation if it served several kinds
+	// of data. Sources not reporting an observation time are omitted.
+	DataAsOf map[string]time.Time `json:"dataAsOf,omitempty"`
+
+	// StaleData lists the kinds of data ("liquidity", "holder") that were
+	// older than Config.MaxDataAge.
+	StaleData []string `json:"staleData,omitempty"`
+
+	// SkippedChecks lists checks that could not be judged because their
+	// input was unknown (e.g., "holder_concentration" when the security
+	// source cannot report holder shares). They neither pass nor fail.
+	SkippedChecks []string `json:"skippedChecks,omitempty"`
+}
+
+// Clone returns a deep copy of the result.
+//
+// Caches use it to isolate stored results from callers, so that a caller
+// modifying a returned result (e.g., appending to FailureReasons) cannot
+// affect other readers. Decimal values are immutable and safely shared.
+func (r *TokenScreeningResult) Clone() *TokenScreeningResult {
+	if r == nil {
+		return nil
+	}
+
+	clone := *r
+	if r.FailureReasons != nil {
+		clone.FailureReasons = make([]string, len(r.FailureReasons))
+		copy(clone.FailureReasons, r.FailureReasons)
+	}
+	if r.Disagreements != nil {
+		clone.Disagreements = make([]Disagreement, len(r.Disagreements))
+		for i, d := range r.Disagreements {
+			clone.Disagreements[i] = d.clone()
+		}
+	}
+	if r.DataAsOf != nil {
+		clone.DataAsOf = make(map[string]time.Time, len(r.DataAsOf))
+		for source, asOf := range r.DataAsOf {
+			clone.DataAsOf[source] = asOf
+		}
+	}
+	if r.StaleData != nil {
+		clone.StaleData = make([]string, len(r.StaleData))
+		copy(clone.StaleData, r.StaleData)
+	}
+	if r.SkippedChecks != nil {
+		clone.SkippedChecks = make([]string, len(r.SkippedChecks))
+		copy(clone.SkippedChecks, r.SkippedChecks)
+	}
+
+	return &clone
 }
 
 // ScreeningDetails contains detailed information about each check performed.
@@ -107,7 +198,8 @@ type ScreeningDetails struct {
 	HasFreezeAuthority bool `json:"hasFreezeAuthority"` // Token has active freeze authority
 
 	// Liquidity check
-	LiquidityUSD decimal.Decimal `json:"liquidityUsd"` // Total liquidity in USD
+	LiquidityUSD      decimal.Decimal `json:"liquidityUsd"`      // Total liquidity in USD
+	LiquidityReported bool            `json:"liquidityReported"` // Liquidity was measured; zero means drained
 
 	// LP lock check (estimated from creator holdings)
 	LPLockedPct decimal.Decimal `json:"lpLockedPct"` // Estimated LP locked percentage
@@ -116,11 +208,45 @@ type ScreeningDetails struct {
 	Top10HoldersPct decimal.Decimal `json:"top10HoldersPct"` // % held by top 10 holders
 	TopHolderPct    decimal.Decimal `json:"topHolderPct"`    // % held by single top holder
 
+	// Insider cluster check (zero if disabled or no holders are linked)
+	InsiderClusters       int             `json:"insiderClusters"`       // Groups of funding-linked holders
+	LargestClusterPct     decimal.Decimal `json:"largestClusterPct"`     // % held by the largest group
+	LargestClusterWallets int             `json:"largestClusterWallets"` // Holders in the largest group
+
+	// Launch snipers
+	SniperWallets   int             `json:"sniperWallets"`   // Wallets that bought in the first slots
+	SniperBoughtPct decimal.Decimal `json:"sniperBoughtPct"` // % of supply they bought
+	SniperHeldPct   decimal.Decimal `json:"sniperHeldPct"`   // % of supply they still hold
+	BundleWallets   int             `json:"bundleWallets"`   // Wallets that bought in the launch slot
+	BundleHeldPct   decimal.Decimal `json:"bundleHeldPct"`   // % of supply they still hold
+
 	// Token-2022 specific features
 	IsToken2022     bool `json:"isToken2022"`     // Uses Token-2022 program
 	HasTransferFee  bool `json:"hasTransferFee"`  // Has transfer fee enabled
 	NonTransferable bool `json:"nonTransferable"` // Token is non-transferable (soulbound)
 	MutableMetadata bool `json:"mutableMetadata"` // Metadata can be changed
+
+	// Market activity checks (zero if the provider does not report them)
+	Volume24hUSD       decimal.Decimal `json:"volume24hUsd"`       // Trading volume over 24 hours
+	MarketCapUSD       decimal.Decimal `json:"marketCapUsd"`       // Market capitalization
+	Holders            int             `json:"holders"`            // Unique holders
+	LiquidityToMcapPct decimal.Decimal `json:"liquidityToMcapPct"` // Liquidity as % of market cap
+	PriceChange24hPct  decimal.Decimal `json:"priceChange24hPct"`  // Price change over 24 hours
+
+	// Token age check (zero if the creation time is unknown). If
+	// CreatedAtExact is false, CreatedAt is a time at which the token
+	// already existed and TokenAge is a lower bound.
+	CreatedAt      time.Time     `json:"createdAt"`
+	CreatedAtExact bool          `json:"createdAtExact"`
+	TokenAge       time.Duration `json:"tokenAge"`
+
+	// Creator reputation check (empty if disabled or the creator is
+	// unknown). Counts cover the creator's other tokens.
+	CreatorAddress      string `json:"creatorAddress,omitempty"`
+	CreatorTokens       int    `json:"creatorTokens"`       // Other tokens screened
+	CreatorFailedTokens int    `json:"creatorFailedTokens"` // Of which failed screening
+	CreatorRuggedTokens int    `json:"creatorRuggedTokens"` // Of which lost their liquidity
+	CreatorFlagged      bool   `json:"creatorFlagged"`      // Creator flagged manually
 }
 
 // ScreeningThresholds defines thresholds for each screening level.
@@ -146,4 +272,53 @@ type ScreeningThresholds struct {
 
 	// MaxTopHolderPct is the maximum percentage that can be held by a single holder.
 	MaxTopHolderPct decimal.Decimal
+
+	// MaxClusterPct is the maximum percentage that can be held by a group
+	// of holders linked through funding (see Config.TransactionHistoryProvider).
+	MaxClusterPct decimal.Decimal
+
+	// MaxSniperHeldPct is the maximum percentage that can still be held by
+	// wallets that bought in the token's first slots (see
+	// Config.SniperWindowSlots).
+	MaxSniperHeldPct decimal.Decimal
+
+	// MaxBundleHeldPct is the maximum percentage that can still be held by
+	// wallets that bought together in the launch slot.
+	MaxBundleHeldPct decimal.Decimal
+
+	// MinVolume24hUSD is the minimum trading volume over 24 hours.
+	MinVolume24hUSD decimal.Decimal
+
+	// MinHolders is the minimum number of unique holders.
+	MinHolders int
+
+	// MinLiquidityToMcapPct is the minimum liquidity as a percentage of
+	// market cap. A thin pool behind a large market cap means the price
+	// cannot be realized when selling.
+	MinLiquidityToMcapPct decimal.Decimal
+
+	// MaxPriceChangePct is the maximum absolute price change over any
+	// reported window (24h and any shorter ones). Larger moves indicate
+	// pumps or dumps in progress.
+	MaxPriceChangePct decimal.Decimal
+
+	// MinTokenAge is the minimum time since the token was created. Tokens
+	// of unknown age, or known only to be older than a lower bound, are
+	// listed in SkippedChecks and not failed unless RequireTokenAge is set.
+	// Set to 0 to accept tokens of any age.
+	MinTokenAge time.Duration
+
+	// RequireTokenAge fails tokens whose age cannot be established to be
+	// at least MinTokenAge.
+	RequireTokenAge bool
+
+	// MaxCreatorRuggedTokens is the maximum number of the creator's other
+	// tokens that lost their liquidity. Flagged creators always fail.
+	MaxCreatorRuggedTokens int
+
+	// MaxCreatorFailedPct is the maximum percentage of the creator's other
+	// tokens that failed screening before the score is penalized. Judged
+	// once the creator has launched at least 3 other tokens; it does not
+	// fail the screening by itself.
+	MaxCreatorFailedPct decimal.Decimal
 }